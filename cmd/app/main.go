@@ -26,15 +26,28 @@ func main() {
 			app.NewPostgresConnection,
 			app.NewTracerProvider,
 			app.NewRopositories,
+			app.NewEventBus,
 			app.NewUseCases,
 			security.NewTokenBlacklist,
+			app.NewRateLimiter,
+			app.NewIdempotencyStore,
+			app.NewMetricsRegistry,
+			app.NewBlobStore,
+			app.NewMailer,
 			app.NewHandlers,
 			gin.New,
 		),
 		fx.Invoke(
 			app.InitTracerProvider,
+			app.SubscribeNotifications,
+			app.SubscribeRecurrence,
 			app.RegisterRoutes,
 			app.RunHTTPServer,
+			app.RunMetricsServer,
+			app.RunTrashPurge,
+			app.RunAutoArchive,
+			app.RunEmailDigest,
+			app.RunRecurrence,
 		),
 	)
 	app.Run()
@@ -1,74 +1,275 @@
 package main
 
 import (
+	"errors"
+	"flag"
 	"fmt"
-	"github.com/golang-migrate/migrate/v4"
-	_ "github.com/golang-migrate/migrate/v4/database/postgres"
-	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"io/fs"
 	"log"
+	"os"
+	"strconv"
+	"task-api/migrations"
 	"task-api/pkg/config"
+	"task-api/pkg/connectors"
+	"task-api/pkg/migrator"
 )
 
+const (
+	exitOK    = 0
+	exitError = 1
+	exitUsage = 2
+)
+
+const usage = `Использование: migrate [флаги] <команда> [аргументы]
+
+Команды:
+  up [N]         применить все ожидающие миграции или только N
+  down N         откатить N последних миграций
+  goto V         перейти к версии V (вверх или вниз)
+  version        показать текущую версию схемы
+  force V        установить версию V без выполнения миграций (снимает флаг dirty)
+  create NAME    создать пару файлов NNN_NAME.up.sql / NNN_NAME.down.sql
+  status         показать список миграций и их состояние
+
+Флаги:
+`
+
+var errUsage = errors.New("usage")
+
 func main() {
-	var cfg config.AppConfig
-	if err := cfg.ReadEnvConfig(); err != nil {
-		log.Println("read env config failed", err)
+	os.Exit(run(os.Args[1:]))
+}
+
+func run(args []string) int {
+	flags := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	path := flags.String("path", "", "каталог с файлами миграций (по умолчанию встроенные в бинарник; для create — migrations)")
+	dryRun := flags.Bool("dry-run", false, "только показать миграции, которые будут выполнены")
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), usage)
+		flags.PrintDefaults()
 	}
-	if err := cfg.Validate(); err != nil {
-		log.Println("validate config failed", err)
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
 	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return exitUsage
+	}
+	command, rest := flags.Arg(0), flags.Args()[1:]
 
-	connect := fmt.Sprintf("postgres://%s:%s@%s:%v/%s?sslmode=%s",
-		cfg.MainStorage.Postgres.User,
-		cfg.MainStorage.Postgres.Password,
-		cfg.MainStorage.Postgres.Host,
-		cfg.MainStorage.Postgres.Port,
-		cfg.MainStorage.Postgres.DBName,
-		cfg.MainStorage.Postgres.SSLMode,
-	)
-
-	m, err := migrate.New("file://migrations", connect)
+	err := execute(command, rest, *path, *dryRun)
+	if errors.Is(err, errUsage) {
+		flags.Usage()
+		return exitUsage
+	}
 	if err != nil {
-		log.Println(err)
+		log.Println("Ошибка:", err)
+		return exitError
 	}
+	return exitOK
+}
 
-	if err := Up(m); err != nil {
-		log.Println(err)
+func execute(command string, args []string, path string, dryRun bool) error {
+	if command == "create" {
+		if len(args) != 1 {
+			return errUsage
+		}
+		if path == "" {
+			path = "migrations"
+		}
+		files, err := migrator.Create(path, args[0])
+		if err != nil {
+			return err
+		}
+		for _, f := range files {
+			fmt.Println("Создан файл", f)
+		}
+		return nil
 	}
 
-	//if err := Down(m); err != nil {
-	//	log.Println("Ошибка при откате миграций", err)
-	//}
+	m, err := open(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := m.Close(); err != nil {
+			log.Println("Ошибка при закрытии мигратора:", err)
+		}
+	}()
 
-	errSource, errDB := m.Close()
-	if errSource != nil {
-		log.Println("Ошибка при закритии источника миграций:", errSource)
+	switch command {
+	case "up":
+		steps, err := optionalInt(args)
+		if err != nil {
+			return err
+		}
+		if dryRun {
+			pending, err := m.Pending()
+			if err != nil {
+				return err
+			}
+			if steps > 0 && steps < len(pending) {
+				pending = pending[:steps]
+			}
+			printPlan("Будут применены", pending)
+			return nil
+		}
+		if err := m.Up(steps); err != nil {
+			return err
+		}
+		log.Println("Миграции успешно применены")
+	case "down":
+		steps, err := requiredInt(args)
+		if err != nil {
+			return err
+		}
+		if dryRun {
+			applied, err := appliedDesc(m)
+			if err != nil {
+				return err
+			}
+			if steps < len(applied) {
+				applied = applied[:steps]
+			}
+			printPlan("Будут откачены", applied)
+			return nil
+		}
+		if err := m.Down(steps); err != nil {
+			return err
+		}
+		log.Println("Миграции успешно отменены")
+	case "goto":
+		version, err := requiredInt(args)
+		if err != nil {
+			return err
+		}
+		if dryRun {
+			plan, err := m.Plan(uint(version))
+			if err != nil {
+				return err
+			}
+			printPlan("Будут выполнены", plan)
+			return nil
+		}
+		if err := m.Goto(uint(version)); err != nil {
+			return err
+		}
+		log.Printf("Схема переведена на версию %d", version)
+	case "force":
+		version, err := requiredInt(args)
+		if err != nil {
+			return err
+		}
+		if dryRun {
+			fmt.Printf("Версия будет установлена в %d\n", version)
+			return nil
+		}
+		if err := m.Force(version); err != nil {
+			return err
+		}
+		log.Printf("Версия принудительно установлена в %d", version)
+	case "version":
+		version, dirty, err := m.Version()
+		if errors.Is(err, migrator.ErrNoVersion) {
+			fmt.Println("Миграции ещё не применялись")
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%d (dirty: %t)\n", version, dirty)
+	case "status":
+		return printStatus(m)
+	default:
+		return errUsage
+	}
+	return nil
+}
+
+func open(path string) (*migrator.Migrator, error) {
+	var cfg config.AppConfig
+	if err := cfg.ReadEnvConfig(); err != nil {
+		return nil, fmt.Errorf("read env config: %w", err)
+	}
+	db, err := connectors.OpenDB(&cfg.MainStorage.Postgres)
+	if err != nil {
+		return nil, err
+	}
+	var fsys fs.FS = migrations.FS
+	if path != "" {
+		fsys = os.DirFS(path)
 	}
-	if errDB != nil {
-		log.Println("Ошибка при закрытии подключения к БД:", errDB)
+	m, err := migrator.New(fsys, db, cfg.MainStorage.Postgres.DBName)
+	if err != nil {
+		db.Close()
+		return nil, err
 	}
+	return m, nil
 }
 
-func Up(m *migrate.Migrate) error {
-	if err := m.Up(); err != nil {
-		if err == migrate.ErrNoChange {
-			log.Println("Миграций для применения не обнаружено")
-			return err
-		}
-		log.Printf("Ошибка при применении миграций: %v", err)
+func printStatus(m *migrator.Migrator) error {
+	all, err := m.Migrations()
+	if err != nil {
 		return err
 	}
-	log.Println("Миграции успешно применены")
+	version, dirty, err := m.Version()
+	switch {
+	case errors.Is(err, migrator.ErrNoVersion):
+		fmt.Println("Текущая версия: нет")
+	case err != nil:
+		return err
+	default:
+		fmt.Printf("Текущая версия: %d (dirty: %t)\n", version, dirty)
+	}
+	for _, mg := range all {
+		state := "pending"
+		if mg.Applied {
+			state = "applied"
+		}
+		fmt.Printf("  %03d  %-8s %s\n", mg.Version, state, mg.Name)
+	}
 	return nil
 }
 
-func Down(m *migrate.Migrate) error {
-	if err := m.Down(); err != nil {
-		if err == migrate.ErrNoChange {
-			return err
+func printPlan(title string, plan []migrator.Migration) {
+	if len(plan) == 0 {
+		fmt.Println("Нет миграций для выполнения")
+		return
+	}
+	fmt.Println(title + ":")
+	for _, mg := range plan {
+		fmt.Printf("  %03d  %s\n", mg.Version, mg.Name)
+	}
+}
+
+func appliedDesc(m *migrator.Migrator) ([]migrator.Migration, error) {
+	all, err := m.Migrations()
+	if err != nil {
+		return nil, err
+	}
+	var applied []migrator.Migration
+	for i := len(all) - 1; i >= 0; i-- {
+		if all[i].Applied {
+			applied = append(applied, all[i])
 		}
-		return err
 	}
-	log.Println("Миграции успешно отменены")
-	return nil
+	return applied, nil
+}
+
+func optionalInt(args []string) (int, error) {
+	if len(args) == 0 {
+		return 0, nil
+	}
+	return requiredInt(args)
+}
+
+func requiredInt(args []string) (int, error) {
+	if len(args) != 1 {
+		return 0, errUsage
+	}
+	n, err := strconv.Atoi(args[0])
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("%w: expected a non-negative number, got %q", errUsage, args[0])
+	}
+	return n, nil
 }
@@ -0,0 +1,19 @@
+package attachment
+
+import (
+	"task-api/internal/domain/entities"
+)
+
+func FromEntityAttachment(e *entities.Attachment) *AttachmentResponse {
+	return &AttachmentResponse{
+		ID:          e.ID,
+		TaskID:      e.TaskID,
+		CommentID:   e.CommentID,
+		UploadedBy:  e.UploadedBy,
+		FileName:    e.FileName,
+		ContentType: e.ContentType,
+		Size:        e.Size,
+		URL:         "/api/v1/attachments/" + e.ID.String(),
+		CreatedAt:   e.CreatedAt,
+	}
+}
@@ -0,0 +1,18 @@
+package attachment
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type AttachmentResponse struct {
+	ID          uuid.UUID  `json:"id"`
+	TaskID      uuid.UUID  `json:"task_id"`
+	CommentID   *uuid.UUID `json:"comment_id,omitempty"`
+	UploadedBy  uuid.UUID  `json:"uploaded_by"`
+	FileName    string     `json:"file_name"`
+	ContentType string     `json:"content_type"`
+	Size        int64      `json:"size"`
+	URL         string     `json:"url"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
@@ -0,0 +1,29 @@
+package auth
+
+import (
+	"github.com/google/uuid"
+	"task-api/internal/domain/entities"
+	"time"
+)
+
+func (r *CreateAccessTokenRequest) ToEntity(userID uuid.UUID, expiresAt time.Time) *entities.AccessToken {
+	return &entities.AccessToken{
+		UserID:    userID,
+		Name:      r.Name,
+		Scopes:    r.Scopes,
+		ExpiresAt: expiresAt,
+	}
+}
+
+func FromEntityAccessToken(e *entities.AccessToken) *AccessTokenResponse {
+	return &AccessTokenResponse{
+		ID:         e.ID,
+		Name:       e.Name,
+		Prefix:     e.Prefix,
+		Scopes:     e.Scopes,
+		ExpiresAt:  e.ExpiresAt,
+		LastUsedAt: e.LastUsedAt,
+		RevokedAt:  e.RevokedAt,
+		CreatedAt:  e.CreatedAt,
+	}
+}
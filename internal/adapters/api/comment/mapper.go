@@ -7,10 +7,22 @@ import (
 	"time"
 )
 
-func (r *CreateCommentRequest) ToEntity() *entities.Comment {
+func (r *CreateCommentRequest) ToEntity(authorID uuid.UUID) *entities.Comment {
 	return &entities.Comment{
 		TaskID:    r.TaskID,
-		Author:    r.AuthorID,
+		ParentID:  r.ParentID,
+		Author:    authorID,
+		Content:   r.Content,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+}
+
+func (r *CreateTaskCommentRequest) ToEntity(taskID, authorID uuid.UUID) *entities.Comment {
+	return &entities.Comment{
+		TaskID:    taskID,
+		ParentID:  r.ParentID,
+		Author:    authorID,
 		Content:   r.Content,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
@@ -27,8 +39,9 @@ func (r *UpdateCommentRequest) ToEntity(ID uuid.UUID) *entities.Comment {
 
 func FromModelComment(m *models.CommentWish) *CommentResponse {
 	return &CommentResponse{
-		ID:     m.Comment.ID,
-		TaskID: m.Comment.TaskID,
+		ID:       m.Comment.ID,
+		TaskID:   m.Comment.TaskID,
+		ParentID: m.Comment.ParentID,
 		Author: Author{
 			ID:    m.Author.ID,
 			Name:  m.Author.Name,
@@ -37,5 +50,39 @@ func FromModelComment(m *models.CommentWish) *CommentResponse {
 		Content:   m.Comment.Content,
 		CreatedAt: m.Comment.CreatedAt,
 		UpdatedAt: m.Comment.UpdatedAt,
+		EditedAt:  m.Comment.EditedAt,
+		Reactions: FromModelReactions(m.Reactions),
+	}
+}
+
+func FromModelReactions(reactions []models.ReactionSummary) []ReactionResponse {
+	res := make([]ReactionResponse, 0, len(reactions))
+	for _, r := range reactions {
+		res = append(res, ReactionResponse{
+			Emoji:       r.Emoji,
+			Count:       r.Count,
+			ReactedByMe: r.ReactedByMe,
+		})
+	}
+	return res
+}
+
+func FromModelThread(m *models.CommentThread) *CommentThreadResponse {
+	res := &CommentThreadResponse{
+		CommentResponse: *FromModelComment(&m.CommentWish),
+		Replies:         make([]*CommentThreadResponse, 0, len(m.Replies)),
+	}
+	for _, reply := range m.Replies {
+		res.Replies = append(res.Replies, FromModelThread(reply))
+	}
+	return res
+}
+
+func FromEntityRevision(e *entities.CommentRevision) *CommentRevisionResponse {
+	return &CommentRevisionResponse{
+		ID:        e.ID,
+		Content:   e.Content,
+		EditedBy:  e.EditedBy,
+		CreatedAt: e.CreatedAt,
 	}
 }
@@ -3,11 +3,27 @@ package comment
 import "github.com/google/uuid"
 
 type CreateCommentRequest struct {
-	TaskID   uuid.UUID `json:"task_id" binding:"required"`
-	AuthorID uuid.UUID `json:"author" binding:"required"`
-	Content  string    `json:"content" binding:"required"`
+	TaskID   uuid.UUID  `json:"task_id" binding:"required"`
+	ParentID *uuid.UUID `json:"parent_id"`
+	Content  string     `json:"content" binding:"required"`
+}
+
+type CreateTaskCommentRequest struct {
+	ParentID *uuid.UUID `json:"parent_id"`
+	Content  string     `json:"content" binding:"required"`
 }
 
 type UpdateCommentRequest struct {
 	Content string `json:"content" binding:"required"`
 }
+
+type AddReactionRequest struct {
+	Emoji string `json:"emoji" binding:"required"`
+}
+
+const DefaultThreadLimit = 20
+
+type ThreadQuery struct {
+	Limit  int `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset int `form:"offset" binding:"omitempty,min=0"`
+}
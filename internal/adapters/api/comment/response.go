@@ -6,12 +6,21 @@ import (
 )
 
 type CommentResponse struct {
-	ID        uuid.UUID `json:"id"`
-	TaskID    uuid.UUID `json:"task_id"`
-	Author    Author    `json:"author"`
-	Content   string    `json:"content"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID        uuid.UUID          `json:"id"`
+	TaskID    uuid.UUID          `json:"task_id"`
+	ParentID  *uuid.UUID         `json:"parent_id,omitempty"`
+	Author    Author             `json:"author"`
+	Content   string             `json:"content"`
+	CreatedAt time.Time          `json:"created_at"`
+	UpdatedAt time.Time          `json:"updated_at"`
+	EditedAt  *time.Time         `json:"edited_at,omitempty"`
+	Reactions []ReactionResponse `json:"reactions,omitempty"`
+}
+
+type ReactionResponse struct {
+	Emoji       string `json:"emoji"`
+	Count       int    `json:"count"`
+	ReactedByMe bool   `json:"reacted_by_me"`
 }
 
 type Author struct {
@@ -19,3 +28,22 @@ type Author struct {
 	Name  string    `json:"name"`
 	Email string    `json:"email"`
 }
+
+type CommentThreadResponse struct {
+	CommentResponse
+	Replies []*CommentThreadResponse `json:"replies"`
+}
+
+type CommentThreadPage struct {
+	Items  []*CommentThreadResponse `json:"items"`
+	Total  int                      `json:"total"`
+	Limit  int                      `json:"limit"`
+	Offset int                      `json:"offset"`
+}
+
+type CommentRevisionResponse struct {
+	ID        uuid.UUID  `json:"id"`
+	Content   string     `json:"content"`
+	EditedBy  *uuid.UUID `json:"edited_by,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
@@ -0,0 +1,51 @@
+package notification
+
+import (
+	"github.com/google/uuid"
+	"task-api/internal/domain/entities"
+)
+
+func (r *UpdatePreferencesRequest) ToEntities() []*entities.NotificationPreference {
+	preferences := make([]*entities.NotificationPreference, 0, len(r.Preferences))
+	for _, p := range r.Preferences {
+		preferences = append(preferences, &entities.NotificationPreference{Type: p.Type, Enabled: p.Enabled})
+	}
+	return preferences
+}
+
+func FromEntityNotification(e *entities.Notification) *NotificationResponse {
+	return &NotificationResponse{
+		ID:        e.ID,
+		Type:      e.Type,
+		TaskID:    e.TaskID,
+		CommentID: e.CommentID,
+		ActorID:   e.ActorID,
+		Data:      e.Data,
+		CreatedAt: e.CreatedAt,
+		ReadAt:    e.ReadAt,
+	}
+}
+
+func FromEntityPreferences(preferences []*entities.NotificationPreference) []PreferenceResponse {
+	res := make([]PreferenceResponse, 0, len(preferences))
+	for _, p := range preferences {
+		res = append(res, PreferenceResponse{Type: p.Type, Enabled: p.Enabled})
+	}
+	return res
+}
+
+func (r *DigestSettingsRequest) ToEntity(userID uuid.UUID) *entities.DigestSettings {
+	return &entities.DigestSettings{
+		UserID:   userID,
+		Enabled:  *r.Enabled,
+		Timezone: r.Timezone,
+	}
+}
+
+func FromEntityDigestSettings(e *entities.DigestSettings) *DigestSettingsResponse {
+	return &DigestSettingsResponse{
+		Enabled:    e.Enabled,
+		Timezone:   e.Timezone,
+		LastSentAt: e.LastSentAt,
+	}
+}
@@ -0,0 +1,23 @@
+package notification
+
+const DefaultLimit = 20
+
+type ListNotificationsQuery struct {
+	UnreadOnly bool `form:"unread_only"`
+	Limit      int  `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset     int  `form:"offset" binding:"omitempty,min=0"`
+}
+
+type PreferenceRequest struct {
+	Type    string `json:"type" binding:"required"`
+	Enabled bool   `json:"enabled"`
+}
+
+type UpdatePreferencesRequest struct {
+	Preferences []PreferenceRequest `json:"preferences" binding:"required,dive"`
+}
+
+type DigestSettingsRequest struct {
+	Enabled  *bool  `json:"enabled" binding:"required"`
+	Timezone string `json:"timezone" example:"Europe/Moscow"`
+}
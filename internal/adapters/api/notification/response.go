@@ -0,0 +1,35 @@
+package notification
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type NotificationResponse struct {
+	ID        uuid.UUID         `json:"id"`
+	Type      string            `json:"type"`
+	TaskID    *uuid.UUID        `json:"task_id,omitempty"`
+	CommentID *uuid.UUID        `json:"comment_id,omitempty"`
+	ActorID   *uuid.UUID        `json:"actor_id,omitempty"`
+	Data      map[string]string `json:"data,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+	ReadAt    *time.Time        `json:"read_at,omitempty"`
+}
+
+type NotificationListResponse struct {
+	Items       []*NotificationResponse `json:"items"`
+	UnreadCount int                     `json:"unread_count"`
+	Limit       int                     `json:"limit"`
+	Offset      int                     `json:"offset"`
+}
+
+type PreferenceResponse struct {
+	Type    string `json:"type"`
+	Enabled bool   `json:"enabled"`
+}
+
+type DigestSettingsResponse struct {
+	Enabled    bool       `json:"enabled"`
+	Timezone   string     `json:"timezone"`
+	LastSentAt *time.Time `json:"last_sent_at,omitempty"`
+}
@@ -2,31 +2,47 @@ package tag
 
 import (
 	"github.com/google/uuid"
+	"task-api/internal/adapters/models"
 	"task-api/internal/domain/entities"
 	"time"
 )
 
-func (t *CreateTagRequest) ToEntity() *entities.Tag {
+func (t *CreateTagRequest) ToEntity(ownerID uuid.UUID) *entities.Tag {
 	return &entities.Tag{
-		Title:     t.Title,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		OwnerID:     ownerID,
+		Title:       t.Title,
+		Color:       t.Color,
+		Description: t.Description,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
 	}
 }
 
-func (t *UpdateTagRequest) ToEntity(ID uuid.UUID) *entities.Tag {
+func (t *UpdateTagRequest) ToEntity(ID, ownerID uuid.UUID) *entities.Tag {
 	return &entities.Tag{
-		ID:        ID,
-		Title:     t.Title,
-		UpdatedAt: time.Now(),
+		ID:          ID,
+		OwnerID:     ownerID,
+		Title:       t.Title,
+		Color:       t.Color,
+		Description: t.Description,
+		UpdatedAt:   time.Now(),
 	}
 }
 
 func FromEntityTag(e *entities.Tag) *TagResponse {
 	return &TagResponse{
-		ID:        e.ID,
-		Title:     e.Title,
-		CreatedAt: e.CreatedAt,
-		UpdatedAt: e.UpdatedAt,
+		ID:          e.ID,
+		Title:       e.Title,
+		Color:       e.Color,
+		Description: e.Description,
+		CreatedAt:   e.CreatedAt,
+		UpdatedAt:   e.UpdatedAt,
+	}
+}
+
+func FromModelTagUsage(m *models.TagUsage) *TagUsageResponse {
+	return &TagUsageResponse{
+		TagResponse: *FromEntityTag(&m.Tag),
+		UsageCount:  m.UsageCount,
 	}
 }
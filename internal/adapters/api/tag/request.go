@@ -1,9 +1,27 @@
 package tag
 
+import "github.com/google/uuid"
+
+// DefaultAutocompleteLimit applies when a prefix is given without a limit.
+const DefaultAutocompleteLimit = 10
+
 type CreateTagRequest struct {
-	Title string `json:"title"`
+	Title       string `json:"title" binding:"required,max=64"`
+	Color       string `json:"color" binding:"omitempty,hexcolor" example:"#ff8800"`
+	Description string `json:"description" binding:"max=500"`
 }
 
 type UpdateTagRequest struct {
-	Title string `json:"title"`
+	Title       string `json:"title" binding:"required,max=64"`
+	Color       string `json:"color" binding:"omitempty,hexcolor" example:"#ff8800"`
+	Description string `json:"description" binding:"max=500"`
+}
+
+type ListTagsQuery struct {
+	Prefix string `form:"prefix" binding:"max=64"`
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+type MergeTagRequest struct {
+	TargetID uuid.UUID `json:"target_id" binding:"required"`
 }
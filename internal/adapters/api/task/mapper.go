@@ -2,9 +2,11 @@ package task
 
 import (
 	"github.com/google/uuid"
+	"task-api/internal/adapters/api/attachment"
 	commentRes "task-api/internal/adapters/api/comment"
 	"task-api/internal/adapters/models"
 	"task-api/internal/domain/entities"
+	"task-api/pkg/taskquery"
 	"time"
 )
 
@@ -12,7 +14,10 @@ func (req *CreateTaskRequest) ToEntity(userID uuid.UUID) *entities.Task {
 	return &entities.Task{
 		Title:       req.Title,
 		Description: req.Description,
-		Status:      "new",
+		Status:      entities.TaskStatusNew,
+		Priority:    priorityOrDefault(req.Priority),
+		DueAt:       inUTC(req.DueAt),
+		Recurrence:  req.Recurrence,
 		CreatedBy:   userID,
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
@@ -25,41 +30,94 @@ func (req *UpdateTaskRequest) ToEntity(ID uuid.UUID) *entities.Task {
 		Title:       req.Title,
 		Description: req.Description,
 		Status:      req.Status,
+		Priority:    priorityOrDefault(req.Priority),
+		DueAt:       inUTC(req.DueAt),
+		Recurrence:  req.Recurrence,
 		UpdatedAt:   time.Now(),
 	}
 }
 
+// priorityOrDefault gives tasks created or updated without a priority the medium one.
+func priorityOrDefault(priority string) string {
+	if priority == "" {
+		return entities.TaskPriorityMedium
+	}
+	return priority
+}
+
+// inUTC normalizes a client supplied time: timestamp columns keep no time zone.
+func inUTC(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	u := t.UTC()
+	return &u
+}
+
+func (req *MoveTaskRequest) ToModel(taskID, actorID uuid.UUID) models.TaskMove {
+	return models.TaskMove{
+		TaskID:   taskID,
+		ActorID:  actorID,
+		Status:   req.Status,
+		AfterID:  req.AfterID,
+		BeforeID: req.BeforeID,
+	}
+}
+
+// ToFilter returns a *taskquery.SyntaxError when the search query is invalid.
+func (q *ListTasksQuery) ToFilter() (models.TaskFilter, error) {
+	expr, err := taskquery.Parse(q.Query)
+	if err != nil {
+		return models.TaskFilter{}, err
+	}
+	return models.TaskFilter{
+		IncludeArchived: q.IncludeArchived,
+		ArchivedOnly:    q.ArchivedOnly,
+		Status:          q.Status,
+		Tags:            q.Tags,
+		Query:           expr,
+	}, nil
+}
+
 func FromModelTask(m *models.Task) *TaskResponse {
 	res := &TaskResponse{
 		ID:          m.Task.ID,
 		Title:       m.Task.Title,
 		Description: m.Task.Description,
 		Status:      m.Task.Status,
+		Priority:    m.Task.Priority,
+		ParentID:    m.Task.ParentID,
+		Rank:        m.Task.Rank,
 		CreatedBy: Creator{
 			ID:    m.User.ID,
 			Name:  m.User.Name,
 			Email: m.User.Email,
 		},
-		CreatedAt: m.Task.CreatedAt,
-		UpdatedAt: m.Task.UpdatedAt,
-	}
-	for _, comment := range m.Comments {
-		res.Comments = append(res.Comments,
-			commentRes.CommentResponse{
-				ID:     comment.Comment.ID,
-				TaskID: comment.Comment.TaskID,
-				Author: commentRes.Author{
-					ID:    comment.Author.ID,
-					Name:  comment.Author.Name,
-					Email: comment.Author.Email,
-				},
-				Content:   comment.Comment.Content,
-				CreatedAt: comment.Comment.CreatedAt,
-				UpdatedAt: comment.Comment.UpdatedAt,
-			})
+		CreatedAt:   m.Task.CreatedAt,
+		UpdatedAt:   m.Task.UpdatedAt,
+		DueAt:       m.Task.DueAt,
+		CompletedAt: m.Task.CompletedAt,
+		ArchivedAt:  m.Task.ArchivedAt,
+		Recurrence:  m.Task.Recurrence,
+	}
+	for i := range m.Comments {
+		res.Comments = append(res.Comments, *commentRes.FromModelComment(&m.Comments[i]))
 	}
 	for _, tag := range m.Tags {
-		res.Tags = append(res.Tags, Tags{ID: tag.ID, Title: tag.Title})
+		res.Tags = append(res.Tags, Tags{ID: tag.ID, Title: tag.Title, Color: tag.Color})
+	}
+	for _, assignee := range m.Assignees {
+		res.Assignees = append(res.Assignees, Assignee{ID: assignee.ID, Name: assignee.Name, Email: assignee.Email})
+	}
+	for i := range m.Attachments {
+		res.Attachments = append(res.Attachments, *attachment.FromEntityAttachment(&m.Attachments[i]))
+	}
+	for _, subtask := range m.Subtasks {
+		res.Subtasks = append(res.Subtasks, Subtask{ID: subtask.ID, Title: subtask.Title, Status: subtask.Status, Priority: subtask.Priority, DueAt: subtask.DueAt})
+	}
+	for _, total := range m.TimeTotals {
+		res.TimeSpentSeconds += total.Seconds
+		res.TimeByUser = append(res.TimeByUser, TimeTotal{UserID: total.User.ID, Name: total.User.Name, Seconds: total.Seconds})
 	}
 	return res
 }
@@ -70,11 +128,31 @@ func FromModelTaskForAll(m *models.TasksWishTags) *TaskAllResponse {
 		Title:       m.Task.Title,
 		Description: m.Task.Description,
 		Status:      m.Task.Status,
+		Priority:    m.Task.Priority,
+		ParentID:    m.Task.ParentID,
+		Rank:        m.Task.Rank,
 		CreatedAt:   m.Task.CreatedAt,
 		UpdatedAt:   m.Task.UpdatedAt,
+		DueAt:       m.Task.DueAt,
+		CompletedAt: m.Task.CompletedAt,
+		ArchivedAt:  m.Task.ArchivedAt,
+		Recurrence:  m.Task.Recurrence,
+		DeletedAt:   m.Task.DeletedAt,
 	}
 	for _, tag := range m.Tags {
-		res.Tags = append(res.Tags, Tags{ID: tag.ID, Title: tag.Title})
+		res.Tags = append(res.Tags, Tags{ID: tag.ID, Title: tag.Title, Color: tag.Color})
+	}
+	return res
+}
+
+func FromModelBoard(columns []models.BoardColumn) *BoardResponse {
+	res := &BoardResponse{Columns: make([]BoardColumn, 0, len(columns))}
+	for _, column := range columns {
+		tasks := make([]TaskAllResponse, 0, len(column.Tasks))
+		for _, task := range column.Tasks {
+			tasks = append(tasks, *FromModelTaskForAll(task))
+		}
+		res.Columns = append(res.Columns, BoardColumn{Status: column.Status, Tasks: tasks})
 	}
 	return res
 }
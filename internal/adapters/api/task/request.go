@@ -1,18 +1,67 @@
 package task
 
-import "github.com/google/uuid"
+import (
+	"github.com/google/uuid"
+	"time"
+)
 
 type CreateTaskRequest struct {
-	Title       string `json:"title" binding:"required"`
-	Description string `json:"description" binding:"required"`
+	Title       string     `json:"title" binding:"required"`
+	Description string     `json:"description" binding:"required"`
+	Priority    string     `json:"priority" binding:"omitempty,oneof=low medium high"`
+	DueAt       *time.Time `json:"due_at"`
+	// Recurrence is an RRULE such as FREQ=WEEKLY;BYDAY=MO; it needs due_at,
+	// the first occurrence.
+	Recurrence string `json:"recurrence" binding:"max=200"`
+	// Tags are titles of the creator's tags; missing tags are created.
+	Tags []string `json:"tags" binding:"omitempty,max=50,dive,max=64"`
 }
 
 type TagRequest struct {
 	ID uuid.UUID `json:"id" binding:"required"`
 }
 
+type AssigneeRequest struct {
+	UserID uuid.UUID `json:"user_id" binding:"required"`
+}
+
+type ListTasksQuery struct {
+	IncludeArchived bool     `form:"include_archived"`
+	ArchivedOnly    bool     `form:"archived_only"`
+	Status          string   `form:"status" binding:"max=32"`
+	Tags            []string `form:"tag" binding:"max=50,dive,max=64"`
+	Query           string   `form:"q" binding:"max=500"`
+}
+
 type UpdateTaskRequest struct {
-	Title       string `json:"title" binding:"required"`
-	Description string `json:"description" binding:"required"`
-	Status      string `json:"status" binding:"required"`
+	Title       string     `json:"title" binding:"required"`
+	Description string     `json:"description" binding:"required"`
+	Status      string     `json:"status" binding:"required"`
+	Priority    string     `json:"priority" binding:"omitempty,oneof=low medium high"`
+	DueAt       *time.Time `json:"due_at"`
+	// Recurrence is cleared when omitted, like due_at.
+	Recurrence string `json:"recurrence" binding:"max=200"`
+	// Tags replaces the caller's tags on the task when present; omit it to
+	// keep them and send an empty list to remove them.
+	Tags []string `json:"tags" binding:"omitempty,max=50,dive,max=64"`
+}
+
+type OccurrencesQuery struct {
+	Limit int `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+// MoveTaskRequest names at most one neighbour in the target column; without
+// one the task goes to the end of the column.
+type MoveTaskRequest struct {
+	Status   string     `json:"status" binding:"required,max=32"`
+	AfterID  *uuid.UUID `json:"after_id" binding:"omitempty,excluded_with=BeforeID"`
+	BeforeID *uuid.UUID `json:"before_id"`
+}
+
+type BoardQuery struct {
+	Statuses []string `form:"status" binding:"max=20,dive,required,max=32"`
+}
+
+type ReplaceTagsRequest struct {
+	Tags []string `json:"tags" binding:"required,max=50,dive,max=64"`
 }
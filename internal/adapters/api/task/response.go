@@ -2,30 +2,81 @@ package task
 
 import (
 	"github.com/google/uuid"
+	"task-api/internal/adapters/api/attachment"
 	"task-api/internal/adapters/api/comment"
 	"time"
 )
 
 type TaskResponse struct {
-	ID          uuid.UUID                 `json:"id"`
-	Title       string                    `json:"title"`
-	Description string                    `json:"description"`
-	Status      string                    `json:"status"`
-	CreatedBy   Creator                   `json:"created_by"`
-	Tags        []Tags                    `json:"tags"`
-	Comments    []comment.CommentResponse `json:"comments"`
-	CreatedAt   time.Time                 `json:"created_at"`
-	UpdatedAt   time.Time                 `json:"updated_at"`
+	ID          uuid.UUID                       `json:"id"`
+	Title       string                          `json:"title"`
+	Description string                          `json:"description"`
+	Status      string                          `json:"status"`
+	Priority    string                          `json:"priority"`
+	ParentID    *uuid.UUID                      `json:"parent_id,omitempty"`
+	Rank        string                          `json:"rank"`
+	CreatedBy   Creator                         `json:"created_by"`
+	Tags        []Tags                          `json:"tags"`
+	Comments    []comment.CommentResponse       `json:"comments"`
+	Attachments []attachment.AttachmentResponse `json:"attachments"`
+	Assignees   []Assignee                      `json:"assignees"`
+	Subtasks    []Subtask                       `json:"subtasks"`
+	CreatedAt   time.Time                       `json:"created_at"`
+	UpdatedAt   time.Time                       `json:"updated_at"`
+	DueAt       *time.Time                      `json:"due_at,omitempty"`
+	CompletedAt *time.Time                      `json:"completed_at,omitempty"`
+	ArchivedAt  *time.Time                      `json:"archived_at,omitempty"`
+	Recurrence  string                          `json:"recurrence,omitempty"`
+	// TimeSpentSeconds sums the finished time entries of all users.
+	TimeSpentSeconds int64       `json:"time_spent_seconds"`
+	TimeByUser       []TimeTotal `json:"time_by_user"`
 }
 
 type TaskAllResponse struct {
-	ID          uuid.UUID `json:"id"`
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	Status      string    `json:"status"`
-	Tags        []Tags    `json:"tags"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID          uuid.UUID  `json:"id"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Status      string     `json:"status"`
+	Priority    string     `json:"priority"`
+	ParentID    *uuid.UUID `json:"parent_id,omitempty"`
+	Rank        string     `json:"rank,omitempty"`
+	Tags        []Tags     `json:"tags"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	DueAt       *time.Time `json:"due_at,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	ArchivedAt  *time.Time `json:"archived_at,omitempty"`
+	Recurrence  string     `json:"recurrence,omitempty"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty"`
+}
+
+type OccurrencesResponse struct {
+	TaskID      uuid.UUID   `json:"task_id"`
+	Occurrences []time.Time `json:"occurrences"`
+}
+
+type BoardResponse struct {
+	Columns []BoardColumn `json:"columns"`
+}
+
+// BoardColumn lists tasks in board order, by ascending rank.
+type BoardColumn struct {
+	Status string            `json:"status"`
+	Tasks  []TaskAllResponse `json:"tasks"`
+}
+
+type Subtask struct {
+	ID       uuid.UUID  `json:"id"`
+	Title    string     `json:"title"`
+	Status   string     `json:"status"`
+	Priority string     `json:"priority"`
+	DueAt    *time.Time `json:"due_at,omitempty"`
+}
+
+type TimeTotal struct {
+	UserID  uuid.UUID `json:"user_id"`
+	Name    string    `json:"name"`
+	Seconds int64     `json:"seconds"`
 }
 
 type Creator struct {
@@ -34,7 +85,14 @@ type Creator struct {
 	Email string    `json:"email"`
 }
 
+type Assignee struct {
+	ID    uuid.UUID `json:"id"`
+	Name  string    `json:"name"`
+	Email string    `json:"email"`
+}
+
 type Tags struct {
 	ID    uuid.UUID `json:"id"`
 	Title string    `json:"title"`
+	Color string    `json:"color,omitempty"`
 }
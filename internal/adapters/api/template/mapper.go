@@ -0,0 +1,89 @@
+package template
+
+import (
+	"github.com/google/uuid"
+	"slices"
+	"task-api/internal/domain/entities"
+	"task-api/pkg/placeholder"
+	"time"
+)
+
+func toSubtasks(requests []SubtaskRequest) []entities.TemplateSubtask {
+	subtasks := make([]entities.TemplateSubtask, 0, len(requests))
+	for _, r := range requests {
+		subtasks = append(subtasks, entities.TemplateSubtask{Title: r.Title, Description: r.Description, Priority: r.Priority})
+	}
+	return subtasks
+}
+
+func (r *CreateTemplateRequest) ToEntity(ownerID uuid.UUID) *entities.Template {
+	return &entities.Template{
+		OwnerID:     ownerID,
+		Name:        r.Name,
+		Title:       r.Title,
+		Description: r.Description,
+		Priority:    r.Priority,
+		Tags:        r.Tags,
+		Subtasks:    toSubtasks(r.Subtasks),
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+}
+
+func (r *UpdateTemplateRequest) ToEntity(ID, ownerID uuid.UUID) *entities.Template {
+	return &entities.Template{
+		ID:          ID,
+		OwnerID:     ownerID,
+		Name:        r.Name,
+		Title:       r.Title,
+		Description: r.Description,
+		Priority:    r.Priority,
+		Tags:        r.Tags,
+		Subtasks:    toSubtasks(r.Subtasks),
+		UpdatedAt:   time.Now(),
+	}
+}
+
+// inUTC normalizes a client supplied time: timestamp columns keep no time zone.
+func inUTC(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	u := t.UTC()
+	return &u
+}
+
+func (r *InstantiateRequest) DueAtUTC() *time.Time {
+	return inUTC(r.DueAt)
+}
+
+func FromEntityTemplate(t *entities.Template) *TemplateResponse {
+	res := &TemplateResponse{
+		ID:          t.ID,
+		Name:        t.Name,
+		Title:       t.Title,
+		Description: t.Description,
+		Priority:    t.Priority,
+		Tags:        t.Tags,
+		Subtasks:    make([]SubtaskResponse, 0, len(t.Subtasks)),
+		Variables:   []string{},
+		CreatedAt:   t.CreatedAt,
+		UpdatedAt:   t.UpdatedAt,
+	}
+	if res.Tags == nil {
+		res.Tags = []string{}
+	}
+	texts := []string{t.Title, t.Description}
+	for _, subtask := range t.Subtasks {
+		res.Subtasks = append(res.Subtasks, SubtaskResponse{Title: subtask.Title, Description: subtask.Description, Priority: subtask.Priority})
+		texts = append(texts, subtask.Title, subtask.Description)
+	}
+	for _, text := range texts {
+		for _, name := range placeholder.Names(text) {
+			if !slices.Contains(res.Variables, name) {
+				res.Variables = append(res.Variables, name)
+			}
+		}
+	}
+	return res
+}
@@ -0,0 +1,35 @@
+package template
+
+import "time"
+
+type SubtaskRequest struct {
+	Title       string `json:"title" binding:"required,max=200"`
+	Description string `json:"description" binding:"max=5000"`
+	// Priority defaults to the priority of the template.
+	Priority string `json:"priority" binding:"omitempty,oneof=low medium high"`
+}
+
+type CreateTemplateRequest struct {
+	Name        string           `json:"name" binding:"required,max=100"`
+	Title       string           `json:"title" binding:"required,max=200"`
+	Description string           `json:"description" binding:"max=5000"`
+	Priority    string           `json:"priority" binding:"omitempty,oneof=low medium high"`
+	Tags        []string         `json:"tags" binding:"max=50,dive,max=64"`
+	Subtasks    []SubtaskRequest `json:"subtasks" binding:"max=50,dive"`
+}
+
+type UpdateTemplateRequest struct {
+	Name        string           `json:"name" binding:"required,max=100"`
+	Title       string           `json:"title" binding:"required,max=200"`
+	Description string           `json:"description" binding:"max=5000"`
+	Priority    string           `json:"priority" binding:"omitempty,oneof=low medium high"`
+	Tags        []string         `json:"tags" binding:"max=50,dive,max=64"`
+	Subtasks    []SubtaskRequest `json:"subtasks" binding:"max=50,dive"`
+}
+
+type InstantiateRequest struct {
+	// Variables are the values of the {{name}} variables of the template.
+	Variables map[string]string `json:"variables" binding:"max=50,dive,keys,max=64,endkeys,max=1000"`
+	// DueAt is the due date of the main task; subtasks get none.
+	DueAt *time.Time `json:"due_at"`
+}
@@ -0,0 +1,26 @@
+package template
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type TemplateResponse struct {
+	ID          uuid.UUID         `json:"id"`
+	Name        string            `json:"name"`
+	Title       string            `json:"title"`
+	Description string            `json:"description"`
+	Priority    string            `json:"priority"`
+	Tags        []string          `json:"tags"`
+	Subtasks    []SubtaskResponse `json:"subtasks"`
+	// Variables lists the variables used in titles and descriptions.
+	Variables []string  `json:"variables"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type SubtaskResponse struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Priority    string `json:"priority,omitempty"`
+}
@@ -0,0 +1,151 @@
+package timeentry
+
+import (
+	"encoding/csv"
+	"github.com/google/uuid"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"task-api/internal/adapters/models"
+	"task-api/internal/domain/entities"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+func (r *CreateTimeEntryRequest) ToEntity(taskID, userID uuid.UUID) *entities.TimeEntry {
+	duration := time.Duration(r.Minutes) * time.Minute
+	startedAt := time.Now().Add(-duration)
+	if r.StartedAt != nil {
+		startedAt = r.StartedAt.UTC()
+	}
+	endedAt := startedAt.Add(duration)
+	return &entities.TimeEntry{
+		TaskID:    taskID,
+		UserID:    userID,
+		StartedAt: startedAt,
+		EndedAt:   &endedAt,
+		Note:      r.Note,
+	}
+}
+
+// ToFilter expects a bound query, so the dates and the user ID are valid.
+func (q *TimeReportQuery) ToFilter(viewerID uuid.UUID) (models.TimeReportFilter, error) {
+	from, err := time.Parse(dateLayout, q.From)
+	if err != nil {
+		return models.TimeReportFilter{}, err
+	}
+	to, err := time.Parse(dateLayout, q.To)
+	if err != nil {
+		return models.TimeReportFilter{}, err
+	}
+	filter := models.TimeReportFilter{
+		ViewerID: viewerID,
+		From:     from,
+		To:       to.AddDate(0, 0, 1),
+		Tag:      q.Tag,
+	}
+	if q.UserID != "" {
+		userID, err := uuid.Parse(q.UserID)
+		if err != nil {
+			return models.TimeReportFilter{}, err
+		}
+		filter.UserID = &userID
+	}
+	for _, group := range q.GroupBy {
+		if !slices.Contains(filter.GroupBy, group) {
+			filter.GroupBy = append(filter.GroupBy, group)
+		}
+	}
+	return filter, nil
+}
+
+func FromModelTimeEntry(m *models.TimeEntry) *TimeEntryResponse {
+	return &TimeEntryResponse{
+		ID:              m.Entry.ID,
+		TaskID:          m.Entry.TaskID,
+		User:            User{ID: m.User.ID, Name: m.User.Name, Email: m.User.Email},
+		StartedAt:       m.Entry.StartedAt,
+		EndedAt:         m.Entry.EndedAt,
+		DurationSeconds: int64(m.Entry.Duration().Seconds()),
+		Running:         m.Entry.EndedAt == nil,
+		Note:            m.Entry.Note,
+	}
+}
+
+func FromModelTimeReport(m *models.TimeReport) *TimeReportResponse {
+	res := &TimeReportResponse{
+		From:         m.Filter.From.Format(dateLayout),
+		To:           m.Filter.To.AddDate(0, 0, -1).Format(dateLayout),
+		GroupBy:      m.Filter.GroupBy,
+		Rows:         make([]TimeReportRow, 0, len(m.Rows)),
+		TotalSeconds: m.TotalSeconds,
+		TotalEntries: m.TotalEntries,
+	}
+	for _, row := range m.Rows {
+		out := TimeReportRow{Seconds: row.Seconds, Entries: row.Entries}
+		for _, group := range m.Filter.GroupBy {
+			switch group {
+			case models.TimeGroupUser:
+				out.User = &User{ID: row.User.ID, Name: row.User.Name, Email: row.User.Email}
+			case models.TimeGroupTag:
+				out.Tag = &row.Tag
+			case models.TimeGroupDay:
+				out.Day = row.Day.Format(dateLayout)
+			}
+		}
+		res.Rows = append(res.Rows, out)
+	}
+	return res
+}
+
+// WriteTimeReportCSV writes one line per row, with the grouping columns first
+// and the time both in seconds and in hours rounded to two decimals.
+func WriteTimeReportCSV(w io.Writer, m *models.TimeReport) error {
+	var header []string
+	for _, group := range m.Filter.GroupBy {
+		switch group {
+		case models.TimeGroupUser:
+			header = append(header, "user_id", "user_name", "user_email")
+		case models.TimeGroupTag:
+			header = append(header, "tag")
+		case models.TimeGroupDay:
+			header = append(header, "day")
+		}
+	}
+	out := csv.NewWriter(w)
+	if err := out.Write(append(header, "seconds", "hours", "entries")); err != nil {
+		return err
+	}
+	for _, row := range m.Rows {
+		var record []string
+		for _, group := range m.Filter.GroupBy {
+			switch group {
+			case models.TimeGroupUser:
+				record = append(record, row.User.ID.String(), csvText(row.User.Name), csvText(row.User.Email))
+			case models.TimeGroupTag:
+				record = append(record, csvText(row.Tag))
+			case models.TimeGroupDay:
+				record = append(record, row.Day.Format(dateLayout))
+			}
+		}
+		record = append(record,
+			strconv.FormatInt(row.Seconds, 10),
+			strconv.FormatFloat(float64(row.Seconds)/3600, 'f', 2, 64),
+			strconv.Itoa(row.Entries))
+		if err := out.Write(record); err != nil {
+			return err
+		}
+	}
+	out.Flush()
+	return out.Error()
+}
+
+// csvText keeps spreadsheets from running user supplied text as a formula.
+func csvText(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
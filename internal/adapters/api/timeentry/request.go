@@ -0,0 +1,24 @@
+package timeentry
+
+import "time"
+
+type StartTimerRequest struct {
+	Note string `json:"note" binding:"max=1000"`
+}
+
+type CreateTimeEntryRequest struct {
+	// StartedAt defaults to Minutes before now.
+	StartedAt *time.Time `json:"started_at"`
+	Minutes   int        `json:"minutes" binding:"required,min=1,max=1440"`
+	Note      string     `json:"note" binding:"max=1000"`
+}
+
+// TimeReportQuery covers the days from From to To inclusive, in UTC.
+type TimeReportQuery struct {
+	From    string   `form:"from" binding:"required,datetime=2006-01-02"`
+	To      string   `form:"to" binding:"required,datetime=2006-01-02"`
+	UserID  string   `form:"user_id" binding:"omitempty,uuid"`
+	Tag     string   `form:"tag" binding:"max=64"`
+	GroupBy []string `form:"group_by" binding:"max=3,dive,oneof=user tag day"`
+	Format  string   `form:"format" binding:"omitempty,oneof=json csv"`
+}
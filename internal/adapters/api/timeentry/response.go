@@ -0,0 +1,42 @@
+package timeentry
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type TimeEntryResponse struct {
+	ID        uuid.UUID  `json:"id"`
+	TaskID    uuid.UUID  `json:"task_id"`
+	User      User       `json:"user"`
+	StartedAt time.Time  `json:"started_at"`
+	EndedAt   *time.Time `json:"ended_at,omitempty"`
+	// DurationSeconds is 0 while Running.
+	DurationSeconds int64  `json:"duration_seconds"`
+	Running         bool   `json:"running"`
+	Note            string `json:"note"`
+}
+
+type User struct {
+	ID    uuid.UUID `json:"id"`
+	Name  string    `json:"name"`
+	Email string    `json:"email"`
+}
+
+type TimeReportResponse struct {
+	From         string          `json:"from"`
+	To           string          `json:"to"`
+	GroupBy      []string        `json:"group_by"`
+	Rows         []TimeReportRow `json:"rows"`
+	TotalSeconds int64           `json:"total_seconds"`
+	TotalEntries int             `json:"total_entries"`
+}
+
+// TimeReportRow has only the fields of the requested grouping.
+type TimeReportRow struct {
+	User    *User   `json:"user,omitempty"`
+	Tag     *string `json:"tag,omitempty"`
+	Day     string  `json:"day,omitempty"`
+	Seconds int64   `json:"seconds"`
+	Entries int     `json:"entries"`
+}
@@ -32,6 +32,7 @@ func FromEntityUser(e *entities.User) *UserResponse {
 		Name:      e.Name,
 		Email:     e.Email,
 		Password:  e.Password,
+		Role:      e.Role,
 		CreatedAt: e.CreatedAt,
 		UpdatedAt: e.UpdatedAt,
 	}
@@ -0,0 +1,59 @@
+package view
+
+import (
+	"github.com/google/uuid"
+	"task-api/internal/adapters/models"
+	"task-api/internal/domain/entities"
+	"time"
+)
+
+func (f *ViewFilterRequest) ToEntity() entities.ViewFilter {
+	return entities.ViewFilter{
+		Status:          f.Status,
+		Tags:            f.Tags,
+		IncludeArchived: f.IncludeArchived,
+		ArchivedOnly:    f.ArchivedOnly,
+		Query:           f.Query,
+	}
+}
+
+func (v *CreateViewRequest) ToEntity(ownerID uuid.UUID) *entities.View {
+	return &entities.View{
+		OwnerID:   ownerID,
+		Name:      v.Name,
+		Filter:    v.Filter.ToEntity(),
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+}
+
+func (v *UpdateViewRequest) ToEntity(ID, ownerID uuid.UUID) *entities.View {
+	return &entities.View{
+		ID:        ID,
+		OwnerID:   ownerID,
+		Name:      v.Name,
+		Filter:    v.Filter.ToEntity(),
+		UpdatedAt: time.Now(),
+	}
+}
+
+func FromModelView(m *models.View) *ViewResponse {
+	res := &ViewResponse{
+		ID:      m.View.ID,
+		OwnerID: m.View.OwnerID,
+		Name:    m.View.Name,
+		Filter: ViewFilterResponse{
+			Status:          m.View.Filter.Status,
+			Tags:            m.View.Filter.Tags,
+			IncludeArchived: m.View.Filter.IncludeArchived,
+			ArchivedOnly:    m.View.Filter.ArchivedOnly,
+			Query:           m.View.Filter.Query,
+		},
+		CreatedAt: m.View.CreatedAt,
+		UpdatedAt: m.View.UpdatedAt,
+	}
+	for _, user := range m.SharedWith {
+		res.SharedWith = append(res.SharedWith, SharedUser{ID: user.ID, Name: user.Name, Email: user.Email})
+	}
+	return res
+}
@@ -0,0 +1,26 @@
+package view
+
+import "github.com/google/uuid"
+
+// ViewFilterRequest takes the same filters as the task listing.
+type ViewFilterRequest struct {
+	Status          string   `json:"status" binding:"max=32"`
+	Tags            []string `json:"tags" binding:"max=50,dive,max=64"`
+	IncludeArchived bool     `json:"include_archived"`
+	ArchivedOnly    bool     `json:"archived_only"`
+	Query           string   `json:"query" binding:"max=500"`
+}
+
+type CreateViewRequest struct {
+	Name   string            `json:"name" binding:"required,max=100"`
+	Filter ViewFilterRequest `json:"filter"`
+}
+
+type UpdateViewRequest struct {
+	Name   string            `json:"name" binding:"required,max=100"`
+	Filter ViewFilterRequest `json:"filter"`
+}
+
+type ShareViewRequest struct {
+	UserID uuid.UUID `json:"user_id" binding:"required"`
+}
@@ -0,0 +1,30 @@
+package view
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type ViewResponse struct {
+	ID         uuid.UUID          `json:"id"`
+	OwnerID    uuid.UUID          `json:"owner_id"`
+	Name       string             `json:"name"`
+	Filter     ViewFilterResponse `json:"filter"`
+	SharedWith []SharedUser       `json:"shared_with,omitempty"`
+	CreatedAt  time.Time          `json:"created_at"`
+	UpdatedAt  time.Time          `json:"updated_at"`
+}
+
+type ViewFilterResponse struct {
+	Status          string   `json:"status,omitempty"`
+	Tags            []string `json:"tags,omitempty"`
+	IncludeArchived bool     `json:"include_archived"`
+	ArchivedOnly    bool     `json:"archived_only"`
+	Query           string   `json:"query,omitempty"`
+}
+
+type SharedUser struct {
+	ID    uuid.UUID `json:"id"`
+	Name  string    `json:"name"`
+	Email string    `json:"email"`
+}
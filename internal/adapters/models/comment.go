@@ -1,8 +1,26 @@
 package models
 
-import "task-api/internal/domain/entities"
+import (
+	"github.com/google/uuid"
+	"task-api/internal/domain/entities"
+)
 
 type CommentWish struct {
-	Comment entities.Comment
-	Author  entities.User
+	Comment   entities.Comment
+	Author    entities.User
+	Reactions []ReactionSummary
+}
+
+type CommentThread struct {
+	CommentWish
+	Replies []*CommentThread
+}
+
+// ReactionSummary is the number of users who reacted to a comment with one
+// emoji and whether the viewing user is one of them.
+type ReactionSummary struct {
+	CommentID   uuid.UUID
+	Emoji       string
+	Count       int
+	ReactedByMe bool
 }
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"task-api/internal/domain/entities"
+	"time"
+)
+
+type DigestRecipient struct {
+	User     entities.User
+	Settings entities.DigestSettings
+}
+
+type DigestComment struct {
+	TaskID     uuid.UUID
+	TaskTitle  string
+	AuthorName string
+	Content    string
+	CreatedAt  time.Time
+}
+
+type DigestAssignment struct {
+	TaskID         uuid.UUID
+	TaskTitle      string
+	AssignedByName string
+	AssignedAt     time.Time
+}
+
+// Digest is everything one email digest reports, collected for the period
+// between Since and Until.
+type Digest struct {
+	User        entities.User
+	Location    *time.Location
+	Since       time.Time
+	Until       time.Time
+	Overdue     []*entities.Task
+	DueSoon     []*entities.Task
+	Comments    []*DigestComment
+	Assignments []*DigestAssignment
+}
+
+func (d *Digest) IsEmpty() bool {
+	return len(d.Overdue) == 0 && len(d.DueSoon) == 0 && len(d.Comments) == 0 && len(d.Assignments) == 0
+}
@@ -0,0 +1,9 @@
+package models
+
+import "task-api/internal/domain/entities"
+
+// TagUsage is a tag with the number of tasks, outside of the trash, it is attached to.
+type TagUsage struct {
+	Tag        entities.Tag
+	UsageCount int
+}
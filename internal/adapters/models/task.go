@@ -6,10 +6,14 @@ import (
 )
 
 type Task struct {
-	Task     entities.Task
-	Tags     []entities.Tag
-	User     entities.User
-	Comments []CommentWish
+	Task        entities.Task
+	Tags        []entities.Tag
+	User        entities.User
+	Comments    []CommentWish
+	Attachments []entities.Attachment
+	Assignees   []entities.User
+	Subtasks    []entities.Task
+	TimeTotals  []TimeTotal
 }
 
 type TasksWishTags struct {
@@ -17,6 +21,22 @@ type TasksWishTags struct {
 	Tags []entities.Tag
 }
 
+// BoardColumn holds the tasks of one status in board order.
+type BoardColumn struct {
+	Status string
+	Tasks  []*TasksWishTags
+}
+
+// TaskMove places TaskID right after AfterID, right before BeforeID or, with
+// neither, at the end of the Status column.
+type TaskMove struct {
+	TaskID   uuid.UUID
+	ActorID  uuid.UUID
+	Status   string
+	AfterID  *uuid.UUID
+	BeforeID *uuid.UUID
+}
+
 type TagWishTaskID struct {
 	TaskID uuid.UUID
 	Tag    entities.Tag
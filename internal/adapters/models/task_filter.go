@@ -0,0 +1,14 @@
+package models
+
+import "task-api/pkg/taskquery"
+
+// TaskFilter narrows task listings. Archived tasks are hidden unless requested.
+// Tags are titles of the listing user's tags; a task must have all of them.
+// Query is a parsed search query applied on top of the other filters.
+type TaskFilter struct {
+	IncludeArchived bool
+	ArchivedOnly    bool
+	Status          string
+	Tags            []string
+	Query           taskquery.Expr
+}
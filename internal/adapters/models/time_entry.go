@@ -0,0 +1,54 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"task-api/internal/domain/entities"
+	"time"
+)
+
+const (
+	TimeGroupUser = "user"
+	TimeGroupTag  = "tag"
+	TimeGroupDay  = "day"
+)
+
+type TimeEntry struct {
+	Entry entities.TimeEntry
+	User  entities.User
+}
+
+// TimeTotal is the time one user spent on a task; running timers are not counted.
+type TimeTotal struct {
+	User    entities.User
+	Seconds int64
+}
+
+// TimeReportFilter selects the finished entries started in [From, To) on tasks
+// the viewer can see. Tag is the title of one of the viewer's tags. Entries are
+// summed per combination of the GroupBy values; without any, into one row.
+type TimeReportFilter struct {
+	ViewerID uuid.UUID
+	From     time.Time
+	To       time.Time
+	UserID   *uuid.UUID
+	Tag      string
+	GroupBy  []string
+}
+
+// TimeReportRow holds only the fields of the grouping it was made with. An
+// entry on a task with several of the viewer's tags is counted for each of
+// them; Tag is empty for tasks without any.
+type TimeReportRow struct {
+	User    entities.User
+	Tag     string
+	Day     time.Time
+	Seconds int64
+	Entries int
+}
+
+type TimeReport struct {
+	Filter       TimeReportFilter
+	Rows         []*TimeReportRow
+	TotalSeconds int64
+	TotalEntries int
+}
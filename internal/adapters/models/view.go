@@ -0,0 +1,28 @@
+package models
+
+import (
+	"task-api/internal/domain/entities"
+	"task-api/pkg/taskquery"
+)
+
+// View is a saved view with the users it is shared with. SharedWith is only
+// filled for the owner.
+type View struct {
+	View       entities.View
+	SharedWith []entities.User
+}
+
+// TaskFilter returns a *taskquery.SyntaxError when the stored query is invalid.
+func (v *View) TaskFilter() (TaskFilter, error) {
+	expr, err := taskquery.Parse(v.View.Filter.Query)
+	if err != nil {
+		return TaskFilter{}, err
+	}
+	return TaskFilter{
+		IncludeArchived: v.View.Filter.IncludeArchived,
+		ArchivedOnly:    v.View.Filter.ArchivedOnly,
+		Status:          v.View.Filter.Status,
+		Tags:            v.View.Filter.Tags,
+		Query:           expr,
+	}, nil
+}
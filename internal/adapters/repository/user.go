@@ -11,6 +11,7 @@ type User struct {
 	Name      string    `db:"name"`
 	Email     string    `db:"email"`
 	Password  string    `db:"password"`
+	Role      string    `db:"role"`
 	CreatedAt time.Time `db:"created_at"`
 	UpdatedAt time.Time `db:"updated_at"`
 }
@@ -21,6 +22,7 @@ func (u *User) ToEntity() *entities.User {
 		Name:      u.Name,
 		Email:     u.Email,
 		Password:  u.Password,
+		Role:      u.Role,
 		CreatedAt: u.CreatedAt,
 		UpdatedAt: u.UpdatedAt,
 	}
@@ -32,6 +34,7 @@ func FromEntityUser(e *entities.User) *User {
 		Name:      e.Name,
 		Email:     e.Email,
 		Password:  e.Password,
+		Role:      e.Role,
 		CreatedAt: e.CreatedAt,
 		UpdatedAt: e.UpdatedAt,
 	}
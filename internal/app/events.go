@@ -0,0 +1,47 @@
+package app
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"task-api/internal/domain/entities"
+	"task-api/internal/domain/events"
+	"task-api/internal/infrastructure/eventbus"
+	"time"
+)
+
+func NewEventBus() *eventbus.MemoryBus {
+	return eventbus.NewMemoryBus()
+}
+
+// SubscribeNotifications turns comment and task events into in-app notifications.
+func SubscribeNotifications(bus *eventbus.MemoryBus, useCases *UseCases, logger *zap.Logger) {
+	handler := func(ctx context.Context, event events.Event) {
+		// Events are published from request handlers; a client that disconnects
+		// must not cancel notifications for everybody else.
+		if err := useCases.notifyUseCase.Notify(context.WithoutCancel(ctx), event); err != nil {
+			logger.Error("failed to create notifications", zap.String("event", event.EventName()), zap.Error(err))
+		}
+	}
+	for _, name := range []string{
+		events.CommentCreatedEvent,
+		events.CommentMentionedEvent,
+		events.TaskStatusChangedEvent,
+		events.TaskAssignedEvent,
+	} {
+		bus.Subscribe(name, handler)
+	}
+}
+
+// SubscribeRecurrence spawns the next instance of a recurring task as soon as
+// it is done; RunRecurrence covers the tasks whose due date passes first.
+func SubscribeRecurrence(bus *eventbus.MemoryBus, useCases *UseCases, logger *zap.Logger) {
+	bus.Subscribe(events.TaskStatusChangedEvent, func(ctx context.Context, event events.Event) {
+		changed, ok := event.(events.TaskStatusChanged)
+		if !ok || changed.NewStatus != entities.TaskStatusDone {
+			return
+		}
+		if _, err := useCases.taskUseCase.SpawnNext(context.WithoutCancel(ctx), changed.TaskID, time.Now()); err != nil {
+			logger.Error("failed to spawn recurring task", zap.String("task_id", changed.TaskID.String()), zap.Error(err))
+		}
+	})
+}
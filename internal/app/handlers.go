@@ -1,17 +1,26 @@
 package app
 
 import (
+	"task-api/internal/infrastructure/api/http/attachment"
 	"task-api/internal/infrastructure/api/http/auth/login"
 	"task-api/internal/infrastructure/api/http/auth/logout"
 	"task-api/internal/infrastructure/api/http/auth/me"
+	"task-api/internal/infrastructure/api/http/auth/oidc"
 	"task-api/internal/infrastructure/api/http/auth/refresh"
 	"task-api/internal/infrastructure/api/http/auth/registr"
+	"task-api/internal/infrastructure/api/http/auth/tokens"
 	"task-api/internal/infrastructure/api/http/comment"
+	"task-api/internal/infrastructure/api/http/health"
+	"task-api/internal/infrastructure/api/http/notification"
 	"task-api/internal/infrastructure/api/http/tag"
 	"task-api/internal/infrastructure/api/http/task"
+	"task-api/internal/infrastructure/api/http/template"
+	"task-api/internal/infrastructure/api/http/timeentry"
 	"task-api/internal/infrastructure/api/http/user"
+	"task-api/internal/infrastructure/api/http/view"
 	"task-api/internal/infrastructure/security"
 	"task-api/pkg/config"
+	"task-api/pkg/connectors"
 )
 
 type Handlers struct {
@@ -24,9 +33,17 @@ type Handlers struct {
 	logoutHandler  *logout.Handler
 	meHandler      *me.Handler
 	refreshHandler *refresh.Handler
+	oidcHandler    *oidc.Handler
+	tokensHandler  *tokens.Handler
+	healthHandler  *health.Handler
+	attachHandler  *attachment.Handler
+	notifyHandler  *notification.Handler
+	viewHandler    *view.Handler
+	templHandler   *template.Handler
+	timeHandler    *timeentry.Handler
 }
 
-func NewHandlers(useCase *UseCases, cfg *config.AppConfig, blackListToken *security.TokenBlacklist) *Handlers {
+func NewHandlers(useCase *UseCases, cfg *config.AppConfig, blackListToken *security.TokenBlacklist, pool *connectors.PostgresConnect) *Handlers {
 	return &Handlers{
 		taskHandler:    task.NewTaskHandler(useCase.taskUseCase),
 		tagHandler:     tag.NewTagHandler(useCase.tagUseCase),
@@ -38,5 +55,13 @@ func NewHandlers(useCase *UseCases, cfg *config.AppConfig, blackListToken *secur
 		logoutHandler:  logout.NewAuthHandler(*cfg, blackListToken),
 		meHandler:      me.NewAuthHandler(useCase.userUseCase),
 		refreshHandler: refresh.NewAuthHandler(useCase.authUseCase, *cfg),
+		oidcHandler:    oidc.NewAuthHandler(useCase.authUseCase, *cfg, security.NewOIDCProvider(cfg.OIDC), security.NewOIDCStateStore()),
+		tokensHandler:  tokens.NewAuthHandler(useCase.tokenUseCase, *cfg),
+		healthHandler:  health.NewHealthHandler(pool.Pool),
+		attachHandler:  attachment.NewAttachmentHandler(useCase.attachmentUseCase, *cfg),
+		notifyHandler:  notification.NewNotificationHandler(useCase.notifyUseCase, useCase.digestUseCase),
+		viewHandler:    view.NewViewHandler(useCase.viewUseCase),
+		templHandler:   template.NewTemplateHandler(useCase.templateUseCase),
+		timeHandler:    timeentry.NewTimeEntryHandler(useCase.timeEntryUseCase),
 	}
 }
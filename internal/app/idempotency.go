@@ -0,0 +1,10 @@
+package app
+
+import (
+	"task-api/internal/infrastructure/idempotency"
+	"task-api/pkg/connectors"
+)
+
+func NewIdempotencyStore(pool *connectors.PostgresConnect) idempotency.Store {
+	return idempotency.NewPostgresStore(pool.Pool)
+}
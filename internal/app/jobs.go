@@ -0,0 +1,118 @@
+package app
+
+import (
+	"context"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"task-api/pkg/config"
+	"time"
+)
+
+// RunTrashPurge permanently removes tasks and comments that stayed in the trash
+// longer than the configured retention.
+func RunTrashPurge(lc fx.Lifecycle, cfg *config.AppConfig, useCases *UseCases, logger *zap.Logger) {
+	if cfg.Trash.RetentionDays <= 0 {
+		return
+	}
+	retention := time.Duration(cfg.Trash.RetentionDays) * 24 * time.Hour
+	runPeriodic(lc, logger, "trash purge", cfg.Trash.PurgeInterval, func(ctx context.Context) error {
+		before := time.Now().Add(-retention)
+		// Blobs are removed first: their rows disappear with the tasks through ON DELETE CASCADE.
+		if _, err := useCases.attachmentUseCase.PurgeForDeletedTasks(ctx, before); err != nil {
+			return err
+		}
+		tasks, err := useCases.taskUseCase.PurgeTrash(ctx, before)
+		if err != nil {
+			return err
+		}
+		comments, err := useCases.commentUseCase.PurgeDeleted(ctx, before)
+		if err != nil {
+			return err
+		}
+		if tasks > 0 || comments > 0 {
+			logger.Info("trash purged", zap.Int64("tasks", tasks), zap.Int64("comments", comments))
+		}
+		return nil
+	})
+}
+
+// RunAutoArchive archives tasks that have been done for longer than the configured number of days.
+func RunAutoArchive(lc fx.Lifecycle, cfg *config.AppConfig, useCases *UseCases, logger *zap.Logger) {
+	if cfg.Archive.AutoArchiveDays <= 0 {
+		return
+	}
+	age := time.Duration(cfg.Archive.AutoArchiveDays) * 24 * time.Hour
+	runPeriodic(lc, logger, "auto archive", cfg.Archive.Interval, func(ctx context.Context) error {
+		archived, err := useCases.taskUseCase.AutoArchive(ctx, time.Now().Add(-age))
+		if err != nil {
+			return err
+		}
+		if archived > 0 {
+			logger.Info("tasks auto-archived", zap.Int64("count", archived))
+		}
+		return nil
+	})
+}
+
+// RunEmailDigest sends the daily email digests. Every run only mails the users
+// whose local send hour has passed and who have not received today's digest.
+func RunEmailDigest(lc fx.Lifecycle, cfg *config.AppConfig, useCases *UseCases, logger *zap.Logger) {
+	if !cfg.Digest.Enabled {
+		return
+	}
+	runPeriodic(lc, logger, "email digest", cfg.Digest.Interval, func(ctx context.Context) error {
+		sent, err := useCases.digestUseCase.SendDue(ctx, time.Now())
+		if sent > 0 {
+			logger.Info("email digests sent", zap.Int("count", sent))
+		}
+		return err
+	})
+}
+
+// RunRecurrence spawns the next instance of recurring tasks whose due date has
+// passed, so that a series goes on even when a task is never completed.
+func RunRecurrence(lc fx.Lifecycle, cfg *config.AppConfig, useCases *UseCases, logger *zap.Logger) {
+	runPeriodic(lc, logger, "recurrence", cfg.Recurrence.Interval, func(ctx context.Context) error {
+		spawned, err := useCases.taskUseCase.SpawnRecurrences(ctx, time.Now())
+		if spawned > 0 {
+			logger.Info("recurring tasks spawned", zap.Int("count", spawned))
+		}
+		return err
+	})
+}
+
+// runPeriodic calls fn every interval until the application stops. A failed
+// run is logged and retried on the next tick.
+func runPeriodic(lc fx.Lifecycle, logger *zap.Logger, name string, interval time.Duration, fn func(ctx context.Context) error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			go func() {
+				defer close(done)
+				ticker := time.NewTicker(interval)
+				defer ticker.Stop()
+				for {
+					if err := fn(ctx); err != nil && ctx.Err() == nil {
+						logger.Error("background job failed", zap.String("job", name), zap.Error(err))
+					}
+					select {
+					case <-ctx.Done():
+						return
+					case <-ticker.C:
+					}
+				}
+			}()
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+			case <-stopCtx.Done():
+			}
+			return nil
+		},
+	})
+}
@@ -0,0 +1,20 @@
+package app
+
+import (
+	"task-api/internal/infrastructure/mailer"
+	"task-api/pkg/config"
+)
+
+func NewMailer(cfg *config.AppConfig) (mailer.Mailer, error) {
+	if cfg.Mail.Backend == "smtp" {
+		return mailer.NewSMTPMailer(mailer.SMTPConfig{
+			Host:     cfg.Mail.SMTPHost,
+			Port:     cfg.Mail.SMTPPort,
+			Username: cfg.Mail.SMTPUsername,
+			Password: cfg.Mail.SMTPPassword,
+			From:     cfg.Mail.From,
+			Timeout:  cfg.Mail.SMTPTimeout,
+		}), nil
+	}
+	return mailer.NewFileMailer(cfg.Mail.FileDir, cfg.Mail.From)
+}
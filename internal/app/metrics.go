@@ -0,0 +1,62 @@
+package app
+
+import (
+	"context"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"net/http"
+	"task-api/internal/infrastructure/metrics"
+	"task-api/pkg/config"
+	"task-api/pkg/connectors"
+	"time"
+)
+
+func NewMetricsRegistry(pg *connectors.PostgresConnect) *prometheus.Registry {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	registry.MustRegister(metrics.Collectors()...)
+	registry.MustRegister(metrics.PoolCollectors(pg.Pool)...)
+	return registry
+}
+
+func metricsHandler(registry *prometheus.Registry) http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{Registry: registry})
+}
+
+// RunMetricsServer serves /metrics on a separate admin listener so that it
+// does not have to be exposed together with the public API.
+func RunMetricsServer(lc fx.Lifecycle, cfg *config.AppConfig, registry *prometheus.Registry, logger *zap.Logger) {
+	if !cfg.Metrics.Enabled || cfg.Metrics.Address == "" {
+		return
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metricsHandler(registry))
+	svr := &http.Server{
+		Addr:              cfg.Metrics.Address,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			go func() {
+				logger.Info("starting metrics server", zap.String("address", cfg.Metrics.Address))
+				if err := svr.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+					logger.Error("metrics server failed", zap.Error(err))
+				}
+			}()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			shutdownCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+			defer cancel()
+			return svr.Shutdown(shutdownCtx)
+		},
+	})
+}
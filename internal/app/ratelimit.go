@@ -0,0 +1,30 @@
+package app
+
+import (
+	"task-api/internal/infrastructure/api/middleware"
+	"task-api/internal/infrastructure/ratelimit"
+	"task-api/pkg/config"
+	"task-api/pkg/connectors"
+)
+
+func NewRateLimiter(cfg *config.AppConfig, pool *connectors.PostgresConnect) ratelimit.Limiter {
+	if cfg.RateLimit.Backend == "postgres" {
+		return ratelimit.NewPostgresLimiter(pool.Pool)
+	}
+	return ratelimit.NewMemoryLimiter()
+}
+
+func rateLimitPolicies(cfg *config.AppConfig) []middleware.RateLimitPolicy {
+	return []middleware.RateLimitPolicy{
+		{
+			Name:   "auth",
+			Prefix: "/api/v1/auth",
+			Limit:  ratelimit.Limit{Rate: cfg.RateLimit.AuthRate, Burst: cfg.RateLimit.AuthBurst},
+		},
+		{
+			Name:   "api",
+			Prefix: "/api/v1",
+			Limit:  ratelimit.Limit{Rate: cfg.RateLimit.APIRate, Burst: cfg.RateLimit.APIBurst},
+		},
+	}
+}
@@ -11,6 +11,14 @@ type Repositories struct {
 	commentRepo      *postgres.CommentRepository
 	userRepo         *postgres.UserRepository
 	refreshTokenRepo *postgres.RefreshTokenPostgresRepository
+	identityRepo     *postgres.IdentityRepository
+	accessTokenRepo  *postgres.AccessTokenRepository
+	attachmentRepo   *postgres.AttachmentRepository
+	notificationRepo *postgres.NotificationRepository
+	digestRepo       *postgres.DigestRepository
+	viewRepo         *postgres.ViewRepository
+	templateRepo     *postgres.TemplateRepository
+	timeEntryRepo    *postgres.TimeEntryRepository
 }
 
 func NewRopositories(pool *connectors.PostgresConnect) *Repositories {
@@ -20,5 +28,13 @@ func NewRopositories(pool *connectors.PostgresConnect) *Repositories {
 		commentRepo:      postgres.NewCommentRepository(pool.Pool),
 		userRepo:         postgres.NewUserRepository(pool.Pool),
 		refreshTokenRepo: postgres.NewRefreshTokenPostgresRepository(pool.Pool),
+		identityRepo:     postgres.NewIdentityRepository(pool.Pool),
+		accessTokenRepo:  postgres.NewAccessTokenRepository(pool.Pool),
+		attachmentRepo:   postgres.NewAttachmentRepository(pool.Pool),
+		notificationRepo: postgres.NewNotificationRepository(pool.Pool),
+		digestRepo:       postgres.NewDigestRepository(pool.Pool),
+		viewRepo:         postgres.NewViewRepository(pool.Pool),
+		templateRepo:     postgres.NewTemplateRepository(pool.Pool),
+		timeEntryRepo:    postgres.NewTimeEntryRepository(pool.Pool),
 	}
 }
@@ -2,39 +2,75 @@ package app
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
+	"task-api/internal/infrastructure/api/http/attachment"
 	"task-api/internal/infrastructure/api/http/auth/login"
 	"task-api/internal/infrastructure/api/http/auth/logout"
 	"task-api/internal/infrastructure/api/http/auth/me"
+	"task-api/internal/infrastructure/api/http/auth/oidc"
 	"task-api/internal/infrastructure/api/http/auth/refresh"
 	"task-api/internal/infrastructure/api/http/auth/registr"
+	"task-api/internal/infrastructure/api/http/auth/tokens"
 	"task-api/internal/infrastructure/api/http/comment"
+	"task-api/internal/infrastructure/api/http/health"
+	"task-api/internal/infrastructure/api/http/notification"
 	"task-api/internal/infrastructure/api/http/tag"
 	"task-api/internal/infrastructure/api/http/task"
+	"task-api/internal/infrastructure/api/http/template"
+	"task-api/internal/infrastructure/api/http/timeentry"
 	"task-api/internal/infrastructure/api/http/user"
+	"task-api/internal/infrastructure/api/http/view"
 	"task-api/internal/infrastructure/api/middleware"
+	"task-api/internal/infrastructure/idempotency"
+	"task-api/internal/infrastructure/ratelimit"
 	"task-api/internal/infrastructure/security"
 	"task-api/pkg/config"
 )
 
-func RegisterRoutes(router *gin.Engine, cfg *config.AppConfig, handers *Handlers, blackListToken *security.TokenBlacklist) {
+func RegisterRoutes(router *gin.Engine, cfg *config.AppConfig, handers *Handlers, useCases *UseCases, blackListToken *security.TokenBlacklist, limiter ratelimit.Limiter, idempotencyStore idempotency.Store, registry *prometheus.Registry) {
 	// Middleware
 	router.Use(middleware.TracingMiddleware())
+	if cfg.Metrics.Enabled {
+		router.Use(middleware.MetricsMiddleware())
+	}
 	router.Use(middleware.RecoveryMiddleware())
 	router.Use(middleware.LoggerMiddleware())
+	if cfg.RateLimit.Enabled {
+		router.Use(middleware.RateLimitMiddleware(*cfg, limiter, rateLimitPolicies(cfg)))
+	}
+	// Health
+	health.Router(router, handers.healthHandler)
+	// Metrics
+	if cfg.Metrics.Enabled && cfg.Metrics.Address == "" {
+		router.GET("/metrics", gin.WrapH(metricsHandler(registry)))
+	}
 	// Swagger
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
+	authMiddleware := middleware.AuthMiddleware(*cfg, blackListToken, useCases.tokenUseCase)
+	idempotencyMiddleware := middleware.IdempotencyMiddleware(idempotencyStore, cfg.Idempotency.TTL)
+	adminMiddleware := middleware.RequireAdmin(useCases.userUseCase)
+
 	// Routes
-	task.Router(router, handers.taskHandler, *cfg, blackListToken)
-	tag.Router(router, handers.tagHandler, *cfg, blackListToken)
-	comment.Router(router, handers.commentHandler, *cfg, blackListToken)
-	user.Router(router, handers.userHandler, *cfg, blackListToken)
+	task.Router(router, handers.taskHandler, authMiddleware, idempotencyMiddleware)
+	tag.Router(router, handers.tagHandler, authMiddleware)
+	comment.Router(router, handers.commentHandler, authMiddleware, adminMiddleware, idempotencyMiddleware)
+	user.Router(router, handers.userHandler, authMiddleware)
+	attachment.Router(router, handers.attachHandler, authMiddleware)
+	notification.Router(router, handers.notifyHandler, authMiddleware)
+	view.Router(router, handers.viewHandler, authMiddleware)
+	template.Router(router, handers.templHandler, authMiddleware, idempotencyMiddleware)
+	timeentry.Router(router, handers.timeHandler, authMiddleware, idempotencyMiddleware)
 	//Auth Routes
 	login.Router(router, handers.loginHandler)
 	registr.Router(router, handers.registHandler)
-	logout.Router(router, handers.logoutHandler)
-	me.Router(router, handers.meHandler, *cfg, blackListToken)
+	logout.Router(router, handers.logoutHandler, authMiddleware)
+	me.Router(router, handers.meHandler, authMiddleware)
 	refresh.Router(router, handers.refreshHandler)
+	tokens.Router(router, handers.tokensHandler, authMiddleware)
+	if cfg.OIDC.Enabled {
+		oidc.Router(router, handers.oidcHandler)
+	}
 }
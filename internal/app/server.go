@@ -10,7 +10,7 @@ import (
 	"time"
 )
 
-func RunHTTPServer(lc fx.Lifecycle, engine *gin.Engine, cfg *config.AppConfig, logger *zap.Logger) {
+func RunHTTPServer(lc fx.Lifecycle, engine *gin.Engine, cfg *config.AppConfig, handers *Handlers, logger *zap.Logger) {
 	svr := &http.Server{
 		Addr:    cfg.AddressServer,
 		Handler: engine,
@@ -24,9 +24,17 @@ func RunHTTPServer(lc fx.Lifecycle, engine *gin.Engine, cfg *config.AppConfig, l
 					logger.Fatal("failed to start server", zap.Error(err))
 				}
 			}()
+			handers.healthHandler.SetReady(true)
 			return nil
 		},
 		OnStop: func(ctx context.Context) error {
+			// Report not ready first so that load balancers stop sending traffic before the listener closes.
+			handers.healthHandler.SetReady(false)
+			logger.Info("draining HTTP server", zap.Duration("delay", cfg.ShutdownDrainDelay))
+			select {
+			case <-time.After(cfg.ShutdownDrainDelay):
+			case <-ctx.Done():
+			}
 			logger.Info("Shutting down HTTP server...")
 			shutdownCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
 			defer cancel()
@@ -0,0 +1,22 @@
+package app
+
+import (
+	"net/http"
+	"task-api/internal/infrastructure/storage"
+	"task-api/pkg/config"
+	"time"
+)
+
+func NewBlobStore(cfg *config.AppConfig) (storage.BlobStore, error) {
+	if cfg.Storage.Backend == "s3" {
+		return storage.NewS3Store(storage.S3Config{
+			Endpoint:  cfg.Storage.S3.Endpoint,
+			Region:    cfg.Storage.S3.Region,
+			Bucket:    cfg.Storage.S3.Bucket,
+			AccessKey: cfg.Storage.S3.AccessKey,
+			SecretKey: cfg.Storage.S3.SecretKey,
+			PathStyle: cfg.Storage.S3.PathStyle,
+		}, &http.Client{Timeout: 5 * time.Minute}), nil
+	}
+	return storage.NewLocalStore(cfg.Storage.LocalPath)
+}
@@ -1,21 +1,41 @@
 package app
 
-import "task-api/internal/usecases"
+import (
+	"task-api/internal/infrastructure/eventbus"
+	"task-api/internal/infrastructure/mailer"
+	"task-api/internal/infrastructure/storage"
+	"task-api/internal/usecases"
+	"task-api/pkg/config"
+)
 
 type UseCases struct {
-	taskUseCase    usecases.TaskUseCase
-	tagUseCase     usecases.TagUseCase
-	commentUseCase usecases.CommentUseCase
-	userUseCase    usecases.UserUseCase
-	authUseCase    usecases.AuthUseCase
+	taskUseCase       usecases.TaskUseCase
+	tagUseCase        usecases.TagUseCase
+	commentUseCase    usecases.CommentUseCase
+	userUseCase       usecases.UserUseCase
+	authUseCase       usecases.AuthUseCase
+	tokenUseCase      usecases.AccessTokenUseCase
+	attachmentUseCase usecases.AttachmentUseCase
+	notifyUseCase     usecases.NotificationUseCase
+	digestUseCase     usecases.DigestUseCase
+	viewUseCase       usecases.ViewUseCase
+	templateUseCase   usecases.TemplateUseCase
+	timeEntryUseCase  usecases.TimeEntryUseCase
 }
 
-func NewUseCases(repos *Repositories) *UseCases {
+func NewUseCases(repos *Repositories, blobs storage.BlobStore, bus *eventbus.MemoryBus, mail mailer.Mailer, cfg *config.AppConfig) *UseCases {
 	return &UseCases{
-		taskUseCase:    usecases.NewTasksUseCase(repos.taskRepo),
-		tagUseCase:     usecases.NewTagsUseCase(repos.tagRepo),
-		commentUseCase: usecases.NewCommentUseCase(repos.commentRepo),
-		userUseCase:    usecases.NewUserUseCase(repos.userRepo),
-		authUseCase:    usecases.NewAuthUseCase(repos.userRepo, repos.refreshTokenRepo),
+		taskUseCase:       usecases.NewTasksUseCase(repos.taskRepo, bus),
+		tagUseCase:        usecases.NewTagsUseCase(repos.tagRepo, repos.taskRepo),
+		commentUseCase:    usecases.NewCommentUseCase(repos.commentRepo, repos.taskRepo, repos.userRepo, bus),
+		userUseCase:       usecases.NewUserUseCase(repos.userRepo),
+		authUseCase:       usecases.NewAuthUseCase(repos.userRepo, repos.refreshTokenRepo, repos.identityRepo),
+		tokenUseCase:      usecases.NewAccessTokenUseCase(repos.accessTokenRepo),
+		attachmentUseCase: usecases.NewAttachmentUseCase(repos.attachmentRepo, repos.taskRepo, blobs),
+		notifyUseCase:     usecases.NewNotificationUseCase(repos.notificationRepo, repos.taskRepo),
+		digestUseCase:     usecases.NewDigestUseCase(repos.digestRepo, mail, cfg.Digest.SendHour, cfg.Digest.DueSoonWindow),
+		viewUseCase:       usecases.NewViewUseCase(repos.viewRepo, repos.taskRepo),
+		templateUseCase:   usecases.NewTemplateUseCase(repos.templateRepo, repos.taskRepo),
+		timeEntryUseCase:  usecases.NewTimeEntryUseCase(repos.timeEntryRepo, repos.taskRepo),
 	}
 }
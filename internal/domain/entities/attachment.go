@@ -0,0 +1,18 @@
+package entities
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type Attachment struct {
+	ID          uuid.UUID
+	TaskID      uuid.UUID
+	CommentID   *uuid.UUID
+	UploadedBy  uuid.UUID
+	FileName    string
+	ContentType string
+	Size        int64
+	StorageKey  string
+	CreatedAt   time.Time
+}
@@ -8,8 +8,27 @@ import (
 type Comment struct {
 	ID        uuid.UUID
 	TaskID    uuid.UUID
+	ParentID  *uuid.UUID
 	Author    uuid.UUID
 	Content   string
 	CreatedAt time.Time
 	UpdatedAt time.Time
+	EditedAt  *time.Time
+	DeletedAt *time.Time
+}
+
+// CommentRevision keeps the content a comment had before an edit.
+type CommentRevision struct {
+	ID        uuid.UUID
+	CommentID uuid.UUID
+	Content   string
+	EditedBy  *uuid.UUID
+	CreatedAt time.Time
+}
+
+type CommentReaction struct {
+	CommentID uuid.UUID
+	UserID    uuid.UUID
+	Emoji     string
+	CreatedAt time.Time
 }
@@ -0,0 +1,17 @@
+package entities
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+const DefaultDigestTimezone = "UTC"
+
+// DigestSettings controls the daily email digest of a user. Users without
+// stored settings receive the digest in UTC.
+type DigestSettings struct {
+	UserID     uuid.UUID
+	Enabled    bool
+	Timezone   string
+	LastSentAt *time.Time
+}
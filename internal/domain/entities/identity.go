@@ -0,0 +1,16 @@
+package entities
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type Identity struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	Provider  string
+	Subject   string
+	Email     string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -0,0 +1,49 @@
+package entities
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	NotificationTaskCommented     = "task_commented"
+	NotificationMentioned         = "mentioned"
+	NotificationTaskStatusChanged = "task_status_changed"
+	NotificationTaskAssigned      = "task_assigned"
+)
+
+var NotificationTypes = []string{
+	NotificationTaskCommented,
+	NotificationMentioned,
+	NotificationTaskStatusChanged,
+	NotificationTaskAssigned,
+}
+
+func IsValidNotificationType(t string) bool {
+	for _, nt := range NotificationTypes {
+		if nt == t {
+			return true
+		}
+	}
+	return false
+}
+
+type Notification struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	Type      string
+	TaskID    *uuid.UUID
+	CommentID *uuid.UUID
+	ActorID   *uuid.UUID
+	Data      map[string]string
+	CreatedAt time.Time
+	ReadAt    *time.Time
+}
+
+// NotificationPreference turns one notification type on or off for a user.
+// Types without a stored preference are enabled.
+type NotificationPreference struct {
+	UserID  uuid.UUID
+	Type    string
+	Enabled bool
+}
@@ -5,9 +5,13 @@ import (
 	"time"
 )
 
+// Tag belongs to the user who created it; titles are unique per owner.
 type Tag struct {
-	ID        uuid.UUID
-	Title     string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID          uuid.UUID
+	OwnerID     uuid.UUID
+	Title       string
+	Color       string
+	Description string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
 }
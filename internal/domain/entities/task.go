@@ -5,12 +5,35 @@ import (
 	"time"
 )
 
+const (
+	TaskStatusNew  = "new"
+	TaskStatusDone = "done"
+)
+
+const (
+	TaskPriorityLow    = "low"
+	TaskPriorityMedium = "medium"
+	TaskPriorityHigh   = "high"
+)
+
 type Task struct {
 	ID          uuid.UUID
 	Title       string
 	Description string
 	Status      string
+	Priority    string
+	// ParentID is set on subtasks.
+	ParentID *uuid.UUID
+	// Rank orders the tasks of the creator's board; see pkg/rank.
+	Rank        string
 	CreatedBy   uuid.UUID
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
+	DeletedAt   *time.Time
+	DueAt       *time.Time
+	CompletedAt *time.Time
+	ArchivedAt  *time.Time
+	// Recurrence is an RRULE; the next instance is created from this task
+	// when it is done or its due date passes. Empty for one-off tasks.
+	Recurrence string
 }
@@ -0,0 +1,29 @@
+package entities
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Template describes a task, with its subtasks, that its owner can create
+// again and again. Titles and descriptions may contain {{name}} variables.
+type Template struct {
+	ID          uuid.UUID
+	OwnerID     uuid.UUID
+	Name        string
+	Title       string
+	Description string
+	Priority    string
+	// Tags are titles of the owner's tags put on every task created from the template.
+	Tags      []string
+	Subtasks  []TemplateSubtask
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// TemplateSubtask is stored as JSON.
+type TemplateSubtask struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Priority    string `json:"priority,omitempty"`
+}
@@ -0,0 +1,26 @@
+package entities
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// TimeEntry is time a user spent on a task, either tracked with a timer or
+// entered by hand. A running timer has no EndedAt yet.
+type TimeEntry struct {
+	ID        uuid.UUID
+	TaskID    uuid.UUID
+	UserID    uuid.UUID
+	StartedAt time.Time
+	EndedAt   *time.Time
+	Note      string
+	CreatedAt time.Time
+}
+
+// Duration is zero while the timer is running.
+func (e *TimeEntry) Duration() time.Duration {
+	if e.EndedAt == nil {
+		return 0
+	}
+	return e.EndedAt.Sub(e.StartedAt)
+}
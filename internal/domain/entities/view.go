@@ -0,0 +1,26 @@
+package entities
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// View is a saved task filter. The owner can share it with other users, who
+// may run it against their own tasks but not change it.
+type View struct {
+	ID        uuid.UUID
+	OwnerID   uuid.UUID
+	Name      string
+	Filter    ViewFilter
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// ViewFilter is stored as JSON; its fields mirror the task listing filters.
+type ViewFilter struct {
+	Status          string   `json:"status,omitempty"`
+	Tags            []string `json:"tags,omitempty"`
+	IncludeArchived bool     `json:"include_archived,omitempty"`
+	ArchivedOnly    bool     `json:"archived_only,omitempty"`
+	Query           string   `json:"query,omitempty"`
+}
@@ -0,0 +1,94 @@
+package events
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"time"
+)
+
+type Event interface {
+	EventName() string
+}
+
+type Handler func(ctx context.Context, event Event)
+
+type Publisher interface {
+	Publish(ctx context.Context, event Event)
+}
+
+const CommentMentionedEvent = "comment.mentioned"
+
+// CommentMentioned is published for users who were newly @mentioned in a
+// comment, either when it is created or when an edit adds the mention.
+type CommentMentioned struct {
+	CommentID  uuid.UUID
+	TaskID     uuid.UUID
+	AuthorID   uuid.UUID
+	UserIDs    []uuid.UUID
+	OccurredAt time.Time
+}
+
+func (CommentMentioned) EventName() string { return CommentMentionedEvent }
+
+const (
+	CommentReactionAddedEvent   = "comment.reaction_added"
+	CommentReactionRemovedEvent = "comment.reaction_removed"
+)
+
+type CommentReactionAdded struct {
+	CommentID  uuid.UUID
+	TaskID     uuid.UUID
+	UserID     uuid.UUID
+	Emoji      string
+	OccurredAt time.Time
+}
+
+func (CommentReactionAdded) EventName() string { return CommentReactionAddedEvent }
+
+type CommentReactionRemoved struct {
+	CommentID  uuid.UUID
+	TaskID     uuid.UUID
+	UserID     uuid.UUID
+	Emoji      string
+	OccurredAt time.Time
+}
+
+func (CommentReactionRemoved) EventName() string { return CommentReactionRemovedEvent }
+
+const CommentCreatedEvent = "comment.created"
+
+// CommentCreated carries the users mentioned in the comment so that consumers
+// can avoid notifying them twice.
+type CommentCreated struct {
+	CommentID  uuid.UUID
+	TaskID     uuid.UUID
+	AuthorID   uuid.UUID
+	Mentioned  []uuid.UUID
+	OccurredAt time.Time
+}
+
+func (CommentCreated) EventName() string { return CommentCreatedEvent }
+
+const (
+	TaskStatusChangedEvent = "task.status_changed"
+	TaskAssignedEvent      = "task.assigned"
+)
+
+type TaskStatusChanged struct {
+	TaskID     uuid.UUID
+	ActorID    uuid.UUID
+	OldStatus  string
+	NewStatus  string
+	OccurredAt time.Time
+}
+
+func (TaskStatusChanged) EventName() string { return TaskStatusChangedEvent }
+
+type TaskAssigned struct {
+	TaskID     uuid.UUID
+	UserID     uuid.UUID
+	AssignedBy uuid.UUID
+	OccurredAt time.Time
+}
+
+func (TaskAssigned) EventName() string { return TaskAssignedEvent }
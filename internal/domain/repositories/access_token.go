@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"task-api/internal/domain/entities"
+	"time"
+)
+
+type AccessTokenRepository interface {
+	Create(ctx context.Context, token *entities.AccessToken) error
+	GetByHash(ctx context.Context, hash string) (*entities.AccessToken, error)
+	GetAllByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.AccessToken, error)
+	Revoke(ctx context.Context, id, userID uuid.UUID, revokedAt time.Time) (bool, error)
+	UpdateLastUsed(ctx context.Context, id uuid.UUID, lastUsedAt time.Time) error
+}
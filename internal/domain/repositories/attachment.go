@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"task-api/internal/domain/entities"
+	"time"
+)
+
+type AttachmentRepository interface {
+	Create(ctx context.Context, attachment *entities.Attachment) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.Attachment, error)
+	GetAllByTaskID(ctx context.Context, taskID uuid.UUID) ([]*entities.Attachment, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	GetAllForDeletedTasks(ctx context.Context, before time.Time) ([]*entities.Attachment, error)
+}
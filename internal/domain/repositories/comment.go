@@ -5,12 +5,21 @@ import (
 	"github.com/google/uuid"
 	"task-api/internal/adapters/models"
 	"task-api/internal/domain/entities"
+	"time"
 )
 
 type CommentRepository interface {
 	GetAll(ctx context.Context) ([]*models.CommentWish, error)
 	Create(ctx context.Context, tag *entities.Comment) error
 	GetByID(ctx context.Context, id uuid.UUID) (*models.CommentWish, error)
-	Update(ctx context.Context, comment *entities.Comment) error
-	Delete(ctx context.Context, id uuid.UUID) error
+	GetRootsByTaskID(ctx context.Context, taskID uuid.UUID, limit, offset int) ([]*models.CommentWish, int, error)
+	GetReplies(ctx context.Context, rootIDs []uuid.UUID) ([]*models.CommentWish, error)
+	Update(ctx context.Context, comment *entities.Comment, editedBy uuid.UUID) error
+	GetRevisions(ctx context.Context, commentID uuid.UUID) ([]*entities.CommentRevision, error)
+	SetMentions(ctx context.Context, commentID uuid.UUID, userIDs []uuid.UUID) ([]uuid.UUID, error)
+	AddReaction(ctx context.Context, reaction *entities.CommentReaction) (bool, error)
+	RemoveReaction(ctx context.Context, commentID, userID uuid.UUID, emoji string) (bool, error)
+	GetReactions(ctx context.Context, commentIDs []uuid.UUID, viewerID uuid.UUID) ([]*models.ReactionSummary, error)
+	Delete(ctx context.Context, id uuid.UUID, deletedAt time.Time) error
+	PurgeDeleted(ctx context.Context, before time.Time) (int64, error)
 }
@@ -0,0 +1,19 @@
+package repositories
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"task-api/internal/adapters/models"
+	"task-api/internal/domain/entities"
+	"time"
+)
+
+type DigestRepository interface {
+	GetSettings(ctx context.Context, userID uuid.UUID) (*entities.DigestSettings, error)
+	SetSettings(ctx context.Context, settings *entities.DigestSettings) error
+	GetRecipients(ctx context.Context) ([]*models.DigestRecipient, error)
+	GetDueTasks(ctx context.Context, userID uuid.UUID, dueBefore time.Time) ([]*entities.Task, error)
+	GetNewComments(ctx context.Context, userID uuid.UUID, since time.Time) ([]*models.DigestComment, error)
+	GetNewAssignments(ctx context.Context, userID uuid.UUID, since time.Time) ([]*models.DigestAssignment, error)
+	MarkSent(ctx context.Context, userID uuid.UUID, sentAt time.Time) error
+}
@@ -0,0 +1,12 @@
+package repositories
+
+import (
+	"context"
+	"task-api/internal/domain/entities"
+)
+
+type IdentityRepository interface {
+	Create(ctx context.Context, identity *entities.Identity) error
+	GetByProviderSubject(ctx context.Context, provider, subject string) (*entities.Identity, error)
+	Update(ctx context.Context, identity *entities.Identity) error
+}
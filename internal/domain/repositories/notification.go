@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"task-api/internal/domain/entities"
+	"time"
+)
+
+type NotificationRepository interface {
+	Create(ctx context.Context, notification *entities.Notification) (bool, error)
+	GetByUserID(ctx context.Context, userID uuid.UUID, unreadOnly bool, limit, offset int) ([]*entities.Notification, error)
+	CountUnread(ctx context.Context, userID uuid.UUID) (int, error)
+	MarkRead(ctx context.Context, id, userID uuid.UUID, readAt time.Time) (bool, error)
+	MarkAllRead(ctx context.Context, userID uuid.UUID, readAt time.Time) (int64, error)
+	GetPreferences(ctx context.Context, userID uuid.UUID) ([]*entities.NotificationPreference, error)
+	SetPreferences(ctx context.Context, preferences []*entities.NotificationPreference) error
+}
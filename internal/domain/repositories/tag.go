@@ -3,13 +3,17 @@ package repositories
 import (
 	"context"
 	"github.com/google/uuid"
+	"task-api/internal/adapters/models"
 	"task-api/internal/domain/entities"
 )
 
 type TagRepository interface {
-	GetAllTags(ctx context.Context) ([]*entities.Tag, error)
+	ListTags(ctx context.Context, ownerID uuid.UUID, prefix string, limit int) ([]*models.TagUsage, error)
+	GetTagUsage(ctx context.Context, id uuid.UUID) (*models.TagUsage, error)
 	CreateTag(ctx context.Context, tag *entities.Tag) error
 	GetTagByID(ctx context.Context, id uuid.UUID) (*entities.Tag, error)
+	GetTagByTitle(ctx context.Context, ownerID uuid.UUID, title string) (*entities.Tag, error)
 	UpdateTag(ctx context.Context, tag *entities.Tag) error
 	DeleteTag(ctx context.Context, id uuid.UUID) error
+	MergeTags(ctx context.Context, sourceID, targetID, ownerID uuid.UUID) (bool, error)
 }
@@ -5,19 +5,40 @@ import (
 	"github.com/google/uuid"
 	"task-api/internal/adapters/models"
 	"task-api/internal/domain/entities"
+	"time"
 )
 
 type TaskRepository interface {
 	GetAllTasks(ctx context.Context) ([]*models.Task, error)
-	GetAllTasksByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.Task, error)
-	CreateTask(ctx context.Context, task *entities.Task) error
+	GetAllTasksByUserID(ctx context.Context, userID uuid.UUID, filter models.TaskFilter) ([]*entities.Task, error)
+	GetTasksByTagID(ctx context.Context, tagID, userID uuid.UUID) ([]*entities.Task, error)
+	GetBoardTasks(ctx context.Context, userID uuid.UUID) ([]*entities.Task, error)
+	CreateTask(ctx context.Context, task *entities.Task, tags []string) error
+	CreateTaskWithSubtasks(ctx context.Context, task *entities.Task, subtasks []*entities.Task, tags []string) error
 	GetTaskByID(ctx context.Context, id uuid.UUID) (*models.Task, error)
-	UpdateTask(ctx context.Context, task *entities.Task) error
-	DeleteTask(ctx context.Context, id uuid.UUID) error
+	IsVisibleTo(ctx context.Context, id, userID uuid.UUID) (bool, error)
+	UpdateTask(ctx context.Context, task *entities.Task, actorID uuid.UUID, tags []string) (string, error)
+	MoveTask(ctx context.Context, id uuid.UUID, status string, afterID, beforeID *uuid.UUID, movedAt time.Time) (string, bool, error)
+	DeleteTask(ctx context.Context, id, userID uuid.UUID, deletedAt time.Time) error
+	GetDeletedTasksByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.Task, error)
+	RestoreTask(ctx context.Context, id, userID uuid.UUID) (bool, error)
+	PurgeDeletedTasks(ctx context.Context, before time.Time) (int64, error)
+	SetArchived(ctx context.Context, id uuid.UUID, archivedAt *time.Time) (bool, error)
+	ArchiveCompletedTasks(ctx context.Context, completedBefore, archivedAt time.Time) (int64, error)
+	GetPendingRecurrences(ctx context.Context, now time.Time) ([]*entities.Task, error)
+	SpawnRecurrence(ctx context.Context, sourceID uuid.UUID, next *entities.Task, spawnedAt time.Time) (bool, error)
 
-	AddTags(ctx context.Context, taskID, tagID uuid.UUID) error
+	AddTags(ctx context.Context, taskID, tagID, ownerID uuid.UUID) (bool, error)
 	RemoveTags(ctx context.Context, taskID, tagID uuid.UUID) error
+	ReplaceTags(ctx context.Context, taskID, ownerID uuid.UUID, tags []string) error
 	GetTags(ctx context.Context, taskID uuid.UUID) ([]*entities.Tag, error)
 	GetTagsForManyTasks(ctx context.Context, taskIDs []uuid.UUID) ([]*models.TagWishTaskID, error)
 	GetComments(ctx context.Context, taskID uuid.UUID) ([]*models.CommentWish, error)
+	GetAttachments(ctx context.Context, taskID uuid.UUID) ([]*entities.Attachment, error)
+	AddAssignee(ctx context.Context, taskID, userID, assignedBy uuid.UUID, assignedAt time.Time) (bool, error)
+	RemoveAssignee(ctx context.Context, taskID, userID uuid.UUID) (bool, error)
+	GetAssignees(ctx context.Context, taskID uuid.UUID) ([]*entities.User, error)
+	GetSubtasks(ctx context.Context, taskID uuid.UUID) ([]*entities.Task, error)
+	GetTimeTotals(ctx context.Context, taskID uuid.UUID) ([]*models.TimeTotal, error)
+	GetParticipants(ctx context.Context, taskID uuid.UUID) ([]uuid.UUID, error)
 }
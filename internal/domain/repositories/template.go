@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"task-api/internal/domain/entities"
+)
+
+type TemplateRepository interface {
+	CreateTemplate(ctx context.Context, template *entities.Template) error
+	GetTemplateByID(ctx context.Context, id uuid.UUID) (*entities.Template, error)
+	GetTemplatesByOwner(ctx context.Context, ownerID uuid.UUID) ([]*entities.Template, error)
+	UpdateTemplate(ctx context.Context, template *entities.Template) error
+	DeleteTemplate(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,20 @@
+package repositories
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"task-api/internal/adapters/models"
+	"task-api/internal/domain/entities"
+	"time"
+)
+
+type TimeEntryRepository interface {
+	StartTimer(ctx context.Context, entry *entities.TimeEntry) (bool, error)
+	StopTimer(ctx context.Context, taskID, userID uuid.UUID, endedAt time.Time) (*entities.TimeEntry, error)
+	CreateEntry(ctx context.Context, entry *entities.TimeEntry) error
+	GetEntryByID(ctx context.Context, id uuid.UUID) (*models.TimeEntry, error)
+	GetRunningEntry(ctx context.Context, userID uuid.UUID) (*models.TimeEntry, error)
+	GetEntriesByTask(ctx context.Context, taskID uuid.UUID) ([]*models.TimeEntry, error)
+	DeleteEntry(ctx context.Context, id uuid.UUID) error
+	GetTimeReport(ctx context.Context, filter models.TimeReportFilter) ([]*models.TimeReportRow, error)
+}
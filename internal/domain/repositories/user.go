@@ -12,4 +12,5 @@ type UserRepository interface {
 	GetByEmail(ctx context.Context, email string) (*entities.User, error)
 	Update(ctx context.Context, user *entities.User) error
 	Delete(ctx context.Context, id uuid.UUID) error
+	ResolveMentions(ctx context.Context, handles []string) ([]uuid.UUID, error)
 }
@@ -0,0 +1,20 @@
+package repositories
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"task-api/internal/domain/entities"
+	"time"
+)
+
+type ViewRepository interface {
+	CreateView(ctx context.Context, view *entities.View) error
+	GetViewByID(ctx context.Context, id uuid.UUID) (*entities.View, error)
+	GetViewsForUser(ctx context.Context, userID uuid.UUID) ([]*entities.View, error)
+	UpdateView(ctx context.Context, view *entities.View) error
+	DeleteView(ctx context.Context, id uuid.UUID) error
+	IsSharedWith(ctx context.Context, viewID, userID uuid.UUID) (bool, error)
+	GetShares(ctx context.Context, viewID uuid.UUID) ([]entities.User, error)
+	ShareView(ctx context.Context, viewID, userID uuid.UUID, sharedAt time.Time) error
+	UnshareView(ctx context.Context, viewID, userID uuid.UUID) (bool, error)
+}
@@ -0,0 +1,272 @@
+package attachment
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"task-api/internal/adapters/api/attachment"
+	"task-api/internal/domain/entities"
+	"task-api/internal/infrastructure/api/middleware"
+	"task-api/internal/infrastructure/security"
+	"task-api/internal/usecases"
+	"task-api/pkg/config"
+	"unicode"
+)
+
+const (
+	sniffLength       = 512
+	maxFileNameLength = 255
+	// multipartOverhead leaves room for boundaries and form fields around the file.
+	multipartOverhead = 64 << 10
+)
+
+func Router(r *gin.Engine, handler *Handler, authMiddleware gin.HandlerFunc) {
+	read := middleware.RequireScope(security.ScopeTasksRead)
+	write := middleware.RequireScope(security.ScopeTasksWrite)
+	taskRouter := r.Group("/api/v1/tasks/:id/attachments")
+	taskRouter.Use(authMiddleware)
+	{
+		taskRouter.POST("", write, handler.Upload)
+		taskRouter.GET("", read, handler.GetAll)
+	}
+	attachmentRouter := r.Group("/api/v1/attachments")
+	attachmentRouter.Use(authMiddleware)
+	{
+		attachmentRouter.GET("/:id", read, handler.Download)
+		attachmentRouter.DELETE("/:id", write, handler.Delete)
+	}
+}
+
+type Handler struct {
+	useCase usecases.AttachmentUseCase
+	maxSize int64
+}
+
+func NewAttachmentHandler(useCase usecases.AttachmentUseCase, cfg config.AppConfig) *Handler {
+	return &Handler{useCase: useCase, maxSize: cfg.Attachments.MaxSize}
+}
+
+// Upload godoc
+// @Summary Загрузить вложение
+// @Description Загрузка файла к задаче (multipart/form-data). Тип содержимого определяется по самому файлу
+// @Tags attachments
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID задачи"
+// @Param file formData file true "Файл"
+// @Param comment_id formData string false "ID комментария, к которому относится файл"
+// @Success 201 {object} attachment.AttachmentResponse
+// @Failure 404 {object} map[string]string
+// @Failure 413 {object} map[string]string
+// @Router /tasks/{id}/attachments [post]
+func (h *Handler) Upload(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	idStr := c.Param("id")
+	taskID, err := uuid.Parse(idStr)
+	if err != nil {
+		zap.L().Warn("invalid task ID for upload", zap.String("task_id", idStr), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxSize+multipartOverhead)
+	header, err := c.FormFile("file")
+	if err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "file is too large", "max_size": h.maxSize})
+			return
+		}
+		zap.L().Warn("invalid upload request", zap.String("task_id", idStr), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if header.Size > h.maxSize {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "file is too large", "max_size": h.maxSize})
+		return
+	}
+	var commentID *uuid.UUID
+	if raw := c.PostForm("comment_id"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		commentID = &id
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		zap.L().Error("failed to open uploaded file", zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer file.Close()
+
+	// The client-supplied Content-Type is not trusted; the stored type is sniffed from the content.
+	head := make([]byte, sniffLength)
+	n, err := io.ReadFull(file, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	entity := &entities.Attachment{
+		TaskID:      taskID,
+		CommentID:   commentID,
+		UploadedBy:  userID.(uuid.UUID),
+		FileName:    cleanFileName(header.Filename),
+		ContentType: http.DetectContentType(head[:n]),
+		Size:        header.Size,
+	}
+	created, err := h.useCase.Upload(c, entity, file)
+	if err != nil {
+		if errors.Is(err, usecases.ErrTaskNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		zap.L().Error("failed to upload attachment", zap.String("task_id", idStr), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	zap.L().Info("attachment uploaded", zap.String("attachment_id", created.ID.String()), zap.String("task_id", idStr), zap.Int64("size", created.Size), zap.Any("user_id", userID))
+	c.JSON(http.StatusCreated, attachment.FromEntityAttachment(created))
+}
+
+// GetAll godoc
+// @Summary Список вложений задачи
+// @Description Получение метаданных всех вложений задачи
+// @Tags attachments
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID задачи"
+// @Success 200 {array} attachment.AttachmentResponse
+// @Failure 404 {object} map[string]string
+// @Router /tasks/{id}/attachments [get]
+func (h *Handler) GetAll(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	idStr := c.Param("id")
+	taskID, err := uuid.Parse(idStr)
+	if err != nil {
+		zap.L().Warn("invalid task ID for attachments", zap.String("task_id", idStr), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	attachments, err := h.useCase.GetAllByTaskID(c, taskID, userID.(uuid.UUID))
+	if errors.Is(err, usecases.ErrTaskNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		zap.L().Error("failed to get attachments", zap.String("task_id", idStr), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	output := make([]*attachment.AttachmentResponse, 0, len(attachments))
+	for _, entity := range attachments {
+		output = append(output, attachment.FromEntityAttachment(entity))
+	}
+	c.JSON(http.StatusOK, output)
+}
+
+// Download godoc
+// @Summary Скачать вложение
+// @Description Потоковая выдача содержимого файла
+// @Tags attachments
+// @Produce octet-stream
+// @Security BearerAuth
+// @Param id path string true "ID вложения"
+// @Success 200 {file} file
+// @Failure 404 {object} map[string]string
+// @Router /attachments/{id} [get]
+func (h *Handler) Download(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	entity, content, err := h.useCase.Open(c, id, userID.(uuid.UUID))
+	if err != nil {
+		if errors.Is(err, usecases.ErrAttachmentNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		zap.L().Error("failed to open attachment", zap.String("attachment_id", idStr), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer content.Close()
+
+	c.DataFromReader(http.StatusOK, entity.Size, entity.ContentType, content, map[string]string{
+		"Content-Disposition":    mime.FormatMediaType("attachment", map[string]string{"filename": entity.FileName}),
+		"X-Content-Type-Options": "nosniff",
+	})
+}
+
+// Delete godoc
+// @Summary Удалить вложение
+// @Description Удаление файла и его метаданных. Удалить вложение может загрузивший его пользователь или создатель задачи
+// @Tags attachments
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID вложения"
+// @Success 200 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /attachments/{id} [delete]
+func (h *Handler) Delete(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := h.useCase.Delete(c, id, userID.(uuid.UUID)); err != nil {
+		if errors.Is(err, usecases.ErrAttachmentNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, usecases.ErrAttachmentForbidden) {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		zap.L().Error("failed to delete attachment", zap.String("attachment_id", idStr), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	zap.L().Info("attachment deleted", zap.String("attachment_id", idStr), zap.Any("user_id", userID))
+	c.JSON(http.StatusOK, gin.H{"message": "attachment deleted"})
+}
+
+// cleanFileName keeps only the base name and drops control characters so the
+// name is safe to echo back in Content-Disposition.
+func cleanFileName(name string) string {
+	name = filepath.Base(strings.ReplaceAll(name, "\\", "/"))
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.TrimSpace(name)
+	if name == "" || name == "." || name == "/" {
+		return "file"
+	}
+	if len(name) > maxFileNameLength {
+		name = strings.ToValidUTF8(name[:maxFileNameLength], "")
+	}
+	return name
+}
@@ -0,0 +1,198 @@
+package attachment_test
+
+import (
+	"bytes"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"task-api/internal/domain/entities"
+	handler "task-api/internal/infrastructure/api/http/attachment"
+	"task-api/internal/usecases"
+	"task-api/internal/usecases/mocks"
+	"task-api/pkg/config"
+	"testing"
+)
+
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func newUploadRequest(t *testing.T, taskID uuid.UUID, filename string, content []byte) *http.Request {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("file", filename)
+	require.NoError(t, err)
+	_, err = part.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/tasks/"+taskID.String()+"/attachments", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+func TestHandler_Upload_SniffsContentType(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockAttachmentUseCase(ctrl)
+	h := handler.NewAttachmentHandler(mockUseCase, config.AppConfig{Attachments: config.Attachments{MaxSize: 1024}})
+
+	taskID := uuid.New()
+	userID := uuid.New()
+
+	mockUseCase.EXPECT().
+		Upload(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ any, a *entities.Attachment, r io.Reader) (*entities.Attachment, error) {
+			content, err := io.ReadAll(r)
+			require.NoError(t, err)
+			assert.Equal(t, pngHeader, content)
+			assert.Equal(t, "image/png", a.ContentType)
+			assert.Equal(t, "screen.png", a.FileName)
+			assert.Equal(t, int64(len(pngHeader)), a.Size)
+			assert.Equal(t, userID, a.UploadedBy)
+			a.ID = uuid.New()
+			return a, nil
+		})
+
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("user_id", userID)
+	c.Request = newUploadRequest(t, taskID, "../../screen.png", pngHeader)
+	c.Params = gin.Params{{Key: "id", Value: taskID.String()}}
+
+	h.Upload(c)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"content_type":"image/png"`)
+}
+
+func TestHandler_Upload_TooLarge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockAttachmentUseCase(ctrl)
+	h := handler.NewAttachmentHandler(mockUseCase, config.AppConfig{Attachments: config.Attachments{MaxSize: 8}})
+
+	taskID := uuid.New()
+
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("user_id", uuid.New())
+	c.Request = newUploadRequest(t, taskID, "big.log", bytes.Repeat([]byte("x"), 64))
+	c.Params = gin.Params{{Key: "id", Value: taskID.String()}}
+
+	h.Upload(c)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+}
+
+func newContext(method, path string, userID uuid.UUID, params gin.Params) (*gin.Context, *httptest.ResponseRecorder) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("user_id", userID)
+	c.Request, _ = http.NewRequest(method, path, nil)
+	c.Params = params
+	return c, w
+}
+
+func TestHandler_Upload_TaskNotVisible(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockAttachmentUseCase(ctrl)
+	h := handler.NewAttachmentHandler(mockUseCase, config.AppConfig{Attachments: config.Attachments{MaxSize: 1024}})
+
+	taskID := uuid.New()
+	mockUseCase.EXPECT().Upload(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, usecases.ErrTaskNotFound)
+
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("user_id", uuid.New())
+	c.Request = newUploadRequest(t, taskID, "screen.png", pngHeader)
+	c.Params = gin.Params{{Key: "id", Value: taskID.String()}}
+
+	h.Upload(c)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestHandler_GetAll_TaskNotVisible(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockAttachmentUseCase(ctrl)
+	h := handler.NewAttachmentHandler(mockUseCase, config.AppConfig{})
+
+	taskID := uuid.New()
+	userID := uuid.New()
+	mockUseCase.EXPECT().GetAllByTaskID(gomock.Any(), taskID, userID).Return(nil, usecases.ErrTaskNotFound)
+
+	c, w := newContext(http.MethodGet, "/api/v1/tasks/"+taskID.String()+"/attachments", userID, gin.Params{{Key: "id", Value: taskID.String()}})
+
+	h.GetAll(c)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestHandler_Download_TaskNotVisible(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockAttachmentUseCase(ctrl)
+	h := handler.NewAttachmentHandler(mockUseCase, config.AppConfig{})
+
+	id := uuid.New()
+	userID := uuid.New()
+	mockUseCase.EXPECT().Open(gomock.Any(), id, userID).Return(nil, nil, usecases.ErrAttachmentNotFound)
+
+	c, w := newContext(http.MethodGet, "/api/v1/attachments/"+id.String(), userID, gin.Params{{Key: "id", Value: id.String()}})
+
+	h.Download(c)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestHandler_Delete_TaskNotVisible(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockAttachmentUseCase(ctrl)
+	h := handler.NewAttachmentHandler(mockUseCase, config.AppConfig{})
+
+	id := uuid.New()
+	userID := uuid.New()
+	mockUseCase.EXPECT().Delete(gomock.Any(), id, userID).Return(usecases.ErrAttachmentNotFound)
+
+	c, w := newContext(http.MethodDelete, "/api/v1/attachments/"+id.String(), userID, gin.Params{{Key: "id", Value: id.String()}})
+
+	h.Delete(c)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestHandler_Delete_NotUploaderOrCreator(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockAttachmentUseCase(ctrl)
+	h := handler.NewAttachmentHandler(mockUseCase, config.AppConfig{})
+
+	id := uuid.New()
+	userID := uuid.New()
+	mockUseCase.EXPECT().Delete(gomock.Any(), id, userID).Return(usecases.ErrAttachmentForbidden)
+
+	c, w := newContext(http.MethodDelete, "/api/v1/attachments/"+id.String(), userID, gin.Params{{Key: "id", Value: id.String()}})
+
+	h.Delete(c)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
@@ -9,9 +9,9 @@ import (
 	"task-api/pkg/config"
 )
 
-func Router(r *gin.Engine, handler *Handler) {
+func Router(r *gin.Engine, handler *Handler, authMiddleware gin.HandlerFunc) {
 	logoutRouter := r.Group("/api/v1/auth")
-	logoutRouter.Use(middleware.AuthMiddleware(handler.cfg, handler.blackList))
+	logoutRouter.Use(authMiddleware, middleware.RequireSession())
 	logoutRouter.POST("/logout", handler.Logout)
 }
 
@@ -9,13 +9,12 @@ import (
 	"task-api/internal/infrastructure/api/middleware"
 	"task-api/internal/infrastructure/security"
 	"task-api/internal/usecases"
-	"task-api/pkg/config"
 )
 
-func Router(r *gin.Engine, handler *Handler, cfg config.AppConfig, blackListToken *security.TokenBlacklist) {
+func Router(r *gin.Engine, handler *Handler, authMiddleware gin.HandlerFunc) {
 	meRouter := r.Group("api/v1/auth")
-	meRouter.Use(middleware.AuthMiddleware(cfg, blackListToken))
-	meRouter.POST("/me", handler.Me)
+	meRouter.Use(authMiddleware)
+	meRouter.POST("/me", middleware.RequireScope(security.ScopeUsersRead), handler.Me)
 }
 
 type Handler struct {
@@ -0,0 +1,146 @@
+package oidc
+
+import (
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"net/http"
+	"task-api/internal/adapters/api/auth"
+	"task-api/internal/domain/entities"
+	"task-api/internal/infrastructure/security"
+	"task-api/internal/usecases"
+	"task-api/pkg/config"
+	"time"
+)
+
+func Router(r *gin.Engine, handler *Handler) {
+	oidcRouter := r.Group("/api/v1/auth/oidc")
+	oidcRouter.GET("/login", handler.Login)
+	oidcRouter.GET("/callback", handler.Callback)
+}
+
+type Handler struct {
+	useCase  usecases.AuthUseCase
+	cfg      config.AppConfig
+	provider *security.OIDCProvider
+	states   *security.OIDCStateStore
+}
+
+func NewAuthHandler(useCase usecases.AuthUseCase, cfg config.AppConfig, provider *security.OIDCProvider, states *security.OIDCStateStore) *Handler {
+	return &Handler{useCase: useCase, cfg: cfg, provider: provider, states: states}
+}
+
+// Login godoc
+// @Summary Вход через корпоративный OIDC-провайдер
+// @Description Перенаправляет пользователя на страницу авторизации провайдера (authorization code + PKCE)
+// @Tags auth
+// @Success 302
+// @Router /auth/oidc/login [get]
+func (h *Handler) Login(c *gin.Context) {
+	state, err := security.RandomString(32)
+	if err != nil {
+		zap.L().Error("failed generate oidc state", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	nonce, err := security.RandomString(32)
+	if err != nil {
+		zap.L().Error("failed generate oidc nonce", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	verifier, err := security.RandomString(64)
+	if err != nil {
+		zap.L().Error("failed generate pkce verifier", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	authURL, err := h.provider.AuthCodeURL(c, state, nonce, security.PKCEChallenge(verifier))
+	if err != nil {
+		zap.L().Error("failed build oidc auth url", zap.Error(err))
+		c.JSON(http.StatusBadGateway, gin.H{"error": "identity provider unavailable"})
+		return
+	}
+	h.states.Save(state, security.OIDCState{
+		Nonce:        nonce,
+		CodeVerifier: verifier,
+		ExpiresAt:    time.Now().Add(h.cfg.OIDC.StateTTL),
+	})
+	c.Redirect(http.StatusFound, authURL)
+}
+
+// Callback godoc
+// @Summary Callback OIDC-провайдера
+// @Description Обменивает код авторизации на токены провайдера, связывает внешнюю учётную запись с пользователем и возвращает access и refresh токены
+// @Tags auth
+// @Produce json
+// @Param code query string true "Код авторизации"
+// @Param state query string true "Состояние запроса"
+// @Success 200 {object} auth.LoginResponse
+// @Failure 401 {object} map[string]string
+// @Router /auth/oidc/callback [get]
+func (h *Handler) Callback(c *gin.Context) {
+	if errParam := c.Query("error"); errParam != "" {
+		zap.L().Warn("oidc provider returned error", zap.String("error", errParam), zap.String("description", c.Query("error_description")))
+		c.JSON(http.StatusUnauthorized, gin.H{"error": errParam})
+		return
+	}
+	code := c.Query("code")
+	state, ok := h.states.Pop(c.Query("state"))
+	if code == "" || !ok {
+		zap.L().Warn("invalid oidc callback", zap.Bool("state_found", ok))
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid state"})
+		return
+	}
+	token, err := h.provider.Exchange(c, code, state.CodeVerifier)
+	if err != nil {
+		zap.L().Warn("failed exchange oidc code", zap.Error(err))
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Failed to exchange authorization code"})
+		return
+	}
+	claims, err := h.provider.VerifyIDToken(c, token.IDToken, state.Nonce)
+	if err != nil {
+		zap.L().Warn("invalid oidc id token", zap.Error(err))
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid id token"})
+		return
+	}
+	if claims.Email == "" {
+		zap.L().Warn("oidc id token has no email", zap.String("subject", claims.Subject))
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Email claim is required"})
+		return
+	}
+
+	name := claims.Name
+	if name == "" {
+		name = claims.PreferredUsername
+	}
+	if name == "" {
+		name = claims.Email
+	}
+	identity := &entities.Identity{
+		Provider: h.provider.Name(),
+		Subject:  claims.Subject,
+		Email:    claims.Email,
+	}
+	user, err := h.useCase.LoginExternal(c, identity, &entities.User{Name: name, Email: claims.Email}, claims.EmailVerified)
+	if err != nil {
+		zap.L().Warn("failed oidc login", zap.String("subject", claims.Subject), zap.Error(err))
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	signedToken, err := security.CreateAccessJWT(h.cfg, user.ID)
+	if err != nil {
+		zap.L().Warn("failed create access token", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	expiresAt := time.Now().Add(h.cfg.Auth.JWTRefreshExpiry)
+	refreshToken, err := h.useCase.CreateRefreshToken(c, user.ID, expiresAt)
+	if err != nil {
+		zap.L().Warn("failed create refresh token", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create refresh token"})
+		return
+	}
+	zap.L().Info("success oidc login", zap.String("user_id", user.ID.String()), zap.String("provider", identity.Provider))
+	c.JSON(http.StatusOK, auth.LoginResponse{AccessToken: signedToken, RefreshToken: refreshToken.Token.String()})
+}
@@ -0,0 +1,129 @@
+package tokens
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"net/http"
+	"task-api/internal/adapters/api/auth"
+	"task-api/internal/infrastructure/api/middleware"
+	"task-api/internal/usecases"
+	"task-api/pkg/config"
+	"time"
+)
+
+func Router(r *gin.Engine, handler *Handler, authMiddleware gin.HandlerFunc) {
+	tokensRouter := r.Group("/api/v1/auth/tokens")
+	tokensRouter.Use(authMiddleware, middleware.RequireSession())
+	{
+		tokensRouter.POST("", handler.Create)
+		tokensRouter.GET("", handler.GetAll)
+		tokensRouter.DELETE("/:id", handler.Revoke)
+	}
+}
+
+type Handler struct {
+	useCase usecases.AccessTokenUseCase
+	cfg     config.AppConfig
+}
+
+func NewAuthHandler(useCase usecases.AccessTokenUseCase, cfg config.AppConfig) *Handler {
+	return &Handler{useCase: useCase, cfg: cfg}
+}
+
+// Create godoc
+// @Summary Создать персональный токен доступа
+// @Description Создаёт долгоживущий токен с ограниченными правами (scopes) для автоматизации. Значение токена возвращается только один раз.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body auth.CreateAccessTokenRequest true "Данные токена"
+// @Success 201 {object} auth.CreateAccessTokenResponse
+// @Router /auth/tokens [post]
+func (h *Handler) Create(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	var request auth.CreateAccessTokenRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		zap.L().Warn("invalid access token request", zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	expiresAt := time.Now().Add(h.cfg.Auth.AccessTokenDefaultExpiry)
+	if request.ExpiresAt != nil {
+		expiresAt = *request.ExpiresAt
+	}
+	if !expiresAt.After(time.Now()) || expiresAt.After(time.Now().Add(h.cfg.Auth.AccessTokenMaxExpiry)) {
+		zap.L().Warn("invalid access token expiry", zap.Time("expires_at", expiresAt), zap.Any("user_id", userID))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "expires_at is out of allowed range"})
+		return
+	}
+	raw, entity, err := h.useCase.Create(c, request.ToEntity(userID.(uuid.UUID), expiresAt))
+	if err != nil {
+		zap.L().Warn("failed create access token", zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	zap.L().Info("access token created", zap.String("token_id", entity.ID.String()), zap.Strings("scopes", entity.Scopes), zap.Any("user_id", userID))
+	c.JSON(http.StatusCreated, auth.CreateAccessTokenResponse{
+		AccessTokenResponse: *auth.FromEntityAccessToken(entity),
+		Token:               raw,
+	})
+}
+
+// GetAll godoc
+// @Summary Список персональных токенов
+// @Description Возвращает токены текущего пользователя (без значений токенов)
+// @Tags auth
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} auth.AccessTokenResponse
+// @Router /auth/tokens [get]
+func (h *Handler) GetAll(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	tokens, err := h.useCase.GetAll(c, userID.(uuid.UUID))
+	if err != nil {
+		zap.L().Error("failed get access tokens", zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	output := make([]*auth.AccessTokenResponse, 0, len(tokens))
+	for _, entity := range tokens {
+		output = append(output, auth.FromEntityAccessToken(entity))
+	}
+	zap.L().Info("success get access tokens", zap.Int("count", len(tokens)), zap.Any("user_id", userID))
+	c.JSON(http.StatusOK, output)
+}
+
+// Revoke godoc
+// @Summary Отозвать персональный токен
+// @Description Отзывает токен доступа по ID
+// @Tags auth
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID токена"
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /auth/tokens/{id} [delete]
+func (h *Handler) Revoke(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		zap.L().Warn("invalid access token id", zap.String("token_id", idStr), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := h.useCase.Revoke(c, userID.(uuid.UUID), id); err != nil {
+		if errors.Is(err, usecases.ErrAccessTokenNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		zap.L().Error("failed revoke access token", zap.String("token_id", id.String()), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	zap.L().Info("access token revoked", zap.String("token_id", id.String()), zap.Any("user_id", userID))
+	c.JSON(http.StatusOK, gin.H{"message": "Token revoked"})
+}
@@ -1,26 +1,39 @@
 package comment
 
 import (
+	"errors"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 	"net/http"
 	"task-api/internal/adapters/api/comment"
+	"task-api/internal/adapters/models"
+	"task-api/internal/domain/entities"
 	"task-api/internal/infrastructure/api/middleware"
 	"task-api/internal/infrastructure/security"
 	"task-api/internal/usecases"
-	"task-api/pkg/config"
 )
 
-func Router(r *gin.Engine, handler *Handler, cfg config.AppConfig, blackListToken *security.TokenBlacklist) {
+func Router(r *gin.Engine, handler *Handler, authMiddleware, adminMiddleware, idempotency gin.HandlerFunc) {
+	read := middleware.RequireScope(security.ScopeCommentsRead)
+	write := middleware.RequireScope(security.ScopeCommentsWrite)
 	commentRouter := r.Group("/api/v1/comments")
-	commentRouter.Use(middleware.AuthMiddleware(cfg, blackListToken))
+	commentRouter.Use(authMiddleware)
 	{
-		commentRouter.GET("/", handler.GetAll)
-		commentRouter.POST("/", handler.Create)
-		commentRouter.GET("/:id", handler.GetById)
-		commentRouter.PUT("/:id", handler.Update)
-		commentRouter.DELETE("/:id", handler.Delete)
+		commentRouter.GET("/", read, adminMiddleware, handler.GetAll)
+		commentRouter.POST("/", write, idempotency, handler.Create)
+		commentRouter.GET("/:id", read, handler.GetById)
+		commentRouter.PUT("/:id", write, handler.Update)
+		commentRouter.DELETE("/:id", write, handler.Delete)
+		commentRouter.GET("/:id/revisions", read, handler.GetRevisions)
+		commentRouter.POST("/:id/reactions", write, handler.AddReaction)
+		commentRouter.DELETE("/:id/reactions/:emoji", write, handler.RemoveReaction)
+	}
+	taskCommentRouter := r.Group("/api/v1/tasks/:id/comments")
+	taskCommentRouter.Use(authMiddleware)
+	{
+		taskCommentRouter.GET("", read, handler.GetThread)
+		taskCommentRouter.POST("", write, idempotency, handler.CreateForTask)
 	}
 }
 
@@ -34,16 +47,17 @@ func NewCommentHandler(useCase usecases.CommentUseCase) *Handler {
 
 // GetAll godoc
 // @Summary Получить все комментарии
-// @Description Возвращает все комментарии, доступные пользователю
+// @Description Возвращает все комментарии всех задач. Доступно только администраторам
 // @Tags comments
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Success 200 {array} comment.CommentResponse
+// @Failure 403 {object} map[string]string
 // @Router /comments [get]
 func (h *Handler) GetAll(c *gin.Context) {
 	userID, _ := c.Get("user_id")
-	comments, err := h.useCase.GetAll(c)
+	comments, err := h.useCase.GetAll(c, userID.(uuid.UUID))
 	if err != nil {
 		zap.L().Error("failed get comments", zap.Error(err), zap.Any("user_id", userID))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -59,13 +73,16 @@ func (h *Handler) GetAll(c *gin.Context) {
 
 // Create godoc
 // @Summary Создать комментарий
-// @Description Создаёт новый комментарий к задаче
+// @Description Создаёт новый комментарий к задаче от имени текущего пользователя. С parent_id комментарий становится ответом на другой комментарий той же задачи.
+// @Description Упоминания вида @имя или @email связываются с пользователями, и они получают уведомление.
 // @Tags comments
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param Idempotency-Key header string false "Ключ идемпотентности для безопасных повторов"
 // @Param request body comment.CreateCommentRequest true "Данные комментария"
 // @Success 200 {object} comment.CommentResponse
+// @Deprecated
 // @Router /comments [post]
 func (h *Handler) Create(c *gin.Context) {
 	userID, _ := c.Get("user_id")
@@ -75,16 +92,62 @@ func (h *Handler) Create(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	entity := request.ToEntity()
+	h.create(c, request.ToEntity(userID.(uuid.UUID)), http.StatusOK)
+}
+
+// CreateForTask godoc
+// @Summary Создать комментарий к задаче
+// @Description Создаёт комментарий к задаче от имени текущего пользователя. С parent_id комментарий становится ответом на другой комментарий той же задачи.
+// @Description Упоминания вида @имя или @email связываются с пользователями, и они получают уведомление.
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param Idempotency-Key header string false "Ключ идемпотентности для безопасных повторов"
+// @Param id path string true "ID задачи"
+// @Param request body comment.CreateTaskCommentRequest true "Данные комментария"
+// @Success 201 {object} comment.CommentResponse
+// @Failure 404 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Router /tasks/{id}/comments [post]
+func (h *Handler) CreateForTask(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	idStr := c.Param("id")
+	taskID, err := uuid.Parse(idStr)
+	if err != nil {
+		zap.L().Warn("invalid task id", zap.String("task_id", idStr), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	var request comment.CreateTaskCommentRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		zap.L().Warn("invalid comment request", zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	h.create(c, request.ToEntity(taskID, userID.(uuid.UUID)), http.StatusCreated)
+}
 
+func (h *Handler) create(c *gin.Context, entity *entities.Comment, status int) {
+	userID, _ := c.Get("user_id")
 	create, err := h.useCase.Create(c, entity)
+	if errors.Is(err, usecases.ErrTaskNotFound) {
+		zap.L().Warn("task for comment not found", zap.String("task_id", entity.TaskID.String()), zap.Any("user_id", userID))
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, usecases.ErrParentCommentNotFound) {
+		zap.L().Warn("invalid parent comment", zap.Any("parent_id", entity.ParentID), zap.Any("user_id", userID))
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return
+	}
 	if err != nil {
 		zap.L().Error("failed create comment", zap.Error(err), zap.Any("user_id", userID))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 	zap.L().Info("success create comment", zap.String("comment_id", create.Comment.ID.String()), zap.Any("user_id", userID))
-	c.JSON(http.StatusOK, comment.FromModelComment(create))
+	c.JSON(status, comment.FromModelComment(create))
 }
 
 // GetById godoc
@@ -96,6 +159,7 @@ func (h *Handler) Create(c *gin.Context) {
 // @Security BearerAuth
 // @Param id path string true "ID комментария"
 // @Success 200 {object} comment.CommentResponse
+// @Failure 404 {object} map[string]string
 // @Router /comments/{id} [get]
 func (h *Handler) GetById(c *gin.Context) {
 	userID, _ := c.Get("user_id")
@@ -106,7 +170,11 @@ func (h *Handler) GetById(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	model, err := h.useCase.GetByID(c, id)
+	model, err := h.useCase.GetByID(c, id, userID.(uuid.UUID))
+	if errors.Is(err, usecases.ErrCommentNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
 	if err != nil {
 		zap.L().Error("failed get comment", zap.String("comment_id", id.String()), zap.Error(err), zap.Any("user_id", userID))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -117,7 +185,7 @@ func (h *Handler) GetById(c *gin.Context) {
 
 // Update godoc
 // @Summary Обновить комментарий
-// @Description Обновляет содержимое комментария по его ID
+// @Description Обновляет содержимое комментария по его ID. Редактировать комментарий может только его автор. Прежний текст сохраняется в истории правок.
 // @Tags comments
 // @Accept json
 // @Produce json
@@ -125,6 +193,8 @@ func (h *Handler) GetById(c *gin.Context) {
 // @Param id path string true "ID комментария"
 // @Param request body comment.UpdateCommentRequest true "Новые данные комментария"
 // @Success 200 {object} comment.CommentResponse
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
 // @Router /comments/{id} [put]
 func (h *Handler) Update(c *gin.Context) {
 	userID, _ := c.Get("user_id")
@@ -142,7 +212,15 @@ func (h *Handler) Update(c *gin.Context) {
 		return
 	}
 	entity := request.ToEntity(id)
-	model, err := h.useCase.Update(c, entity)
+	model, err := h.useCase.Update(c, entity, userID.(uuid.UUID))
+	if errors.Is(err, usecases.ErrCommentNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, usecases.ErrCommentForbidden) {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
 	if err != nil {
 		zap.L().Error("failed update comment", zap.String("comment_id", id.String()), zap.Error(err), zap.Any("user_id", userID))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -154,13 +232,15 @@ func (h *Handler) Update(c *gin.Context) {
 
 // Delete godoc
 // @Summary Удалить комментарий
-// @Description Удаляет комментарий по ID
+// @Description Удаляет комментарий по ID. Удалить комментарий может его автор или создатель задачи
 // @Tags comments
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "ID комментария"
 // @Success 200 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
 // @Router /comments/{id} [delete]
 func (h *Handler) Delete(c *gin.Context) {
 	userID, _ := c.Get("user_id")
@@ -171,7 +251,15 @@ func (h *Handler) Delete(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	if err := h.useCase.Delete(c, id); err != nil {
+	if err := h.useCase.Delete(c, id, userID.(uuid.UUID)); err != nil {
+		if errors.Is(err, usecases.ErrCommentNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, usecases.ErrCommentForbidden) {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
 		zap.L().Error("failed delete comment", zap.String("comment_id", id.String()), zap.Error(err), zap.Any("user_id", userID))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -179,3 +267,165 @@ func (h *Handler) Delete(c *gin.Context) {
 	zap.L().Info("success delete comment", zap.String("comment_id", id.String()), zap.Any("user_id", userID))
 	c.JSON(http.StatusOK, gin.H{"message": "Comment deleted"})
 }
+
+// GetThread godoc
+// @Summary Получить обсуждение задачи
+// @Description Возвращает страницу комментариев верхнего уровня задачи, к каждому из которых вложены все ответы. Задача должна быть доступна пользователю
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID задачи"
+// @Param limit query int false "Количество комментариев верхнего уровня (1-100, по умолчанию 20)"
+// @Param offset query int false "Смещение"
+// @Success 200 {object} comment.CommentThreadPage
+// @Failure 404 {object} map[string]string
+// @Router /tasks/{id}/comments [get]
+func (h *Handler) GetThread(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	idStr := c.Param("id")
+	taskID, err := uuid.Parse(idStr)
+	if err != nil {
+		zap.L().Warn("invalid task id", zap.String("task_id", idStr), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	var query comment.ThreadQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		zap.L().Warn("invalid thread query", zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if query.Limit == 0 {
+		query.Limit = comment.DefaultThreadLimit
+	}
+	threads, total, err := h.useCase.GetThread(c, taskID, userID.(uuid.UUID), query.Limit, query.Offset)
+	if errors.Is(err, usecases.ErrTaskNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		zap.L().Error("failed get comment thread", zap.String("task_id", taskID.String()), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	page := comment.CommentThreadPage{
+		Items:  make([]*comment.CommentThreadResponse, 0, len(threads)),
+		Total:  total,
+		Limit:  query.Limit,
+		Offset: query.Offset,
+	}
+	for _, thread := range threads {
+		page.Items = append(page.Items, comment.FromModelThread(thread))
+	}
+	c.JSON(http.StatusOK, page)
+}
+
+// GetRevisions godoc
+// @Summary Получить историю правок комментария
+// @Description Возвращает прежние версии текста комментария, начиная с последней
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID комментария"
+// @Success 200 {array} comment.CommentRevisionResponse
+// @Failure 404 {object} map[string]string
+// @Router /comments/{id}/revisions [get]
+func (h *Handler) GetRevisions(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		zap.L().Warn("invalid comment id", zap.String("comment_id", idStr), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	revisions, err := h.useCase.GetRevisions(c, id, userID.(uuid.UUID))
+	if errors.Is(err, usecases.ErrCommentNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		zap.L().Error("failed get comment revisions", zap.String("comment_id", id.String()), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	output := make([]*comment.CommentRevisionResponse, 0, len(revisions))
+	for _, revision := range revisions {
+		output = append(output, comment.FromEntityRevision(revision))
+	}
+	c.JSON(http.StatusOK, output)
+}
+
+// AddReaction godoc
+// @Summary Добавить реакцию
+// @Description Добавляет реакцию-эмодзи текущего пользователя к комментарию. Повторная такая же реакция ничего не меняет
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID комментария"
+// @Param request body comment.AddReactionRequest true "Эмодзи"
+// @Success 200 {array} comment.ReactionResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /comments/{id}/reactions [post]
+func (h *Handler) AddReaction(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		zap.L().Warn("invalid comment id", zap.String("comment_id", idStr), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	var request comment.AddReactionRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		zap.L().Warn("invalid reaction request", zap.String("comment_id", id.String()), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	reactions, err := h.useCase.AddReaction(c, id, userID.(uuid.UUID), request.Emoji)
+	h.reactionResult(c, id, reactions, err)
+}
+
+// RemoveReaction godoc
+// @Summary Убрать реакцию
+// @Description Убирает реакцию-эмодзи текущего пользователя с комментария
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID комментария"
+// @Param emoji path string true "Эмодзи (в URL-кодировке)"
+// @Success 200 {array} comment.ReactionResponse
+// @Failure 404 {object} map[string]string
+// @Router /comments/{id}/reactions/{emoji} [delete]
+func (h *Handler) RemoveReaction(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		zap.L().Warn("invalid comment id", zap.String("comment_id", idStr), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	reactions, err := h.useCase.RemoveReaction(c, id, userID.(uuid.UUID), c.Param("emoji"))
+	h.reactionResult(c, id, reactions, err)
+}
+
+func (h *Handler) reactionResult(c *gin.Context, id uuid.UUID, reactions []models.ReactionSummary, err error) {
+	userID, _ := c.Get("user_id")
+	switch {
+	case errors.Is(err, usecases.ErrInvalidEmoji):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, usecases.ErrCommentNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case err != nil:
+		zap.L().Error("failed update comment reactions", zap.String("comment_id", id.String()), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusOK, comment.FromModelReactions(reactions))
+	}
+}
@@ -0,0 +1,413 @@
+package comment_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"task-api/internal/adapters/api/comment"
+	"task-api/internal/adapters/models"
+	"task-api/internal/domain/entities"
+	handler "task-api/internal/infrastructure/api/http/comment"
+	"task-api/internal/usecases"
+	"task-api/internal/usecases/mocks"
+	"testing"
+)
+
+func TestHandler_GetThread_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockCommentUseCase(ctrl)
+	h := handler.NewCommentHandler(mockUseCase)
+
+	taskID := uuid.New()
+	rootID := uuid.New()
+	replyID := uuid.New()
+	threads := []*models.CommentThread{{
+		CommentWish: models.CommentWish{Comment: entities.Comment{ID: rootID, TaskID: taskID}},
+		Replies: []*models.CommentThread{{
+			CommentWish: models.CommentWish{Comment: entities.Comment{ID: replyID, TaskID: taskID, ParentID: &rootID}},
+		}},
+	}}
+	userID := uuid.New()
+	mockUseCase.EXPECT().GetThread(gomock.Any(), taskID, userID, 5, 10).Return(threads, 11, nil)
+
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("user_id", userID)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/tasks/"+taskID.String()+"/comments?limit=5&offset=10", nil)
+	c.Params = gin.Params{{Key: "id", Value: taskID.String()}}
+
+	h.GetThread(c)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var page comment.CommentThreadPage
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+	assert.Equal(t, 11, page.Total)
+	assert.Equal(t, 5, page.Limit)
+	assert.Equal(t, 10, page.Offset)
+	require.Len(t, page.Items, 1)
+	assert.Equal(t, rootID, page.Items[0].ID)
+	require.Len(t, page.Items[0].Replies, 1)
+	assert.Equal(t, replyID, page.Items[0].Replies[0].ID)
+	assert.Equal(t, &rootID, page.Items[0].Replies[0].ParentID)
+}
+
+func TestHandler_GetThread_DefaultLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockCommentUseCase(ctrl)
+	h := handler.NewCommentHandler(mockUseCase)
+
+	taskID := uuid.New()
+	mockUseCase.EXPECT().GetThread(gomock.Any(), taskID, gomock.Any(), comment.DefaultThreadLimit, 0).Return(nil, 0, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("user_id", uuid.New())
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/tasks/"+taskID.String()+"/comments", nil)
+	c.Params = gin.Params{{Key: "id", Value: taskID.String()}}
+
+	h.GetThread(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"items":[]`)
+}
+
+func TestHandler_GetThread_InvalidLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	h := handler.NewCommentHandler(mocks.NewMockCommentUseCase(ctrl))
+
+	taskID := uuid.New()
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("user_id", uuid.New())
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/tasks/"+taskID.String()+"/comments?limit=1000", nil)
+	c.Params = gin.Params{{Key: "id", Value: taskID.String()}}
+
+	h.GetThread(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestHandler_Create_InvalidParent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockCommentUseCase(ctrl)
+	h := handler.NewCommentHandler(mockUseCase)
+
+	parentID := uuid.New()
+	mockUseCase.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, usecases.ErrParentCommentNotFound)
+
+	body, _ := json.Marshal(comment.CreateCommentRequest{
+		TaskID:   uuid.New(),
+		ParentID: &parentID,
+		Content:  "reply",
+	})
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("user_id", uuid.New())
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/comments/", bytes.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	h.Create(c)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+}
+
+func TestHandler_GetThread_TaskNotVisible(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockCommentUseCase(ctrl)
+	h := handler.NewCommentHandler(mockUseCase)
+
+	taskID := uuid.New()
+	mockUseCase.EXPECT().GetThread(gomock.Any(), taskID, gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, 0, usecases.ErrTaskNotFound)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("user_id", uuid.New())
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/tasks/"+taskID.String()+"/comments", nil)
+	c.Params = gin.Params{{Key: "id", Value: taskID.String()}}
+
+	h.GetThread(c)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestHandler_CreateForTask_UsesCallerAndPath(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockCommentUseCase(ctrl)
+	h := handler.NewCommentHandler(mockUseCase)
+
+	taskID := uuid.New()
+	userID := uuid.New()
+	mockUseCase.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, e *entities.Comment) (*models.CommentWish, error) {
+			assert.Equal(t, taskID, e.TaskID)
+			assert.Equal(t, userID, e.Author)
+			e.ID = uuid.New()
+			return &models.CommentWish{Comment: *e}, nil
+		})
+
+	body, _ := json.Marshal(comment.CreateTaskCommentRequest{Content: "hello"})
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("user_id", userID)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/tasks/"+taskID.String()+"/comments", bytes.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{{Key: "id", Value: taskID.String()}}
+
+	h.CreateForTask(c)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+}
+
+func TestHandler_CreateForTask_TaskNotVisible(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockCommentUseCase(ctrl)
+	h := handler.NewCommentHandler(mockUseCase)
+
+	taskID := uuid.New()
+	mockUseCase.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, usecases.ErrTaskNotFound)
+
+	body, _ := json.Marshal(comment.CreateTaskCommentRequest{Content: "hello"})
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("user_id", uuid.New())
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/tasks/"+taskID.String()+"/comments", bytes.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{{Key: "id", Value: taskID.String()}}
+
+	h.CreateForTask(c)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestHandler_Update_PassesEditor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockCommentUseCase(ctrl)
+	h := handler.NewCommentHandler(mockUseCase)
+
+	commentID := uuid.New()
+	editorID := uuid.New()
+	mockUseCase.EXPECT().Update(gomock.Any(), gomock.Any(), editorID).Return(nil, usecases.ErrCommentNotFound)
+
+	body, _ := json.Marshal(comment.UpdateCommentRequest{Content: "edited"})
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("user_id", editorID)
+	c.Request, _ = http.NewRequest(http.MethodPut, "/api/v1/comments/"+commentID.String(), bytes.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{{Key: "id", Value: commentID.String()}}
+
+	h.Update(c)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestHandler_Update_NotAuthor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockCommentUseCase(ctrl)
+	h := handler.NewCommentHandler(mockUseCase)
+
+	commentID := uuid.New()
+	editorID := uuid.New()
+	mockUseCase.EXPECT().Update(gomock.Any(), gomock.Any(), editorID).Return(nil, usecases.ErrCommentForbidden)
+
+	body, _ := json.Marshal(comment.UpdateCommentRequest{Content: "@anna look"})
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("user_id", editorID)
+	c.Request, _ = http.NewRequest(http.MethodPut, "/api/v1/comments/"+commentID.String(), bytes.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{{Key: "id", Value: commentID.String()}}
+
+	h.Update(c)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestHandler_Delete_NotAuthorOrCreator(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockCommentUseCase(ctrl)
+	h := handler.NewCommentHandler(mockUseCase)
+
+	commentID := uuid.New()
+	userID := uuid.New()
+	mockUseCase.EXPECT().Delete(gomock.Any(), commentID, userID).Return(usecases.ErrCommentForbidden)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("user_id", userID)
+	c.Request, _ = http.NewRequest(http.MethodDelete, "/api/v1/comments/"+commentID.String(), nil)
+	c.Params = gin.Params{{Key: "id", Value: commentID.String()}}
+
+	h.Delete(c)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestHandler_GetRevisions_TaskNotVisible(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockCommentUseCase(ctrl)
+	h := handler.NewCommentHandler(mockUseCase)
+
+	commentID := uuid.New()
+	userID := uuid.New()
+	mockUseCase.EXPECT().GetRevisions(gomock.Any(), commentID, userID).Return(nil, usecases.ErrCommentNotFound)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("user_id", userID)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/comments/"+commentID.String()+"/revisions", nil)
+	c.Params = gin.Params{{Key: "id", Value: commentID.String()}}
+
+	h.GetRevisions(c)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestHandler_Delete_TaskNotVisible(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockCommentUseCase(ctrl)
+	h := handler.NewCommentHandler(mockUseCase)
+
+	commentID := uuid.New()
+	userID := uuid.New()
+	mockUseCase.EXPECT().Delete(gomock.Any(), commentID, userID).Return(usecases.ErrCommentNotFound)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("user_id", userID)
+	c.Request, _ = http.NewRequest(http.MethodDelete, "/api/v1/comments/"+commentID.String(), nil)
+	c.Params = gin.Params{{Key: "id", Value: commentID.String()}}
+
+	h.Delete(c)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestHandler_AddReaction_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockCommentUseCase(ctrl)
+	h := handler.NewCommentHandler(mockUseCase)
+
+	commentID := uuid.New()
+	userID := uuid.New()
+	mockUseCase.EXPECT().AddReaction(gomock.Any(), commentID, userID, "👍").
+		Return([]models.ReactionSummary{{CommentID: commentID, Emoji: "👍", Count: 2, ReactedByMe: true}}, nil)
+
+	body, _ := json.Marshal(comment.AddReactionRequest{Emoji: "👍"})
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("user_id", userID)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/comments/"+commentID.String()+"/reactions", bytes.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{{Key: "id", Value: commentID.String()}}
+
+	h.AddReaction(c)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `[{"emoji":"👍","count":2,"reacted_by_me":true}]`, w.Body.String())
+}
+
+func TestHandler_AddReaction_InvalidEmoji(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockCommentUseCase(ctrl)
+	h := handler.NewCommentHandler(mockUseCase)
+
+	commentID := uuid.New()
+	mockUseCase.EXPECT().AddReaction(gomock.Any(), commentID, gomock.Any(), "ok").Return(nil, usecases.ErrInvalidEmoji)
+
+	body, _ := json.Marshal(comment.AddReactionRequest{Emoji: "ok"})
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("user_id", uuid.New())
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/comments/"+commentID.String()+"/reactions", bytes.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{{Key: "id", Value: commentID.String()}}
+
+	h.AddReaction(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestHandler_AddReaction_TaskNotVisible(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockCommentUseCase(ctrl)
+	h := handler.NewCommentHandler(mockUseCase)
+
+	commentID := uuid.New()
+	userID := uuid.New()
+	mockUseCase.EXPECT().AddReaction(gomock.Any(), commentID, userID, "👍").Return(nil, usecases.ErrCommentNotFound)
+
+	body, _ := json.Marshal(comment.AddReactionRequest{Emoji: "👍"})
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("user_id", userID)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/comments/"+commentID.String()+"/reactions", bytes.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{{Key: "id", Value: commentID.String()}}
+
+	h.AddReaction(c)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestHandler_RemoveReaction_DecodesEmoji(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockCommentUseCase(ctrl)
+	h := handler.NewCommentHandler(mockUseCase)
+
+	commentID := uuid.New()
+	userID := uuid.New()
+	mockUseCase.EXPECT().RemoveReaction(gomock.Any(), commentID, userID, "🎉").Return(nil, nil)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	auth := func(c *gin.Context) { c.Set("user_id", userID) }
+	handler.Router(r, h, auth, auth, auth)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/comments/"+commentID.String()+"/reactions/%F0%9F%8E%89", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "[]", w.Body.String())
+}
@@ -0,0 +1,77 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+const checkTimeout = 2 * time.Second
+
+func Router(r *gin.Engine, handler *Handler) {
+	r.GET("/healthz", handler.Liveness)
+	r.GET("/readyz", handler.Readiness)
+}
+
+type Handler struct {
+	pool  *pgxpool.Pool
+	ready atomic.Bool
+}
+
+func NewHealthHandler(pool *pgxpool.Pool) *Handler {
+	return &Handler{pool: pool}
+}
+
+// SetReady switches the readiness probe. It is turned off at the start of a
+// graceful shutdown so that load balancers stop routing traffic first.
+func (h *Handler) SetReady(ready bool) {
+	h.ready.Store(ready)
+}
+
+func (h *Handler) Liveness(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+func (h *Handler) Readiness(c *gin.Context) {
+	if !h.ready.Load() {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"status": "not ready", "reason": "shutting down"})
+		return
+	}
+	ctx, cancel := context.WithTimeout(c.Request.Context(), checkTimeout)
+	defer cancel()
+
+	if err := h.pool.Ping(ctx); err != nil {
+		zap.L().Warn("readiness check failed: database unreachable", zap.Error(err))
+		c.JSON(http.StatusServiceUnavailable, gin.H{"status": "not ready", "database": "unreachable"})
+		return
+	}
+	version, dirty, err := h.migrationVersion(ctx)
+	if err != nil {
+		zap.L().Warn("readiness check failed: migration version unavailable", zap.Error(err))
+		c.JSON(http.StatusServiceUnavailable, gin.H{"status": "not ready", "database": "ok", "migrations": "unknown"})
+		return
+	}
+	status := http.StatusOK
+	body := gin.H{"status": "ready", "database": "ok", "migration_version": version, "migration_dirty": dirty}
+	if dirty {
+		status = http.StatusServiceUnavailable
+		body["status"] = "not ready"
+	}
+	c.JSON(status, body)
+}
+
+func (h *Handler) migrationVersion(ctx context.Context) (int64, bool, error) {
+	var version int64
+	var dirty bool
+	err := h.pool.QueryRow(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&version, &dirty)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, false, nil
+	}
+	return version, dirty, err
+}
@@ -0,0 +1,236 @@
+package notification
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"net/http"
+	"task-api/internal/adapters/api/notification"
+	"task-api/internal/infrastructure/api/middleware"
+	"task-api/internal/infrastructure/security"
+	"task-api/internal/usecases"
+)
+
+func Router(r *gin.Engine, handler *Handler, authMiddleware gin.HandlerFunc) {
+	read := middleware.RequireScope(security.ScopeNotificationsRead)
+	write := middleware.RequireScope(security.ScopeNotificationsWrite)
+	notificationRouter := r.Group("/api/v1/notifications")
+	notificationRouter.Use(authMiddleware)
+	{
+		notificationRouter.GET("", read, handler.List)
+		notificationRouter.POST("/:id/read", write, handler.MarkRead)
+		notificationRouter.POST("/read-all", write, handler.MarkAllRead)
+		notificationRouter.GET("/preferences", read, handler.GetPreferences)
+		notificationRouter.PUT("/preferences", write, handler.UpdatePreferences)
+		notificationRouter.GET("/digest", read, handler.GetDigestSettings)
+		notificationRouter.PUT("/digest", write, handler.UpdateDigestSettings)
+	}
+}
+
+type Handler struct {
+	useCase usecases.NotificationUseCase
+	digest  usecases.DigestUseCase
+}
+
+func NewNotificationHandler(useCase usecases.NotificationUseCase, digest usecases.DigestUseCase) *Handler {
+	return &Handler{useCase: useCase, digest: digest}
+}
+
+// List godoc
+// @Summary Получить уведомления
+// @Description Возвращает уведомления текущего пользователя, начиная с новых, и количество непрочитанных
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param unread_only query bool false "Только непрочитанные"
+// @Param limit query int false "Количество уведомлений (1-100, по умолчанию 20)"
+// @Param offset query int false "Смещение"
+// @Success 200 {object} notification.NotificationListResponse
+// @Router /notifications [get]
+func (h *Handler) List(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	var query notification.ListNotificationsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		zap.L().Warn("invalid notifications query", zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if query.Limit == 0 {
+		query.Limit = notification.DefaultLimit
+	}
+	notifications, unread, err := h.useCase.List(c, userID.(uuid.UUID), query.UnreadOnly, query.Limit, query.Offset)
+	if err != nil {
+		zap.L().Error("failed get notifications", zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	res := notification.NotificationListResponse{
+		Items:       make([]*notification.NotificationResponse, 0, len(notifications)),
+		UnreadCount: unread,
+		Limit:       query.Limit,
+		Offset:      query.Offset,
+	}
+	for _, n := range notifications {
+		res.Items = append(res.Items, notification.FromEntityNotification(n))
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// MarkRead godoc
+// @Summary Отметить уведомление прочитанным
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID уведомления"
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /notifications/{id}/read [post]
+func (h *Handler) MarkRead(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		zap.L().Warn("invalid notification id", zap.String("notification_id", idStr), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	err = h.useCase.MarkRead(c, id, userID.(uuid.UUID))
+	if errors.Is(err, usecases.ErrNotificationNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		zap.L().Error("failed mark notification read", zap.String("notification_id", id.String()), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Notification marked as read"})
+}
+
+// MarkAllRead godoc
+// @Summary Отметить все уведомления прочитанными
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]int64
+// @Router /notifications/read-all [post]
+func (h *Handler) MarkAllRead(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	count, err := h.useCase.MarkAllRead(c, userID.(uuid.UUID))
+	if err != nil {
+		zap.L().Error("failed mark all notifications read", zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"marked": count})
+}
+
+// GetPreferences godoc
+// @Summary Получить настройки уведомлений
+// @Description Возвращает для каждого типа уведомлений, включён ли он
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} notification.PreferenceResponse
+// @Router /notifications/preferences [get]
+func (h *Handler) GetPreferences(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	preferences, err := h.useCase.GetPreferences(c, userID.(uuid.UUID))
+	if err != nil {
+		zap.L().Error("failed get notification preferences", zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, notification.FromEntityPreferences(preferences))
+}
+
+// UpdatePreferences godoc
+// @Summary Изменить настройки уведомлений
+// @Description Включает или выключает указанные типы уведомлений; остальные не меняются
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body notification.UpdatePreferencesRequest true "Настройки"
+// @Success 200 {array} notification.PreferenceResponse
+// @Failure 400 {object} map[string]string
+// @Router /notifications/preferences [put]
+func (h *Handler) UpdatePreferences(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	var request notification.UpdatePreferencesRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		zap.L().Warn("invalid notification preferences request", zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	preferences, err := h.useCase.SetPreferences(c, userID.(uuid.UUID), request.ToEntities())
+	if errors.Is(err, usecases.ErrUnknownNotificationType) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		zap.L().Error("failed update notification preferences", zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	zap.L().Info("notification preferences updated", zap.Any("user_id", userID))
+	c.JSON(http.StatusOK, notification.FromEntityPreferences(preferences))
+}
+
+// GetDigestSettings godoc
+// @Summary Получить настройки email-сводки
+// @Description Возвращает, включена ли ежедневная сводка на почту, и часовой пояс, по которому выбирается время отправки
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} notification.DigestSettingsResponse
+// @Router /notifications/digest [get]
+func (h *Handler) GetDigestSettings(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	settings, err := h.digest.GetSettings(c, userID.(uuid.UUID))
+	if err != nil {
+		zap.L().Error("failed get digest settings", zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, notification.FromEntityDigestSettings(settings))
+}
+
+// UpdateDigestSettings godoc
+// @Summary Изменить настройки email-сводки
+// @Description Включает или отключает ежедневную сводку; часовой пояс задаётся именем из базы IANA, по умолчанию UTC
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body notification.DigestSettingsRequest true "Настройки сводки"
+// @Success 200 {object} notification.DigestSettingsResponse
+// @Failure 400 {object} map[string]string
+// @Router /notifications/digest [put]
+func (h *Handler) UpdateDigestSettings(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	var request notification.DigestSettingsRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		zap.L().Warn("invalid digest settings request", zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	settings, err := h.digest.UpdateSettings(c, request.ToEntity(userID.(uuid.UUID)))
+	if errors.Is(err, usecases.ErrInvalidTimezone) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		zap.L().Error("failed update digest settings", zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	zap.L().Info("digest settings updated", zap.Any("user_id", userID), zap.Bool("enabled", settings.Enabled))
+	c.JSON(http.StatusOK, notification.FromEntityDigestSettings(settings))
+}
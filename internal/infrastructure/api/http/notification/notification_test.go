@@ -0,0 +1,135 @@
+package notification_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"task-api/internal/adapters/api/notification"
+	"task-api/internal/domain/entities"
+	handler "task-api/internal/infrastructure/api/http/notification"
+	"task-api/internal/usecases"
+	"task-api/internal/usecases/mocks"
+	"testing"
+)
+
+func TestHandler_List_DefaultLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockNotificationUseCase(ctrl)
+	h := handler.NewNotificationHandler(mockUseCase, nil)
+
+	userID := uuid.New()
+	notificationID := uuid.New()
+	mockUseCase.EXPECT().List(gomock.Any(), userID, true, notification.DefaultLimit, 0).
+		Return([]*entities.Notification{{ID: notificationID, UserID: userID, Type: entities.NotificationTaskCommented}}, 3, nil)
+
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("user_id", userID)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/notifications?unread_only=true", nil)
+
+	h.List(c)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var res notification.NotificationListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Equal(t, 3, res.UnreadCount)
+	assert.Equal(t, notification.DefaultLimit, res.Limit)
+	require.Len(t, res.Items, 1)
+	assert.Equal(t, notificationID, res.Items[0].ID)
+	assert.Equal(t, entities.NotificationTaskCommented, res.Items[0].Type)
+}
+
+func TestHandler_MarkRead_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockNotificationUseCase(ctrl)
+	h := handler.NewNotificationHandler(mockUseCase, nil)
+
+	userID := uuid.New()
+	notificationID := uuid.New()
+	mockUseCase.EXPECT().MarkRead(gomock.Any(), notificationID, userID).Return(usecases.ErrNotificationNotFound)
+
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("user_id", userID)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/notifications/"+notificationID.String()+"/read", nil)
+	c.Params = gin.Params{{Key: "id", Value: notificationID.String()}}
+
+	h.MarkRead(c)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestHandler_UpdatePreferences_UnknownType(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockNotificationUseCase(ctrl)
+	h := handler.NewNotificationHandler(mockUseCase, nil)
+
+	userID := uuid.New()
+	mockUseCase.EXPECT().SetPreferences(gomock.Any(), userID, gomock.Any()).Return(nil, usecases.ErrUnknownNotificationType)
+
+	body, _ := json.Marshal(notification.UpdatePreferencesRequest{
+		Preferences: []notification.PreferenceRequest{{Type: "unknown", Enabled: false}},
+	})
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("user_id", userID)
+	c.Request, _ = http.NewRequest(http.MethodPut, "/api/v1/notifications/preferences", bytes.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	h.UpdatePreferences(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestHandler_UpdateDigestSettings_InvalidTimezone(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDigest := mocks.NewMockDigestUseCase(ctrl)
+	h := handler.NewNotificationHandler(nil, mockDigest)
+
+	userID := uuid.New()
+	mockDigest.EXPECT().UpdateSettings(gomock.Any(), &entities.DigestSettings{UserID: userID, Enabled: true, Timezone: "Mars/Olympus"}).
+		Return(nil, usecases.ErrInvalidTimezone)
+
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("user_id", userID)
+	c.Request, _ = http.NewRequest(http.MethodPut, "/api/v1/notifications/digest", bytes.NewReader([]byte(`{"enabled":true,"timezone":"Mars/Olympus"}`)))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	h.UpdateDigestSettings(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestHandler_UpdateDigestSettings_RequiresEnabled(t *testing.T) {
+	h := handler.NewNotificationHandler(nil, nil)
+
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("user_id", uuid.New())
+	c.Request, _ = http.NewRequest(http.MethodPut, "/api/v1/notifications/digest", bytes.NewReader([]byte(`{"timezone":"UTC"}`)))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	h.UpdateDigestSettings(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
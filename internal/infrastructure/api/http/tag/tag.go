@@ -1,26 +1,31 @@
 package tag
 
 import (
+	"errors"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 	"net/http"
 	"task-api/internal/adapters/api/tag"
+	"task-api/internal/adapters/api/task"
 	"task-api/internal/infrastructure/api/middleware"
 	"task-api/internal/infrastructure/security"
 	"task-api/internal/usecases"
-	"task-api/pkg/config"
 )
 
-func Router(router *gin.Engine, handler *Handler, cfg config.AppConfig, blackListToken *security.TokenBlacklist) {
+func Router(router *gin.Engine, handler *Handler, authMiddleware gin.HandlerFunc) {
+	read := middleware.RequireScope(security.ScopeTagsRead)
+	write := middleware.RequireScope(security.ScopeTagsWrite)
 	tagRouter := router.Group("/api/v1/tags")
-	tagRouter.Use(middleware.AuthMiddleware(cfg, blackListToken))
+	tagRouter.Use(authMiddleware)
 	{
-		tagRouter.GET("/", handler.GetTags)
-		tagRouter.POST("/", handler.Create)
-		tagRouter.GET("/:id", handler.GetTag)
-		tagRouter.PUT("/:id", handler.Update)
-		tagRouter.DELETE("/:id", handler.Delete)
+		tagRouter.GET("/", read, handler.GetTags)
+		tagRouter.POST("/", write, handler.Create)
+		tagRouter.GET("/:id", read, handler.GetTag)
+		tagRouter.PUT("/:id", write, handler.Update)
+		tagRouter.DELETE("/:id", write, handler.Delete)
+		tagRouter.GET("/:id/tasks", read, handler.GetTasks)
+		tagRouter.POST("/:id/merge", write, handler.Merge)
 	}
 }
 
@@ -34,24 +39,35 @@ func NewTagHandler(useCase usecases.TagUseCase) *Handler {
 
 // GetTags godoc
 // @Summary Получить все теги
-// @Description Получает список всех тегов пользователя
+// @Description Получает список тегов текущего пользователя с числом задач у каждого, начиная с самых используемых. С prefix работает как автодополнение
 // @Tags tags
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Success 200 {array} tag.TagResponse
+// @Param prefix query string false "Начало названия без учёта регистра"
+// @Param limit query int false "Количество тегов (1-100; с prefix по умолчанию 10, без него — все)"
+// @Success 200 {array} tag.TagUsageResponse
 // @Router /tags [get]
 func (h *Handler) GetTags(c *gin.Context) {
 	userID, _ := c.Get("user_id")
-	tags, err := h.useCase.GetTags(c)
+	var query tag.ListTagsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		zap.L().Warn("invalid tags query", zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if query.Prefix != "" && query.Limit == 0 {
+		query.Limit = tag.DefaultAutocompleteLimit
+	}
+	tags, err := h.useCase.GetTags(c, userID.(uuid.UUID), query.Prefix, query.Limit)
 	if err != nil {
 		zap.L().Error("failed get tags", zap.Error(err), zap.Any("user_id", userID))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	var output []*tag.TagResponse
-	for _, entity := range tags {
-		output = append(output, tag.FromEntityTag(entity))
+	output := make([]*tag.TagUsageResponse, 0, len(tags))
+	for _, model := range tags {
+		output = append(output, tag.FromModelTagUsage(model))
 	}
 	zap.L().Info("success get tags", zap.Int("count_tags", len(tags)), zap.Any("user_id", userID))
 	c.JSON(http.StatusOK, output)
@@ -60,13 +76,15 @@ func (h *Handler) GetTags(c *gin.Context) {
 
 // Create godoc
 // @Summary Создать тег
-// @Description Создаёт новый тег
+// @Description Создаёт тег текущего пользователя; название уникально среди его тегов
 // @Tags tags
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param request body tag.CreateTagRequest true "Данные нового тега"
 // @Success 200 {object} tag.TagResponse
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
 // @Router /tags [post]
 func (h *Handler) Create(c *gin.Context) {
 	userID, _ := c.Get("user_id")
@@ -76,7 +94,11 @@ func (h *Handler) Create(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	entity, err := h.useCase.Create(c, request.ToEntity())
+	entity, err := h.useCase.Create(c, request.ToEntity(userID.(uuid.UUID)))
+	if errors.Is(err, usecases.ErrTagExists) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
 	if err != nil {
 		zap.L().Error("failed create tag", zap.Error(err), zap.Any("user_id", userID))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -88,13 +110,14 @@ func (h *Handler) Create(c *gin.Context) {
 
 // GetTag godoc
 // @Summary Получить тег
-// @Description Получает тег по ID
+// @Description Получает тег текущего пользователя по ID
 // @Tags tags
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "ID тега"
 // @Success 200 {object} tag.TagResponse
+// @Failure 404 {object} map[string]string
 // @Router /tags/{id} [get]
 func (h *Handler) GetTag(c *gin.Context) {
 	idStr := c.Param("id")
@@ -105,7 +128,11 @@ func (h *Handler) GetTag(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	entity, err := h.useCase.GetTag(c, id)
+	entity, err := h.useCase.GetTag(c, id, userID.(uuid.UUID))
+	if errors.Is(err, usecases.ErrTagNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
 	if err != nil {
 		zap.L().Error("failed get tag", zap.String("tag_id", id.String()), zap.Error(err), zap.Any("user_id", userID))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -117,7 +144,7 @@ func (h *Handler) GetTag(c *gin.Context) {
 
 // Update godoc
 // @Summary Обновить тег
-// @Description Обновляет тег по ID
+// @Description Обновляет тег по ID; изменять можно только свои теги
 // @Tags tags
 // @Accept json
 // @Produce json
@@ -125,6 +152,8 @@ func (h *Handler) GetTag(c *gin.Context) {
 // @Param id path string true "ID тега"
 // @Param request body tag.UpdateTagRequest true "Новые данные тега"
 // @Success 200 {object} tag.TagResponse
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
 // @Router /tags/{id} [put]
 func (h *Handler) Update(c *gin.Context) {
 	idStr := c.Param("id")
@@ -141,8 +170,16 @@ func (h *Handler) Update(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	entity := request.ToEntity(id)
-	if err := h.useCase.Update(c, entity); err != nil {
+	entity, err := h.useCase.Update(c, request.ToEntity(id, userID.(uuid.UUID)))
+	if errors.Is(err, usecases.ErrTagNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, usecases.ErrTagExists) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
 		zap.L().Error("failed update tag", zap.String("tag_id", id.String()), zap.Error(err), zap.Any("user_id", userID))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -153,13 +190,14 @@ func (h *Handler) Update(c *gin.Context) {
 
 // Delete godoc
 // @Summary Удалить тег
-// @Description Удаляет тег по ID
+// @Description Удаляет тег по ID вместе с его привязками к задачам; удалять можно только свои теги
 // @Tags tags
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "ID тега"
 // @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string
 // @Router /tags/{id} [delete]
 func (h *Handler) Delete(c *gin.Context) {
 	idStr := c.Param("id")
@@ -170,7 +208,12 @@ func (h *Handler) Delete(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	if err := h.useCase.Delete(c, id); err != nil {
+	err = h.useCase.Delete(c, id, userID.(uuid.UUID))
+	if errors.Is(err, usecases.ErrTagNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
 		zap.L().Error("failed delete tag", zap.String("tag_id", id.String()), zap.Error(err), zap.Any("user_id", userID))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -178,3 +221,86 @@ func (h *Handler) Delete(c *gin.Context) {
 	zap.L().Info("success delete tag", zap.String("tag_id", id.String()), zap.Any("user_id", userID))
 	c.JSON(http.StatusOK, gin.H{"message": "Tag deleted"})
 }
+
+// GetTasks godoc
+// @Summary Задачи с тегом
+// @Description Возвращает доступные пользователю задачи с этим тегом, кроме архивных и удалённых
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID тега"
+// @Success 200 {array} task.TaskAllResponse
+// @Failure 404 {object} map[string]string
+// @Router /tags/{id}/tasks [get]
+func (h *Handler) GetTasks(c *gin.Context) {
+	idStr := c.Param("id")
+	userID, _ := c.Get("user_id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		zap.L().Warn("invalid tag id", zap.String("tag_id", idStr), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	tasks, err := h.useCase.GetTasks(c, id, userID.(uuid.UUID))
+	if errors.Is(err, usecases.ErrTagNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		zap.L().Error("failed get tag tasks", zap.String("tag_id", id.String()), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	output := make([]*task.TaskAllResponse, 0, len(tasks))
+	for _, model := range tasks {
+		output = append(output, task.FromModelTaskForAll(model))
+	}
+	c.JSON(http.StatusOK, output)
+}
+
+// Merge godoc
+// @Summary Объединить теги
+// @Description Переносит все задачи с тега на целевой тег и удаляет исходный тег. Выполняется атомарно; оба тега должны принадлежать пользователю
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID исходного тега"
+// @Param request body tag.MergeTagRequest true "Целевой тег"
+// @Success 200 {object} tag.TagUsageResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /tags/{id}/merge [post]
+func (h *Handler) Merge(c *gin.Context) {
+	idStr := c.Param("id")
+	userID, _ := c.Get("user_id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		zap.L().Warn("invalid tag id", zap.String("tag_id", idStr), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	var request tag.MergeTagRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		zap.L().Warn("invalid merge tag request", zap.String("tag_id", id.String()), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	merged, err := h.useCase.Merge(c, id, request.TargetID, userID.(uuid.UUID))
+	if errors.Is(err, usecases.ErrMergeIntoSelf) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, usecases.ErrTagNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		zap.L().Error("failed merge tags", zap.String("tag_id", id.String()), zap.String("target_id", request.TargetID.String()), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	zap.L().Info("tags merged", zap.String("tag_id", id.String()), zap.String("target_id", request.TargetID.String()), zap.Any("user_id", userID))
+	c.JSON(http.StatusOK, tag.FromModelTagUsage(merged))
+}
@@ -0,0 +1,226 @@
+package tag_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"task-api/internal/adapters/api/tag"
+	"task-api/internal/adapters/models"
+	"task-api/internal/domain/entities"
+	handler "task-api/internal/infrastructure/api/http/tag"
+	"task-api/internal/usecases"
+	"task-api/internal/usecases/mocks"
+	"testing"
+)
+
+func newContext(method, path string, body any, userID uuid.UUID) (*gin.Context, *httptest.ResponseRecorder) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("user_id", userID)
+	var payload []byte
+	if body != nil {
+		payload, _ = json.Marshal(body)
+	}
+	c.Request, _ = http.NewRequest(method, path, bytes.NewReader(payload))
+	c.Request.Header.Set("Content-Type", "application/json")
+	return c, w
+}
+
+func TestHandler_Create_SetsOwner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockTagUseCase(ctrl)
+	h := handler.NewTagHandler(mockUseCase)
+
+	userID := uuid.New()
+	mockUseCase.EXPECT().Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ any, e *entities.Tag) (*entities.Tag, error) {
+			assert.Equal(t, userID, e.OwnerID)
+			assert.Equal(t, "#ff8800", e.Color)
+			e.ID = uuid.New()
+			return e, nil
+		})
+
+	c, w := newContext(http.MethodPost, "/api/v1/tags/", tag.CreateTagRequest{Title: "bug", Color: "#ff8800"}, userID)
+	h.Create(c)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var res tag.TagResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Equal(t, "bug", res.Title)
+	assert.Equal(t, "#ff8800", res.Color)
+}
+
+func TestHandler_Create_Duplicate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockTagUseCase(ctrl)
+	h := handler.NewTagHandler(mockUseCase)
+
+	mockUseCase.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, usecases.ErrTagExists)
+
+	c, w := newContext(http.MethodPost, "/api/v1/tags/", tag.CreateTagRequest{Title: "bug"}, uuid.New())
+	h.Create(c)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestHandler_Create_InvalidColor(t *testing.T) {
+	h := handler.NewTagHandler(nil)
+
+	c, w := newContext(http.MethodPost, "/api/v1/tags/", tag.CreateTagRequest{Title: "bug", Color: "orange"}, uuid.New())
+	h.Create(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestHandler_Update_NotOwner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockTagUseCase(ctrl)
+	h := handler.NewTagHandler(mockUseCase)
+
+	userID := uuid.New()
+	tagID := uuid.New()
+	mockUseCase.EXPECT().Update(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ any, e *entities.Tag) (*entities.Tag, error) {
+			assert.Equal(t, tagID, e.ID)
+			assert.Equal(t, userID, e.OwnerID)
+			return nil, usecases.ErrTagNotFound
+		})
+
+	c, w := newContext(http.MethodPut, "/api/v1/tags/"+tagID.String(), tag.UpdateTagRequest{Title: "renamed"}, userID)
+	c.Params = gin.Params{{Key: "id", Value: tagID.String()}}
+	h.Update(c)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestHandler_Delete_NotOwner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockTagUseCase(ctrl)
+	h := handler.NewTagHandler(mockUseCase)
+
+	userID := uuid.New()
+	tagID := uuid.New()
+	mockUseCase.EXPECT().Delete(gomock.Any(), tagID, userID).Return(usecases.ErrTagNotFound)
+
+	c, w := newContext(http.MethodDelete, "/api/v1/tags/"+tagID.String(), nil, userID)
+	c.Params = gin.Params{{Key: "id", Value: tagID.String()}}
+	h.Delete(c)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestHandler_GetTags_Autocomplete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockTagUseCase(ctrl)
+	h := handler.NewTagHandler(mockUseCase)
+
+	userID := uuid.New()
+	mockUseCase.EXPECT().GetTags(gomock.Any(), userID, "ba", tag.DefaultAutocompleteLimit).Return([]*models.TagUsage{
+		{Tag: entities.Tag{ID: uuid.New(), Title: "backend"}, UsageCount: 7},
+		{Tag: entities.Tag{ID: uuid.New(), Title: "bug"}, UsageCount: 2},
+	}, nil)
+
+	c, w := newContext(http.MethodGet, "/api/v1/tags/?prefix=ba", nil, userID)
+	h.GetTags(c)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var res []tag.TagUsageResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	require.Len(t, res, 2)
+	assert.Equal(t, "backend", res[0].Title)
+	assert.Equal(t, 7, res[0].UsageCount)
+}
+
+func TestHandler_GetTags_EmptyList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockTagUseCase(ctrl)
+	h := handler.NewTagHandler(mockUseCase)
+
+	userID := uuid.New()
+	mockUseCase.EXPECT().GetTags(gomock.Any(), userID, "", 0).Return(nil, nil)
+
+	c, w := newContext(http.MethodGet, "/api/v1/tags/", nil, userID)
+	h.GetTags(c)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `[]`, w.Body.String())
+}
+
+func TestHandler_GetTasks_NotOwner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockTagUseCase(ctrl)
+	h := handler.NewTagHandler(mockUseCase)
+
+	userID := uuid.New()
+	tagID := uuid.New()
+	mockUseCase.EXPECT().GetTasks(gomock.Any(), tagID, userID).Return(nil, usecases.ErrTagNotFound)
+
+	c, w := newContext(http.MethodGet, "/api/v1/tags/"+tagID.String()+"/tasks", nil, userID)
+	c.Params = gin.Params{{Key: "id", Value: tagID.String()}}
+	h.GetTasks(c)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestHandler_Merge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockTagUseCase(ctrl)
+	h := handler.NewTagHandler(mockUseCase)
+
+	userID := uuid.New()
+	sourceID := uuid.New()
+	targetID := uuid.New()
+	mockUseCase.EXPECT().Merge(gomock.Any(), sourceID, targetID, userID).
+		Return(&models.TagUsage{Tag: entities.Tag{ID: targetID, Title: "bug"}, UsageCount: 5}, nil)
+
+	c, w := newContext(http.MethodPost, "/api/v1/tags/"+sourceID.String()+"/merge", tag.MergeTagRequest{TargetID: targetID}, userID)
+	c.Params = gin.Params{{Key: "id", Value: sourceID.String()}}
+	h.Merge(c)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var res tag.TagUsageResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Equal(t, targetID, res.ID)
+	assert.Equal(t, 5, res.UsageCount)
+}
+
+func TestHandler_Merge_IntoSelf(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockTagUseCase(ctrl)
+	h := handler.NewTagHandler(mockUseCase)
+
+	userID := uuid.New()
+	tagID := uuid.New()
+	mockUseCase.EXPECT().Merge(gomock.Any(), tagID, tagID, userID).Return(nil, usecases.ErrMergeIntoSelf)
+
+	c, w := newContext(http.MethodPost, "/api/v1/tags/"+tagID.String()+"/merge", tag.MergeTagRequest{TargetID: tagID}, userID)
+	c.Params = gin.Params{{Key: "id", Value: tagID.String()}}
+	h.Merge(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -7,26 +7,43 @@ import (
 	"go.uber.org/zap"
 	"net/http"
 	"task-api/internal/adapters/api/task"
+	"task-api/internal/adapters/models"
 	"task-api/internal/domain/entities"
 	"task-api/internal/infrastructure/api/middleware"
 	"task-api/internal/infrastructure/security"
 	"task-api/internal/usecases"
-	"task-api/pkg/config"
+	"task-api/pkg/taskquery"
 )
 
-func Router(router *gin.Engine, handler *Handler, cfg config.AppConfig, blackListToken *security.TokenBlacklist) {
+func Router(router *gin.Engine, handler *Handler, authMiddleware, idempotency gin.HandlerFunc) {
+	read := middleware.RequireScope(security.ScopeTasksRead)
+	write := middleware.RequireScope(security.ScopeTasksWrite)
 	taskRouter := router.Group("/api/v1/tasks")
-	taskRouter.Use(middleware.AuthMiddleware(cfg, blackListToken))
+	taskRouter.Use(authMiddleware)
 	{
-		taskRouter.GET("", handler.GetTasks)
-		taskRouter.GET("/:id", handler.GetTask)
-		taskRouter.POST("", handler.CreateTask)
-		taskRouter.PUT("/:id", handler.UpdateTask)
-		taskRouter.DELETE("/:id", handler.DeleteTask)
-		taskRouter.POST("/:id/tags", handler.AddTags)
-		taskRouter.DELETE("/:id/tags", handler.DeleteTags)
+		taskRouter.GET("", read, handler.GetTasks)
+		taskRouter.GET("/trash", read, handler.GetTrash)
+		taskRouter.GET("/:id", read, handler.GetTask)
+		taskRouter.GET("/:id/occurrences", read, handler.GetOccurrences)
+		taskRouter.POST("", write, idempotency, handler.CreateTask)
+		taskRouter.PUT("/:id", write, handler.UpdateTask)
+		taskRouter.DELETE("/:id", write, handler.DeleteTask)
+		taskRouter.POST("/:id/restore", write, handler.RestoreTask)
+		taskRouter.POST("/:id/archive", write, handler.ArchiveTask)
+		taskRouter.POST("/:id/unarchive", write, handler.UnarchiveTask)
+		taskRouter.POST("/:id/tags", write, handler.AddTags)
+		taskRouter.DELETE("/:id/tags", write, handler.DeleteTags)
+		taskRouter.PUT("/:id/tags", write, handler.ReplaceTags)
+		taskRouter.POST("/:id/assignees", write, handler.AddAssignee)
+		taskRouter.DELETE("/:id/assignees/:user_id", write, handler.RemoveAssignee)
+		taskRouter.POST("/:id/move", write, handler.MoveTask)
 
 	}
+	boardRouter := router.Group("/api/v1/board")
+	boardRouter.Use(authMiddleware)
+	{
+		boardRouter.GET("", read, handler.GetBoard)
+	}
 }
 
 type Handler struct {
@@ -44,6 +61,7 @@ func NewTaskHandler(useCase usecases.TaskUseCase) *Handler {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param Idempotency-Key header string false "Ключ идемпотентности для безопасных повторов"
 // @Param request body task.CreateTaskRequest true "Данные задачи"
 // @Success 201 {object} task.TaskResponse
 // @Router /tasks [post]
@@ -61,7 +79,12 @@ func (h *Handler) CreateTask(c *gin.Context) {
 		return
 	}
 	entity := request.ToEntity(userID.(uuid.UUID))
-	model, err := h.useCase.Create(c, entity)
+	model, err := h.useCase.Create(c, entity, request.Tags)
+	if isRecurrenceError(err) {
+		zap.L().Warn("invalid task recurrence", zap.String("recurrence", request.Recurrence), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 	if err != nil {
 		zap.L().Error("failed to create task", zap.Error(err), zap.Any("user_id", userID))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -73,13 +96,14 @@ func (h *Handler) CreateTask(c *gin.Context) {
 
 // GetTask godoc
 // @Summary Получить задачу
-// @Description Получение задачи по ID
+// @Description Получение задачи по ID. Задача должна быть доступна текущему пользователю
 // @Tags tasks
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "ID задачи"
 // @Success 200 {object} task.TaskResponse
+// @Failure 404 {object} map[string]string
 // @Router /tasks/{id} [get]
 func (h *Handler) GetTask(c *gin.Context) {
 	idStr := c.Param("id")
@@ -90,7 +114,11 @@ func (h *Handler) GetTask(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	model, err := h.useCase.GetTask(c, id)
+	model, err := h.useCase.GetTask(c, id, userID.(uuid.UUID))
+	if errors.Is(err, usecases.ErrTaskNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
 	if err != nil {
 		zap.L().Error("failed to get task", zap.String("task_id", id.String()), zap.Error(err), zap.Any("user_id", userID))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -102,16 +130,35 @@ func (h *Handler) GetTask(c *gin.Context) {
 
 // GetTasks godoc
 // @Summary Получить список задач
-// @Description Получение всех задач текущего пользователя
+// @Description Получение задач текущего пользователя. Архивные задачи по умолчанию не возвращаются
 // @Tags tasks
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param include_archived query bool false "Включить архивные задачи"
+// @Param archived_only query bool false "Только архивные задачи"
+// @Param status query string false "Статус задачи"
+// @Param tag query []string false "Названия своих тегов; задача должна иметь все" collectionFormat(multi)
+// @Param q query string false "Поисковый запрос, например status:new tag:backend due<2026-11-01 -tag:wontfix"
 // @Success 200 {array} task.TaskAllResponse
+// @Failure 400 {object} map[string]any
 // @Router /tasks [get]
 func (h *Handler) GetTasks(c *gin.Context) {
 	userID, _ := c.Get("user_id")
-	tasks, err := h.useCase.GetTasksByUserID(c, userID.(uuid.UUID))
+	var query task.ListTasksQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		zap.L().Warn("invalid tasks query", zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	filter, err := query.ToFilter()
+	var syntaxErr *taskquery.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		zap.L().Warn("invalid tasks search query", zap.String("q", query.Query), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "position": syntaxErr.Pos})
+		return
+	}
+	tasks, err := h.useCase.GetTasksByUserID(c, userID.(uuid.UUID), filter)
 	if err != nil {
 		zap.L().Error("failed to get tasks", zap.Error(err), zap.Any("user_id", userID))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -127,7 +174,7 @@ func (h *Handler) GetTasks(c *gin.Context) {
 
 // UpdateTask godoc
 // @Summary Обновить задачу
-// @Description Обновление существующей задачи по ID
+// @Description Обновление существующей задачи по ID. Задача должна быть доступна текущему пользователю
 // @Tags tasks
 // @Accept json
 // @Produce json
@@ -152,7 +199,16 @@ func (h *Handler) UpdateTask(c *gin.Context) {
 		return
 	}
 	entity := request.ToEntity(id)
-	model, err := h.useCase.Update(c, entity)
+	model, err := h.useCase.Update(c, entity, userID.(uuid.UUID), request.Tags)
+	if errors.Is(err, usecases.ErrTaskNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if isRecurrenceError(err) {
+		zap.L().Warn("invalid task recurrence", zap.String("task_id", id.String()), zap.String("recurrence", request.Recurrence), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 	if err != nil {
 		zap.L().Error("failed to update task", zap.String("task_id", id.String()), zap.Error(err), zap.Any("user_id", userID))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -164,13 +220,14 @@ func (h *Handler) UpdateTask(c *gin.Context) {
 
 // DeleteTask godoc
 // @Summary Удалить задачу
-// @Description Удаление задачи по ID
+// @Description Перемещение задачи вместе с комментариями в корзину по ID. Удалить задачу может только её создатель
 // @Tags tasks
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "ID задачи"
 // @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string
 // @Router /tasks/{id} [delete]
 func (h *Handler) DeleteTask(c *gin.Context) {
 	idStr := c.Param("id")
@@ -181,7 +238,11 @@ func (h *Handler) DeleteTask(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	if err := h.useCase.Delete(c, id); err != nil {
+	if err := h.useCase.Delete(c, id, userID.(uuid.UUID)); err != nil {
+		if errors.Is(err, usecases.ErrTaskNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
 		zap.L().Error("failed to delete task", zap.String("task_id", id.String()), zap.Error(err), zap.Any("user_id", userID))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -190,9 +251,68 @@ func (h *Handler) DeleteTask(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "task deleted"})
 }
 
+// GetTrash godoc
+// @Summary Корзина задач
+// @Description Получение удалённых задач текущего пользователя, которые ещё можно восстановить
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} task.TaskAllResponse
+// @Router /tasks/trash [get]
+func (h *Handler) GetTrash(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	tasks, err := h.useCase.GetTrash(c, userID.(uuid.UUID))
+	if err != nil {
+		zap.L().Error("failed to get trash", zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	output := make([]*task.TaskAllResponse, 0, len(tasks))
+	for _, model := range tasks {
+		output = append(output, task.FromModelTaskForAll(model))
+	}
+	zap.L().Info("trash get", zap.Int("count", len(tasks)), zap.Any("user_id", userID))
+	c.JSON(http.StatusOK, output)
+}
+
+// RestoreTask godoc
+// @Summary Восстановить задачу
+// @Description Восстановление задачи из корзины вместе с комментариями и тегами
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID задачи"
+// @Success 200 {object} task.TaskResponse
+// @Failure 404 {object} map[string]string
+// @Router /tasks/{id}/restore [post]
+func (h *Handler) RestoreTask(c *gin.Context) {
+	idStr := c.Param("id")
+	userID, _ := c.Get("user_id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		zap.L().Warn("invalid restored task ID", zap.String("task_id", idStr), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	model, err := h.useCase.Restore(c, id, userID.(uuid.UUID))
+	if err != nil {
+		if errors.Is(err, usecases.ErrTaskNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		zap.L().Error("failed to restore task", zap.String("task_id", id.String()), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	zap.L().Info("task restored", zap.String("task_id", id.String()), zap.Any("user_id", userID))
+	c.JSON(http.StatusOK, task.FromModelTask(model))
+}
+
 // AddTags godoc
 // @Summary Добавить теги к задаче
-// @Description Привязка тегов к задаче
+// @Description Привязка тегов текущего пользователя к задаче
 // @Tags tasks
 // @Accept json
 // @Produce json
@@ -200,6 +320,8 @@ func (h *Handler) DeleteTask(c *gin.Context) {
 // @Param id path string true "ID задачи"
 // @Param request body []task.TagRequest true "Список тегов"
 // @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 422 {object} map[string]string
 // @Router /tasks/{id}/tags [post]
 func (h *Handler) AddTags(c *gin.Context) {
 	idStr := c.Param("id")
@@ -222,7 +344,16 @@ func (h *Handler) AddTags(c *gin.Context) {
 		tags = append(tags, entity)
 	}
 
-	if err := h.useCase.AddTags(c, id, tags); err != nil {
+	err = h.useCase.AddTags(c, id, userID.(uuid.UUID), tags)
+	if errors.Is(err, usecases.ErrTaskNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, usecases.ErrTagNotFound) {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
 		zap.L().Error("failed to add tags", zap.String("task_id", idStr), zap.Error(err), zap.Any("user_id", userID))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -241,6 +372,7 @@ func (h *Handler) AddTags(c *gin.Context) {
 // @Param id path string true "ID задачи"
 // @Param request body []task.TagRequest true "Список тегов для удаления"
 // @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string
 // @Router /tasks/{id}/tags [delete]
 func (h *Handler) DeleteTags(c *gin.Context) {
 	idStr := c.Param("id")
@@ -263,7 +395,12 @@ func (h *Handler) DeleteTags(c *gin.Context) {
 		tags = append(tags, entity)
 	}
 
-	if err := h.useCase.RemoveTags(c, id, tags); err != nil {
+	err = h.useCase.RemoveTags(c, id, userID.(uuid.UUID), tags)
+	if errors.Is(err, usecases.ErrTaskNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
 		zap.L().Error("failed to remove tags", zap.String("task_id", id.String()), zap.Error(err), zap.Any("user_id", userID))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -271,3 +408,307 @@ func (h *Handler) DeleteTags(c *gin.Context) {
 	zap.L().Info("tags removed", zap.String("task_id", id.String()), zap.Int("tag_count", len(tags)), zap.Any("user_id", userID))
 	c.JSON(http.StatusOK, gin.H{"message": "tags removed"})
 }
+
+// ArchiveTask godoc
+// @Summary Архивировать задачу
+// @Description Скрывает задачу из списка по умолчанию, не удаляя её. Задача должна быть доступна текущему пользователю
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID задачи"
+// @Success 200 {object} task.TaskResponse
+// @Failure 404 {object} map[string]string
+// @Router /tasks/{id}/archive [post]
+func (h *Handler) ArchiveTask(c *gin.Context) {
+	h.setArchived(c, true)
+}
+
+// UnarchiveTask godoc
+// @Summary Вернуть задачу из архива
+// @Description Возвращает архивную задачу в список по умолчанию. Задача должна быть доступна текущему пользователю
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID задачи"
+// @Success 200 {object} task.TaskResponse
+// @Failure 404 {object} map[string]string
+// @Router /tasks/{id}/unarchive [post]
+func (h *Handler) UnarchiveTask(c *gin.Context) {
+	h.setArchived(c, false)
+}
+
+func (h *Handler) setArchived(c *gin.Context, archived bool) {
+	idStr := c.Param("id")
+	userID, _ := c.Get("user_id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		zap.L().Warn("invalid archived task ID", zap.String("task_id", idStr), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	var model *models.Task
+	if archived {
+		model, err = h.useCase.Archive(c, id, userID.(uuid.UUID))
+	} else {
+		model, err = h.useCase.Unarchive(c, id, userID.(uuid.UUID))
+	}
+	if err != nil {
+		if errors.Is(err, usecases.ErrTaskNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		zap.L().Error("failed to change task archive state", zap.String("task_id", id.String()), zap.Bool("archived", archived), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	zap.L().Info("task archive state changed", zap.String("task_id", id.String()), zap.Bool("archived", archived), zap.Any("user_id", userID))
+	c.JSON(http.StatusOK, task.FromModelTask(model))
+}
+
+// AddAssignee godoc
+// @Summary Назначить исполнителя
+// @Description Назначает пользователя исполнителем задачи; он получает уведомление. Задача должна быть доступна текущему пользователю
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID задачи"
+// @Param request body task.AssigneeRequest true "Исполнитель"
+// @Success 200 {object} task.TaskResponse
+// @Failure 404 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Router /tasks/{id}/assignees [post]
+func (h *Handler) AddAssignee(c *gin.Context) {
+	idStr := c.Param("id")
+	userID, _ := c.Get("user_id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		zap.L().Warn("invalid task ID", zap.String("task_id", idStr), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	var request task.AssigneeRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		zap.L().Warn("invalid assignee request", zap.String("task_id", id.String()), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	model, err := h.useCase.AddAssignee(c, id, request.UserID, userID.(uuid.UUID))
+	h.assigneeResult(c, id, model, err)
+}
+
+// RemoveAssignee godoc
+// @Summary Снять исполнителя
+// @Description Снимает пользователя с задачи. Задача должна быть доступна текущему пользователю
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID задачи"
+// @Param user_id path string true "ID исполнителя"
+// @Success 200 {object} task.TaskResponse
+// @Failure 404 {object} map[string]string
+// @Router /tasks/{id}/assignees/{user_id} [delete]
+func (h *Handler) RemoveAssignee(c *gin.Context) {
+	idStr := c.Param("id")
+	userID, _ := c.Get("user_id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		zap.L().Warn("invalid task ID", zap.String("task_id", idStr), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	assigneeStr := c.Param("user_id")
+	assigneeID, err := uuid.Parse(assigneeStr)
+	if err != nil {
+		zap.L().Warn("invalid assignee ID", zap.String("assignee_id", assigneeStr), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	model, err := h.useCase.RemoveAssignee(c, id, assigneeID, userID.(uuid.UUID))
+	h.assigneeResult(c, id, model, err)
+}
+
+func (h *Handler) assigneeResult(c *gin.Context, id uuid.UUID, model *models.Task, err error) {
+	userID, _ := c.Get("user_id")
+	if errors.Is(err, usecases.ErrTaskNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, usecases.ErrUserNotFound) {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		zap.L().Error("failed to change task assignees", zap.String("task_id", id.String()), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	zap.L().Info("task assignees changed", zap.String("task_id", id.String()), zap.Any("user_id", userID))
+	c.JSON(http.StatusOK, task.FromModelTask(model))
+}
+
+// ReplaceTags godoc
+// @Summary Заменить теги задачи
+// @Description Делает набор тегов текущего пользователя на задаче равным переданному списку названий; отсутствующие теги создаются, теги других пользователей не меняются
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID задачи"
+// @Param request body task.ReplaceTagsRequest true "Названия тегов"
+// @Success 200 {object} task.TaskResponse
+// @Failure 404 {object} map[string]string
+// @Router /tasks/{id}/tags [put]
+func (h *Handler) ReplaceTags(c *gin.Context) {
+	idStr := c.Param("id")
+	userID, _ := c.Get("user_id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		zap.L().Warn("invalid task ID for replace tags", zap.String("task_id", idStr), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	var request task.ReplaceTagsRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		zap.L().Warn("invalid request for replace tags", zap.String("task_id", idStr), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	model, err := h.useCase.ReplaceTags(c, id, userID.(uuid.UUID), request.Tags)
+	if errors.Is(err, usecases.ErrTaskNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		zap.L().Error("failed to replace tags", zap.String("task_id", id.String()), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	zap.L().Info("tags replaced", zap.String("task_id", id.String()), zap.Int("tag_count", len(request.Tags)), zap.Any("user_id", userID))
+	c.JSON(http.StatusOK, task.FromModelTask(model))
+}
+
+func isRecurrenceError(err error) bool {
+	return errors.Is(err, usecases.ErrInvalidRecurrence) || errors.Is(err, usecases.ErrRecurrenceNeedsDue)
+}
+
+// GetOccurrences godoc
+// @Summary Получить ближайшие повторения задачи
+// @Description Ближайшие сроки серии повторяющейся задачи, начиная с текущего момента. У задачи без правила повторения список пуст
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID задачи"
+// @Param limit query int false "Количество повторений, по умолчанию 10, не больше 100"
+// @Success 200 {object} task.OccurrencesResponse
+// @Failure 404 {object} map[string]string
+// @Router /tasks/{id}/occurrences [get]
+func (h *Handler) GetOccurrences(c *gin.Context) {
+	idStr := c.Param("id")
+	userID, _ := c.Get("user_id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		zap.L().Warn("invalid task ID for occurrences", zap.String("task_id", idStr), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	var query task.OccurrencesQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		zap.L().Warn("invalid occurrences query", zap.String("task_id", idStr), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	occurrences, err := h.useCase.GetOccurrences(c, id, userID.(uuid.UUID), query.Limit)
+	if errors.Is(err, usecases.ErrTaskNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		zap.L().Error("failed to get occurrences", zap.String("task_id", id.String()), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	zap.L().Info("task occurrences get", zap.String("task_id", id.String()), zap.Int("count", len(occurrences)), zap.Any("user_id", userID))
+	c.JSON(http.StatusOK, task.OccurrencesResponse{TaskID: id, Occurrences: occurrences})
+}
+
+// GetBoard godoc
+// @Summary Получить доску задач
+// @Description Задачи текущего пользователя без архивных, сгруппированные по статусам в порядке доски. По умолчанию колонки: new, остальные используемые статусы по алфавиту и done
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param status query []string false "Статусы колонок в нужном порядке" collectionFormat(multi)
+// @Success 200 {object} task.BoardResponse
+// @Failure 400 {object} map[string]string
+// @Router /board [get]
+func (h *Handler) GetBoard(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	var query task.BoardQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		zap.L().Warn("invalid board query", zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	columns, err := h.useCase.GetBoard(c, userID.(uuid.UUID), query.Statuses)
+	if err != nil {
+		zap.L().Error("failed to get board", zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	zap.L().Info("board get", zap.Int("columns", len(columns)), zap.Any("user_id", userID))
+	c.JSON(http.StatusOK, task.FromModelBoard(columns))
+}
+
+// MoveTask godoc
+// @Summary Переместить задачу на доске
+// @Description Перемещение задачи в колонку статуса сразу после after_id или перед before_id; без них задача встаёт в конец колонки. Смена колонки меняет статус задачи
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID задачи"
+// @Param request body task.MoveTaskRequest true "Колонка и соседняя задача"
+// @Success 200 {object} task.TaskResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /tasks/{id}/move [post]
+func (h *Handler) MoveTask(c *gin.Context) {
+	idStr := c.Param("id")
+	userID, _ := c.Get("user_id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		zap.L().Warn("invalid task ID for move", zap.String("task_id", idStr), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	var request task.MoveTaskRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		zap.L().Warn("invalid move request", zap.String("task_id", idStr), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	model, err := h.useCase.Move(c, request.ToModel(id, userID.(uuid.UUID)))
+	if errors.Is(err, usecases.ErrTaskNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, usecases.ErrInvalidAnchor) {
+		zap.L().Warn("invalid move anchor", zap.String("task_id", id.String()), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		zap.L().Error("failed to move task", zap.String("task_id", id.String()), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	zap.L().Info("task moved", zap.String("task_id", id.String()), zap.String("status", request.Status), zap.Any("user_id", userID))
+	c.JSON(http.StatusOK, task.FromModelTask(model))
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -12,12 +13,15 @@ import (
 	"go.uber.org/mock/gomock"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"task-api/internal/adapters/api/task"
 	"task-api/internal/adapters/models"
 	"task-api/internal/domain/entities"
 	handler "task-api/internal/infrastructure/api/http/task"
+	"task-api/internal/usecases"
 	"task-api/internal/usecases/mocks"
 	"testing"
+	"time"
 )
 
 func TestHandler_GetTask_Success(t *testing.T) {
@@ -38,7 +42,7 @@ func TestHandler_GetTask_Success(t *testing.T) {
 		},
 	}
 
-	mockUseCase.EXPECT().GetTask(gomock.Any(), taskID).Return(expectedModel, nil)
+	mockUseCase.EXPECT().GetTask(gomock.Any(), taskID, userId).Return(expectedModel, nil)
 
 	gin.SetMode(gin.TestMode)
 	w := httptest.NewRecorder()
@@ -67,7 +71,7 @@ func TestHandler_GetTask_NotFound(t *testing.T) {
 	taskID := uuid.New()
 	userId := uuid.New()
 
-	mockUseCase.EXPECT().GetTask(gomock.Any(), taskID).Return(nil, errors.New("task not found"))
+	mockUseCase.EXPECT().GetTask(gomock.Any(), taskID, userId).Return(nil, errors.New("task not found"))
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -83,6 +87,28 @@ func TestHandler_GetTask_NotFound(t *testing.T) {
 	assert.Contains(t, w.Body.String(), "task not found")
 }
 
+func TestHandler_GetTask_NotVisible(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockTaskUseCase(ctrl)
+	h := handler.NewTaskHandler(mockUseCase)
+
+	taskID := uuid.New()
+	userID := uuid.New()
+	mockUseCase.EXPECT().GetTask(gomock.Any(), taskID, userID).Return(nil, usecases.ErrTaskNotFound)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("user_id", userID)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/tasks/"+taskID.String(), nil)
+	c.Params = gin.Params{{Key: "id", Value: taskID.String()}}
+
+	h.GetTask(c)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
 func TestHandler_CreateTask_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -96,6 +122,7 @@ func TestHandler_CreateTask_Success(t *testing.T) {
 	input := task.CreateTaskRequest{
 		Title:       "Test task",
 		Description: "Some description",
+		Tags:        []string{"work", "urgent"},
 	}
 	expected := &models.Task{
 		Task: entities.Task{
@@ -107,8 +134,8 @@ func TestHandler_CreateTask_Success(t *testing.T) {
 	}
 
 	mockUseCase.EXPECT().
-		Create(gomock.Any(), gomock.AssignableToTypeOf(&entities.Task{})).
-		DoAndReturn(func(_ context.Context, actual *entities.Task) (*models.Task, error) {
+		Create(gomock.Any(), gomock.AssignableToTypeOf(&entities.Task{}), []string{"work", "urgent"}).
+		DoAndReturn(func(_ context.Context, actual *entities.Task, _ []string) (*models.Task, error) {
 			expectedEntity := input.ToEntity(userID)
 			require.Equal(t, expectedEntity.Title, actual.Title)
 			require.Equal(t, expectedEntity.Description, actual.Description)
@@ -171,7 +198,7 @@ func TestHandler_CreateTask_UseCaseError(t *testing.T) {
 	}
 
 	mockUseCase.EXPECT().
-		Create(gomock.Any(), gomock.AssignableToTypeOf(&entities.Task{})).
+		Create(gomock.Any(), gomock.AssignableToTypeOf(&entities.Task{}), gomock.Nil()).
 		Return(nil, errors.New("create failed"))
 
 	body, _ := json.Marshal(input)
@@ -241,8 +268,8 @@ func TestHandler_UpdateTask_Success(t *testing.T) {
 	}
 
 	mockUseCase.EXPECT().
-		Update(gomock.Any(), gomock.Any()).
-		DoAndReturn(func(_ context.Context, task *entities.Task) (*models.Task, error) {
+		Update(gomock.Any(), gomock.Any(), userID, gomock.Nil()).
+		DoAndReturn(func(_ context.Context, task *entities.Task, _ uuid.UUID, _ []string) (*models.Task, error) {
 			expectedEntity := input.ToEntity(userID)
 			assert.Equal(t, expectedEntity.CreatedBy, task.CreatedBy)
 			assert.Equal(t, expectedEntity.Title, task.Title)
@@ -311,7 +338,7 @@ func TestHandler_GetTasks_Success(t *testing.T) {
 	}
 
 	mockUseCase.EXPECT().
-		GetTasksByUserID(gomock.Any(), userId).
+		GetTasksByUserID(gomock.Any(), userId, models.TaskFilter{}).
 		Return(expectedModel, nil)
 
 	gin.SetMode(gin.TestMode)
@@ -350,3 +377,565 @@ func TestHandler_GetTasks_Success(t *testing.T) {
 
 	assert.Equal(t, expected, actualResponse)
 }
+
+func TestHandler_RestoreTask_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockTaskUseCase(ctrl)
+	h := handler.NewTaskHandler(mockUseCase)
+
+	taskID := uuid.New()
+	userId := uuid.New()
+
+	mockUseCase.EXPECT().
+		Restore(gomock.Any(), taskID, userId).
+		Return(&models.Task{Task: entities.Task{ID: taskID, Title: "Restored"}}, nil)
+
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+
+	c.Set("user_id", userId)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/tasks/"+taskID.String()+"/restore", nil)
+	c.Params = gin.Params{{Key: "id", Value: taskID.String()}}
+
+	h.RestoreTask(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"title":"Restored"`)
+}
+
+func TestHandler_RestoreTask_NotInTrash(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockTaskUseCase(ctrl)
+	h := handler.NewTaskHandler(mockUseCase)
+
+	taskID := uuid.New()
+	userId := uuid.New()
+
+	mockUseCase.EXPECT().
+		Restore(gomock.Any(), taskID, userId).
+		Return(nil, usecases.ErrTaskNotFound)
+
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+
+	c.Set("user_id", userId)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/tasks/"+taskID.String()+"/restore", nil)
+	c.Params = gin.Params{{Key: "id", Value: taskID.String()}}
+
+	h.RestoreTask(c)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestHandler_GetTrash_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockTaskUseCase(ctrl)
+	h := handler.NewTaskHandler(mockUseCase)
+
+	userId := uuid.New()
+	deletedAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	mockUseCase.EXPECT().
+		GetTrash(gomock.Any(), userId).
+		Return([]*models.TasksWishTags{{Task: entities.Task{ID: uuid.New(), Title: "Deleted", DeletedAt: &deletedAt}}}, nil)
+
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+
+	c.Set("user_id", userId)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/tasks/trash", nil)
+
+	h.GetTrash(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var actualResponse []*task.TaskAllResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &actualResponse))
+	require.Len(t, actualResponse, 1)
+	require.NotNil(t, actualResponse[0].DeletedAt)
+	assert.True(t, deletedAt.Equal(*actualResponse[0].DeletedAt))
+}
+
+func TestHandler_GetTasks_ArchivedOnly(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockTaskUseCase(ctrl)
+	h := handler.NewTaskHandler(mockUseCase)
+
+	userId := uuid.New()
+
+	mockUseCase.EXPECT().
+		GetTasksByUserID(gomock.Any(), userId, models.TaskFilter{ArchivedOnly: true}).
+		Return(nil, nil)
+
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+
+	c.Set("user_id", userId)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/tasks?archived_only=true", nil)
+
+	h.GetTasks(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestHandler_GetTasks_StatusAndTags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockTaskUseCase(ctrl)
+	h := handler.NewTaskHandler(mockUseCase)
+
+	userId := uuid.New()
+
+	mockUseCase.EXPECT().
+		GetTasksByUserID(gomock.Any(), userId, models.TaskFilter{Status: "new", Tags: []string{"backend", "urgent"}}).
+		Return(nil, nil)
+
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+
+	c.Set("user_id", userId)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/tasks?status=new&tag=backend&tag=urgent", nil)
+
+	h.GetTasks(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestHandler_ArchiveTask_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockTaskUseCase(ctrl)
+	h := handler.NewTaskHandler(mockUseCase)
+
+	taskID := uuid.New()
+	userID := uuid.New()
+	mockUseCase.EXPECT().Archive(gomock.Any(), taskID, userID).Return(nil, usecases.ErrTaskNotFound)
+
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+
+	c.Set("user_id", userID)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/tasks/"+taskID.String()+"/archive", nil)
+	c.Params = gin.Params{{Key: "id", Value: taskID.String()}}
+
+	h.ArchiveTask(c)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestHandler_AddAssignee_UnknownUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockTaskUseCase(ctrl)
+	h := handler.NewTaskHandler(mockUseCase)
+
+	taskID := uuid.New()
+	userID := uuid.New()
+	assigneeID := uuid.New()
+
+	mockUseCase.EXPECT().AddAssignee(gomock.Any(), taskID, assigneeID, userID).Return(nil, usecases.ErrUserNotFound)
+
+	body, _ := json.Marshal(task.AssigneeRequest{UserID: assigneeID})
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("user_id", userID)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/tasks/"+taskID.String()+"/assignees", bytes.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{{Key: "id", Value: taskID.String()}}
+
+	h.AddAssignee(c)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+}
+
+func TestHandler_RemoveAssignee_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockTaskUseCase(ctrl)
+	h := handler.NewTaskHandler(mockUseCase)
+
+	taskID := uuid.New()
+	userID := uuid.New()
+	assigneeID := uuid.New()
+
+	mockUseCase.EXPECT().RemoveAssignee(gomock.Any(), taskID, assigneeID, userID).
+		Return(&models.Task{Task: entities.Task{ID: taskID}}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("user_id", userID)
+	c.Request, _ = http.NewRequest(http.MethodDelete, "/api/v1/tasks/"+taskID.String()+"/assignees/"+assigneeID.String(), nil)
+	c.Params = gin.Params{{Key: "id", Value: taskID.String()}, {Key: "user_id", Value: assigneeID.String()}}
+
+	h.RemoveAssignee(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"id":"`+taskID.String()+`"`)
+}
+
+func TestHandler_AddTags_ForeignTag(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockTaskUseCase(ctrl)
+	h := handler.NewTaskHandler(mockUseCase)
+
+	taskID := uuid.New()
+	userID := uuid.New()
+	tagID := uuid.New()
+
+	mockUseCase.EXPECT().AddTags(gomock.Any(), taskID, userID, []*entities.Tag{{ID: tagID}}).Return(usecases.ErrTagNotFound)
+
+	body, _ := json.Marshal([]task.TagRequest{{ID: tagID}})
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("user_id", userID)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/tasks/"+taskID.String()+"/tags", bytes.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{{Key: "id", Value: taskID.String()}}
+
+	h.AddTags(c)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+}
+
+func TestHandler_ReplaceTags_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockTaskUseCase(ctrl)
+	h := handler.NewTaskHandler(mockUseCase)
+
+	taskID := uuid.New()
+	userID := uuid.New()
+	tagID := uuid.New()
+
+	mockUseCase.EXPECT().ReplaceTags(gomock.Any(), taskID, userID, []string{"work"}).
+		Return(&models.Task{
+			Task: entities.Task{ID: taskID, CreatedBy: userID},
+			Tags: []entities.Tag{{ID: tagID, OwnerID: userID, Title: "work"}},
+		}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("user_id", userID)
+	c.Request, _ = http.NewRequest(http.MethodPut, "/api/v1/tasks/"+taskID.String()+"/tags", bytes.NewReader([]byte(`{"tags":["work"]}`)))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{{Key: "id", Value: taskID.String()}}
+
+	h.ReplaceTags(c)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var res task.TaskResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	require.Len(t, res.Tags, 1)
+	assert.Equal(t, "work", res.Tags[0].Title)
+}
+
+func TestHandler_ReplaceTags_RequiresTags(t *testing.T) {
+	h := handler.NewTaskHandler(nil)
+
+	taskID := uuid.New()
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("user_id", uuid.New())
+	c.Request, _ = http.NewRequest(http.MethodPut, "/api/v1/tasks/"+taskID.String()+"/tags", bytes.NewReader([]byte(`{}`)))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{{Key: "id", Value: taskID.String()}}
+
+	h.ReplaceTags(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestHandler_GetTasks_InvalidQuery(t *testing.T) {
+	h := handler.NewTaskHandler(nil)
+
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("user_id", uuid.New())
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/tasks?q="+url.QueryEscape("tag:backend priority:high"), nil)
+
+	h.GetTasks(c)
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	var res struct {
+		Error    string `json:"error"`
+		Position int    `json:"position"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Equal(t, 13, res.Position)
+	assert.Contains(t, res.Error, `unknown field "priority"`)
+}
+
+func TestHandler_CreateTask_InvalidRecurrence(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockTaskUseCase(ctrl)
+	h := handler.NewTaskHandler(mockUseCase)
+
+	userID := uuid.New()
+	mockUseCase.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, entity *entities.Task, _ []string) (*models.Task, error) {
+			assert.Equal(t, "FREQ=HOURLY", entity.Recurrence)
+			return nil, fmt.Errorf("%w: unsupported FREQ HOURLY", usecases.ErrInvalidRecurrence)
+		})
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("user_id", userID)
+	body := `{"title":"Release","description":"Weekly release","due_at":"2026-11-02T10:00:00Z","recurrence":"FREQ=HOURLY"}`
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/tasks", bytes.NewReader([]byte(body)))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	h.CreateTask(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "unsupported FREQ HOURLY")
+}
+
+func TestHandler_UpdateTask_RecurrenceNeedsDue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockTaskUseCase(ctrl)
+	h := handler.NewTaskHandler(mockUseCase)
+
+	taskID := uuid.New()
+	userID := uuid.New()
+	mockUseCase.EXPECT().Update(gomock.Any(), gomock.Any(), userID, gomock.Any()).Return(nil, usecases.ErrRecurrenceNeedsDue)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("user_id", userID)
+	body := `{"title":"Release","description":"Weekly release","status":"new","recurrence":"FREQ=WEEKLY"}`
+	c.Request, _ = http.NewRequest(http.MethodPut, "/api/v1/tasks/"+taskID.String(), bytes.NewReader([]byte(body)))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{{Key: "id", Value: taskID.String()}}
+
+	h.UpdateTask(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestHandler_GetOccurrences_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockTaskUseCase(ctrl)
+	h := handler.NewTaskHandler(mockUseCase)
+
+	taskID := uuid.New()
+	userID := uuid.New()
+	occurrences := []time.Time{
+		time.Date(2026, 11, 2, 10, 0, 0, 0, time.UTC),
+		time.Date(2026, 11, 9, 10, 0, 0, 0, time.UTC),
+	}
+	mockUseCase.EXPECT().GetOccurrences(gomock.Any(), taskID, userID, 2).Return(occurrences, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("user_id", userID)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/tasks/"+taskID.String()+"/occurrences?limit=2", nil)
+	c.Params = gin.Params{{Key: "id", Value: taskID.String()}}
+
+	h.GetOccurrences(c)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var res task.OccurrencesResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Equal(t, taskID, res.TaskID)
+	assert.Equal(t, occurrences, res.Occurrences)
+}
+
+func TestHandler_GetOccurrences_LimitTooLarge(t *testing.T) {
+	h := handler.NewTaskHandler(nil)
+
+	taskID := uuid.New()
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("user_id", uuid.New())
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/tasks/"+taskID.String()+"/occurrences?limit=500", nil)
+	c.Params = gin.Params{{Key: "id", Value: taskID.String()}}
+
+	h.GetOccurrences(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestHandler_MoveTask_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockTaskUseCase(ctrl)
+	h := handler.NewTaskHandler(mockUseCase)
+
+	taskID := uuid.New()
+	userID := uuid.New()
+	afterID := uuid.New()
+	mockUseCase.EXPECT().Move(gomock.Any(), models.TaskMove{TaskID: taskID, ActorID: userID, Status: "in_progress", AfterID: &afterID}).
+		Return(&models.Task{Task: entities.Task{ID: taskID, Status: "in_progress", Rank: "VV"}}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("user_id", userID)
+	body := fmt.Sprintf(`{"status":"in_progress","after_id":"%s"}`, afterID)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/tasks/"+taskID.String()+"/move", bytes.NewReader([]byte(body)))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{{Key: "id", Value: taskID.String()}}
+
+	h.MoveTask(c)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var res task.TaskResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Equal(t, "in_progress", res.Status)
+	assert.Equal(t, "VV", res.Rank)
+}
+
+func TestHandler_MoveTask_BothNeighbours(t *testing.T) {
+	h := handler.NewTaskHandler(nil)
+
+	taskID := uuid.New()
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("user_id", uuid.New())
+	body := fmt.Sprintf(`{"status":"new","after_id":"%s","before_id":"%s"}`, uuid.New(), uuid.New())
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/tasks/"+taskID.String()+"/move", bytes.NewReader([]byte(body)))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{{Key: "id", Value: taskID.String()}}
+
+	h.MoveTask(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestHandler_MoveTask_InvalidAnchor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockTaskUseCase(ctrl)
+	h := handler.NewTaskHandler(mockUseCase)
+
+	taskID := uuid.New()
+	mockUseCase.EXPECT().Move(gomock.Any(), gomock.Any()).Return(nil, usecases.ErrInvalidAnchor)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("user_id", uuid.New())
+	body := fmt.Sprintf(`{"status":"done","before_id":"%s"}`, uuid.New())
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/tasks/"+taskID.String()+"/move", bytes.NewReader([]byte(body)))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{{Key: "id", Value: taskID.String()}}
+
+	h.MoveTask(c)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestHandler_GetBoard_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockTaskUseCase(ctrl)
+	h := handler.NewTaskHandler(mockUseCase)
+
+	userID := uuid.New()
+	first, second := uuid.New(), uuid.New()
+	mockUseCase.EXPECT().GetBoard(gomock.Any(), userID, []string{"new", "done"}).Return([]models.BoardColumn{
+		{Status: "new", Tasks: []*models.TasksWishTags{
+			{Task: entities.Task{ID: first, Status: "new", Rank: "G"}},
+			{Task: entities.Task{ID: second, Status: "new", Rank: "V"}},
+		}},
+		{Status: "done"},
+	}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("user_id", userID)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/board?status=new&status=done", nil)
+
+	h.GetBoard(c)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, fmt.Sprintf(`{"columns":[{"status":"new","tasks":[%s,%s]},{"status":"done","tasks":[]}]}`,
+		boardTaskJSON(first, "G"), boardTaskJSON(second, "V")), w.Body.String())
+}
+
+func boardTaskJSON(id uuid.UUID, rank string) string {
+	return fmt.Sprintf(`{"id":"%s","title":"","description":"","status":"new","priority":"","rank":"%s","tags":null,
+		"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z"}`, id, rank)
+}
+
+func TestHandler_GetTask_TimeTotals(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockTaskUseCase(ctrl)
+	h := handler.NewTaskHandler(mockUseCase)
+
+	taskID := uuid.New()
+	anna, boris := uuid.New(), uuid.New()
+	mockUseCase.EXPECT().GetTask(gomock.Any(), taskID, anna).Return(&models.Task{
+		Task: entities.Task{ID: taskID},
+		TimeTotals: []models.TimeTotal{
+			{User: entities.User{ID: anna, Name: "Anna"}, Seconds: 5400},
+			{User: entities.User{ID: boris, Name: "Boris"}, Seconds: 600},
+		},
+	}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("user_id", anna)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/tasks/"+taskID.String(), nil)
+	c.Params = gin.Params{{Key: "id", Value: taskID.String()}}
+
+	h.GetTask(c)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var res task.TaskResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Equal(t, int64(6000), res.TimeSpentSeconds)
+	assert.Equal(t, []task.TimeTotal{{UserID: anna, Name: "Anna", Seconds: 5400}, {UserID: boris, Name: "Boris", Seconds: 600}}, res.TimeByUser)
+}
+
+func TestHandler_DeleteTask_NotCreator(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockTaskUseCase(ctrl)
+	h := handler.NewTaskHandler(mockUseCase)
+
+	taskID := uuid.New()
+	userID := uuid.New()
+	mockUseCase.EXPECT().Delete(gomock.Any(), taskID, userID).Return(usecases.ErrTaskNotFound)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("user_id", userID)
+	c.Request, _ = http.NewRequest(http.MethodDelete, "/api/v1/tasks/"+taskID.String(), nil)
+	c.Params = gin.Params{{Key: "id", Value: taskID.String()}}
+
+	h.DeleteTask(c)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
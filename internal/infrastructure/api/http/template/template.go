@@ -0,0 +1,253 @@
+package template
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+	"task-api/internal/adapters/api/task"
+	"task-api/internal/adapters/api/template"
+	"task-api/internal/infrastructure/api/middleware"
+	"task-api/internal/infrastructure/security"
+	"task-api/internal/usecases"
+)
+
+func Router(router *gin.Engine, handler *Handler, authMiddleware, idempotency gin.HandlerFunc) {
+	read := middleware.RequireScope(security.ScopeTemplatesRead)
+	write := middleware.RequireScope(security.ScopeTemplatesWrite)
+	templateRouter := router.Group("/api/v1/templates")
+	templateRouter.Use(authMiddleware)
+	{
+		templateRouter.GET("", read, handler.GetTemplates)
+		templateRouter.POST("", write, handler.Create)
+		templateRouter.GET("/:id", read, handler.GetTemplate)
+		templateRouter.PUT("/:id", write, handler.Update)
+		templateRouter.DELETE("/:id", write, handler.Delete)
+	}
+	taskRouter := router.Group("/api/v1/tasks")
+	taskRouter.Use(authMiddleware)
+	{
+		taskRouter.POST("/from-template/:id", read, middleware.RequireScope(security.ScopeTasksWrite), idempotency, handler.Instantiate)
+	}
+}
+
+type Handler struct {
+	useCase usecases.TemplateUseCase
+}
+
+func NewTemplateHandler(useCase usecases.TemplateUseCase) *Handler {
+	return &Handler{useCase: useCase}
+}
+
+// GetTemplates godoc
+// @Summary Получить шаблоны задач
+// @Description Возвращает шаблоны текущего пользователя по названию
+// @Tags templates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} template.TemplateResponse
+// @Router /templates [get]
+func (h *Handler) GetTemplates(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	templates, err := h.useCase.GetTemplates(c, userID.(uuid.UUID))
+	if err != nil {
+		zap.L().Error("failed get templates", zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	output := make([]*template.TemplateResponse, 0, len(templates))
+	for _, entity := range templates {
+		output = append(output, template.FromEntityTemplate(entity))
+	}
+	c.JSON(http.StatusOK, output)
+}
+
+// Create godoc
+// @Summary Создать шаблон задачи
+// @Description Сохраняет задачу с подзадачами, тегами и приоритетом как шаблон. В названиях и описаниях можно использовать переменные {{name}}
+// @Tags templates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body template.CreateTemplateRequest true "Шаблон"
+// @Success 201 {object} template.TemplateResponse
+// @Failure 400 {object} map[string]string
+// @Router /templates [post]
+func (h *Handler) Create(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	var request template.CreateTemplateRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		zap.L().Warn("invalid template request", zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	entity, err := h.useCase.Create(c, request.ToEntity(userID.(uuid.UUID)))
+	if err != nil {
+		zap.L().Error("failed create template", zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	zap.L().Info("template created", zap.String("template_id", entity.ID.String()), zap.Any("user_id", userID))
+	c.JSON(http.StatusCreated, template.FromEntityTemplate(entity))
+}
+
+// GetTemplate godoc
+// @Summary Получить шаблон задачи
+// @Description Возвращает шаблон текущего пользователя и список переменных в нём
+// @Tags templates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID шаблона"
+// @Success 200 {object} template.TemplateResponse
+// @Failure 404 {object} map[string]string
+// @Router /templates/{id} [get]
+func (h *Handler) GetTemplate(c *gin.Context) {
+	idStr := c.Param("id")
+	userID, _ := c.Get("user_id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		zap.L().Warn("invalid template id", zap.String("template_id", idStr), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	entity, err := h.useCase.GetTemplate(c, id, userID.(uuid.UUID))
+	if errors.Is(err, usecases.ErrTemplateNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		zap.L().Error("failed get template", zap.String("template_id", id.String()), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, template.FromEntityTemplate(entity))
+}
+
+// Update godoc
+// @Summary Изменить шаблон задачи
+// @Description Заменяет содержимое шаблона; задачи, уже созданные по нему, не меняются
+// @Tags templates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID шаблона"
+// @Param request body template.UpdateTemplateRequest true "Шаблон"
+// @Success 200 {object} template.TemplateResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /templates/{id} [put]
+func (h *Handler) Update(c *gin.Context) {
+	idStr := c.Param("id")
+	userID, _ := c.Get("user_id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		zap.L().Warn("invalid template id", zap.String("template_id", idStr), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	var request template.UpdateTemplateRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		zap.L().Warn("invalid template request", zap.String("template_id", id.String()), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	entity, err := h.useCase.Update(c, request.ToEntity(id, userID.(uuid.UUID)))
+	if errors.Is(err, usecases.ErrTemplateNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		zap.L().Error("failed update template", zap.String("template_id", id.String()), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	zap.L().Info("template updated", zap.String("template_id", id.String()), zap.Any("user_id", userID))
+	c.JSON(http.StatusOK, template.FromEntityTemplate(entity))
+}
+
+// Delete godoc
+// @Summary Удалить шаблон задачи
+// @Description Удаляет шаблон; задачи, созданные по нему, остаются
+// @Tags templates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID шаблона"
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /templates/{id} [delete]
+func (h *Handler) Delete(c *gin.Context) {
+	idStr := c.Param("id")
+	userID, _ := c.Get("user_id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		zap.L().Warn("invalid template id", zap.String("template_id", idStr), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	err = h.useCase.Delete(c, id, userID.(uuid.UUID))
+	if errors.Is(err, usecases.ErrTemplateNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		zap.L().Error("failed delete template", zap.String("template_id", id.String()), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	zap.L().Info("template deleted", zap.String("template_id", id.String()), zap.Any("user_id", userID))
+	c.JSON(http.StatusOK, gin.H{"message": "Template deleted"})
+}
+
+// Instantiate godoc
+// @Summary Создать задачи по шаблону
+// @Description Создаёт задачу и все подзадачи шаблона в одной транзакции, подставляя значения переменных {{name}}. Переменная {{date}} по умолчанию равна текущей дате (UTC). Если у какой-либо переменной нет значения, ничего не создаётся
+// @Tags templates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param Idempotency-Key header string false "Ключ идемпотентности для безопасных повторов"
+// @Param id path string true "ID шаблона"
+// @Param request body template.InstantiateRequest false "Значения переменных и срок"
+// @Success 201 {object} task.TaskResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /tasks/from-template/{id} [post]
+func (h *Handler) Instantiate(c *gin.Context) {
+	idStr := c.Param("id")
+	userID, _ := c.Get("user_id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		zap.L().Warn("invalid template id", zap.String("template_id", idStr), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	// The body is optional for templates without variables.
+	var request template.InstantiateRequest
+	if err := c.ShouldBindJSON(&request); err != nil && !errors.Is(err, io.EOF) {
+		zap.L().Warn("invalid instantiate request", zap.String("template_id", id.String()), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	model, err := h.useCase.Instantiate(c, id, userID.(uuid.UUID), request.Variables, request.DueAtUTC())
+	if errors.Is(err, usecases.ErrTemplateNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, usecases.ErrMissingVariables) || errors.Is(err, usecases.ErrEmptyTaskTitle) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		zap.L().Error("failed create tasks from template", zap.String("template_id", id.String()), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	zap.L().Info("tasks created from template", zap.String("template_id", id.String()), zap.String("task_id", model.Task.ID.String()),
+		zap.Int("subtasks", len(model.Subtasks)), zap.Any("user_id", userID))
+	c.JSON(http.StatusCreated, task.FromModelTask(model))
+}
@@ -0,0 +1,164 @@
+package template_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"task-api/internal/adapters/api/task"
+	"task-api/internal/adapters/api/template"
+	"task-api/internal/adapters/models"
+	"task-api/internal/domain/entities"
+	handler "task-api/internal/infrastructure/api/http/template"
+	"task-api/internal/usecases"
+	"task-api/internal/usecases/mocks"
+	"testing"
+	"time"
+)
+
+func newContext(method, path string, body any, userID uuid.UUID) (*gin.Context, *httptest.ResponseRecorder) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("user_id", userID)
+	var payload []byte
+	if body != nil {
+		payload, _ = json.Marshal(body)
+	}
+	c.Request, _ = http.NewRequest(method, path, bytes.NewReader(payload))
+	c.Request.Header.Set("Content-Type", "application/json")
+	return c, w
+}
+
+func TestHandler_Create_ListsVariables(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockTemplateUseCase(ctrl)
+	h := handler.NewTemplateHandler(mockUseCase)
+
+	userID := uuid.New()
+	templateID := uuid.New()
+	mockUseCase.EXPECT().Create(gomock.Any(), gomock.AssignableToTypeOf(&entities.Template{})).
+		DoAndReturn(func(_ context.Context, tpl *entities.Template) (*entities.Template, error) {
+			assert.Equal(t, userID, tpl.OwnerID)
+			assert.Equal(t, []entities.TemplateSubtask{{Title: "Laptop for {{name}}", Priority: "high"}}, tpl.Subtasks)
+			tpl.ID = templateID
+			tpl.Priority = entities.TaskPriorityMedium
+			return tpl, nil
+		})
+
+	c, w := newContext(http.MethodPost, "/api/v1/templates", template.CreateTemplateRequest{
+		Name:        "Onboarding",
+		Title:       "Onboard {{name}}",
+		Description: "Starts on {{ start }}",
+		Tags:        []string{"onboarding"},
+		Subtasks:    []template.SubtaskRequest{{Title: "Laptop for {{name}}", Priority: "high"}},
+	}, userID)
+
+	h.Create(c)
+
+	require.Equal(t, http.StatusCreated, w.Code)
+	var res template.TemplateResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Equal(t, templateID, res.ID)
+	assert.Equal(t, []string{"name", "start"}, res.Variables)
+	assert.Equal(t, "medium", res.Priority)
+}
+
+func TestHandler_Create_InvalidPriority(t *testing.T) {
+	h := handler.NewTemplateHandler(nil)
+
+	c, w := newContext(http.MethodPost, "/api/v1/templates", template.CreateTemplateRequest{
+		Name:     "Onboarding",
+		Title:    "Onboard",
+		Subtasks: []template.SubtaskRequest{{Title: "Laptop", Priority: "urgent"}},
+	}, uuid.New())
+
+	h.Create(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestHandler_GetTemplate_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockTemplateUseCase(ctrl)
+	h := handler.NewTemplateHandler(mockUseCase)
+
+	userID := uuid.New()
+	templateID := uuid.New()
+	mockUseCase.EXPECT().GetTemplate(gomock.Any(), templateID, userID).Return(nil, usecases.ErrTemplateNotFound)
+
+	c, w := newContext(http.MethodGet, "/api/v1/templates/"+templateID.String(), nil, userID)
+	c.Params = gin.Params{{Key: "id", Value: templateID.String()}}
+
+	h.GetTemplate(c)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestHandler_Instantiate_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockTemplateUseCase(ctrl)
+	h := handler.NewTemplateHandler(mockUseCase)
+
+	userID := uuid.New()
+	templateID := uuid.New()
+	taskID := uuid.New()
+	dueAt := time.Date(2026, 11, 2, 9, 0, 0, 0, time.UTC)
+	mockUseCase.EXPECT().Instantiate(gomock.Any(), templateID, userID, map[string]string{"name": "Alice"}, &dueAt).
+		Return(&models.Task{
+			Task: entities.Task{ID: taskID, Title: "Onboard Alice", Priority: "medium", DueAt: &dueAt},
+			Subtasks: []entities.Task{
+				{ID: uuid.New(), Title: "Laptop for Alice", Status: "new", Priority: "high", ParentID: &taskID},
+			},
+		}, nil)
+
+	c, w := newContext(http.MethodPost, "/api/v1/tasks/from-template/"+templateID.String(), template.InstantiateRequest{
+		Variables: map[string]string{"name": "Alice"},
+		DueAt:     &dueAt,
+	}, userID)
+	c.Params = gin.Params{{Key: "id", Value: templateID.String()}}
+
+	h.Instantiate(c)
+
+	require.Equal(t, http.StatusCreated, w.Code)
+	var res task.TaskResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Equal(t, "Onboard Alice", res.Title)
+	require.Len(t, res.Subtasks, 1)
+	assert.Equal(t, "Laptop for Alice", res.Subtasks[0].Title)
+	assert.Equal(t, "high", res.Subtasks[0].Priority)
+}
+
+func TestHandler_Instantiate_WithoutBody(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockTemplateUseCase(ctrl)
+	h := handler.NewTemplateHandler(mockUseCase)
+
+	userID := uuid.New()
+	templateID := uuid.New()
+	mockUseCase.EXPECT().Instantiate(gomock.Any(), templateID, userID, nil, nil).
+		Return(nil, fmt.Errorf("%w: name", usecases.ErrMissingVariables))
+
+	c, w := newContext(http.MethodPost, "/api/v1/tasks/from-template/"+templateID.String(), nil, userID)
+	c.Params = gin.Params{{Key: "id", Value: templateID.String()}}
+
+	h.Instantiate(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "template variables have no value: name")
+}
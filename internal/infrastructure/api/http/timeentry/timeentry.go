@@ -0,0 +1,324 @@
+package timeentry
+
+import (
+	"bytes"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+	"task-api/internal/adapters/api/timeentry"
+	"task-api/internal/infrastructure/api/middleware"
+	"task-api/internal/infrastructure/security"
+	"task-api/internal/usecases"
+)
+
+func Router(router *gin.Engine, handler *Handler, authMiddleware, idempotency gin.HandlerFunc) {
+	read := middleware.RequireScope(security.ScopeTimeRead)
+	write := middleware.RequireScope(security.ScopeTimeWrite)
+	taskRouter := router.Group("/api/v1/tasks")
+	taskRouter.Use(authMiddleware)
+	{
+		taskRouter.POST("/:id/timer/start", write, handler.StartTimer)
+		taskRouter.POST("/:id/timer/stop", write, handler.StopTimer)
+		taskRouter.GET("/:id/time-entries", read, handler.GetEntries)
+		taskRouter.POST("/:id/time-entries", write, idempotency, handler.CreateEntry)
+	}
+	timeRouter := router.Group("/api/v1")
+	timeRouter.Use(authMiddleware)
+	{
+		timeRouter.GET("/timer", read, handler.GetTimer)
+		timeRouter.DELETE("/time-entries/:id", write, handler.DeleteEntry)
+		timeRouter.GET("/reports/time", read, handler.GetReport)
+	}
+}
+
+type Handler struct {
+	useCase usecases.TimeEntryUseCase
+}
+
+func NewTimeEntryHandler(useCase usecases.TimeEntryUseCase) *Handler {
+	return &Handler{useCase: useCase}
+}
+
+// StartTimer godoc
+// @Summary Запустить таймер
+// @Description Запускает таймер текущего пользователя на задаче. У пользователя может быть только один запущенный таймер
+// @Tags time
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID задачи"
+// @Param request body timeentry.StartTimerRequest false "Заметка"
+// @Success 201 {object} timeentry.TimeEntryResponse
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /tasks/{id}/timer/start [post]
+func (h *Handler) StartTimer(c *gin.Context) {
+	idStr := c.Param("id")
+	userID, _ := c.Get("user_id")
+	taskID, err := uuid.Parse(idStr)
+	if err != nil {
+		zap.L().Warn("invalid task ID for timer", zap.String("task_id", idStr), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	// The body is optional: a timer may be started without a note.
+	var request timeentry.StartTimerRequest
+	if err := c.ShouldBindJSON(&request); err != nil && !errors.Is(err, io.EOF) {
+		zap.L().Warn("invalid start timer request", zap.String("task_id", idStr), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	model, err := h.useCase.Start(c, taskID, userID.(uuid.UUID), request.Note)
+	if errors.Is(err, usecases.ErrTaskNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, usecases.ErrTimerRunning) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		zap.L().Error("failed to start timer", zap.String("task_id", idStr), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	zap.L().Info("timer started", zap.String("task_id", idStr), zap.String("time_entry_id", model.Entry.ID.String()), zap.Any("user_id", userID))
+	c.JSON(http.StatusCreated, timeentry.FromModelTimeEntry(model))
+}
+
+// StopTimer godoc
+// @Summary Остановить таймер
+// @Description Останавливает таймер текущего пользователя на задаче
+// @Tags time
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID задачи"
+// @Success 200 {object} timeentry.TimeEntryResponse
+// @Failure 404 {object} map[string]string
+// @Router /tasks/{id}/timer/stop [post]
+func (h *Handler) StopTimer(c *gin.Context) {
+	idStr := c.Param("id")
+	userID, _ := c.Get("user_id")
+	taskID, err := uuid.Parse(idStr)
+	if err != nil {
+		zap.L().Warn("invalid task ID for timer", zap.String("task_id", idStr), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	model, err := h.useCase.Stop(c, taskID, userID.(uuid.UUID))
+	if errors.Is(err, usecases.ErrNoRunningTimer) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		zap.L().Error("failed to stop timer", zap.String("task_id", idStr), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	zap.L().Info("timer stopped", zap.String("task_id", idStr), zap.String("time_entry_id", model.Entry.ID.String()),
+		zap.Duration("duration", model.Entry.Duration()), zap.Any("user_id", userID))
+	c.JSON(http.StatusOK, timeentry.FromModelTimeEntry(model))
+}
+
+// GetTimer godoc
+// @Summary Получить запущенный таймер
+// @Description Возвращает запущенный таймер текущего пользователя
+// @Tags time
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} timeentry.TimeEntryResponse
+// @Failure 404 {object} map[string]string
+// @Router /timer [get]
+func (h *Handler) GetTimer(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	model, err := h.useCase.GetRunning(c, userID.(uuid.UUID))
+	if errors.Is(err, usecases.ErrNoRunningTimer) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		zap.L().Error("failed to get timer", zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, timeentry.FromModelTimeEntry(model))
+}
+
+// GetEntries godoc
+// @Summary Получить учёт времени по задаче
+// @Description Записи времени всех пользователей по задаче, начиная с последних
+// @Tags time
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID задачи"
+// @Success 200 {array} timeentry.TimeEntryResponse
+// @Failure 404 {object} map[string]string
+// @Router /tasks/{id}/time-entries [get]
+func (h *Handler) GetEntries(c *gin.Context) {
+	idStr := c.Param("id")
+	userID, _ := c.Get("user_id")
+	taskID, err := uuid.Parse(idStr)
+	if err != nil {
+		zap.L().Warn("invalid task ID for time entries", zap.String("task_id", idStr), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	entries, err := h.useCase.GetEntries(c, taskID, userID.(uuid.UUID))
+	if errors.Is(err, usecases.ErrTaskNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		zap.L().Error("failed to get time entries", zap.String("task_id", idStr), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	output := make([]*timeentry.TimeEntryResponse, 0, len(entries))
+	for _, model := range entries {
+		output = append(output, timeentry.FromModelTimeEntry(model))
+	}
+	c.JSON(http.StatusOK, output)
+}
+
+// CreateEntry godoc
+// @Summary Добавить время вручную
+// @Description Добавляет запись о затраченном времени с длительностью в минутах и заметкой. Без started_at запись заканчивается текущим моментом
+// @Tags time
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param Idempotency-Key header string false "Ключ идемпотентности для безопасных повторов"
+// @Param id path string true "ID задачи"
+// @Param request body timeentry.CreateTimeEntryRequest true "Запись времени"
+// @Success 201 {object} timeentry.TimeEntryResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /tasks/{id}/time-entries [post]
+func (h *Handler) CreateEntry(c *gin.Context) {
+	idStr := c.Param("id")
+	userID, _ := c.Get("user_id")
+	taskID, err := uuid.Parse(idStr)
+	if err != nil {
+		zap.L().Warn("invalid task ID for time entry", zap.String("task_id", idStr), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	var request timeentry.CreateTimeEntryRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		zap.L().Warn("invalid time entry request", zap.String("task_id", idStr), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	model, err := h.useCase.AddEntry(c, request.ToEntity(taskID, userID.(uuid.UUID)))
+	if errors.Is(err, usecases.ErrTaskNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, usecases.ErrEntryInFuture) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		zap.L().Error("failed to create time entry", zap.String("task_id", idStr), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	zap.L().Info("time entry created", zap.String("task_id", idStr), zap.String("time_entry_id", model.Entry.ID.String()), zap.Any("user_id", userID))
+	c.JSON(http.StatusCreated, timeentry.FromModelTimeEntry(model))
+}
+
+// DeleteEntry godoc
+// @Summary Удалить запись времени
+// @Description Удаляет свою запись времени; удаление запущенного таймера отменяет его
+// @Tags time
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID записи"
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /time-entries/{id} [delete]
+func (h *Handler) DeleteEntry(c *gin.Context) {
+	idStr := c.Param("id")
+	userID, _ := c.Get("user_id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		zap.L().Warn("invalid time entry ID", zap.String("time_entry_id", idStr), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	err = h.useCase.DeleteEntry(c, id, userID.(uuid.UUID))
+	if errors.Is(err, usecases.ErrTimeEntryNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		zap.L().Error("failed to delete time entry", zap.String("time_entry_id", idStr), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	zap.L().Info("time entry deleted", zap.String("time_entry_id", idStr), zap.Any("user_id", userID))
+	c.JSON(http.StatusOK, gin.H{"message": "Time entry deleted"})
+}
+
+// GetReport godoc
+// @Summary Отчёт по затраченному времени
+// @Description Суммирует завершённые записи времени по задачам, доступным текущему пользователю, за период в днях UTC (не больше 366 дней). Группировка по пользователю, своему тегу и дню; запись по задаче с несколькими тегами учитывается в каждом из них, но в итоге — один раз
+// @Tags time
+// @Accept json
+// @Produce json
+// @Produce text/csv
+// @Security BearerAuth
+// @Param from query string true "Первый день периода, YYYY-MM-DD"
+// @Param to query string true "Последний день периода включительно, YYYY-MM-DD"
+// @Param user_id query string false "Только записи этого пользователя"
+// @Param tag query string false "Только задачи со своим тегом с этим названием"
+// @Param group_by query []string false "user, tag, day; по умолчанию user" collectionFormat(multi)
+// @Param format query string false "json или csv"
+// @Success 200 {object} timeentry.TimeReportResponse
+// @Failure 400 {object} map[string]string
+// @Router /reports/time [get]
+func (h *Handler) GetReport(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	var query timeentry.TimeReportQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		zap.L().Warn("invalid time report query", zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	filter, err := query.ToFilter(userID.(uuid.UUID))
+	if err != nil {
+		zap.L().Warn("invalid time report query", zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	report, err := h.useCase.Report(c, filter)
+	if errors.Is(err, usecases.ErrInvalidTimeRange) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		zap.L().Error("failed to build time report", zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	zap.L().Info("time report get", zap.Int("rows", len(report.Rows)), zap.String("format", query.Format), zap.Any("user_id", userID))
+	if query.Format == "csv" {
+		var buf bytes.Buffer
+		if err := timeentry.WriteTimeReportCSV(&buf, report); err != nil {
+			zap.L().Error("failed to write time report", zap.Error(err), zap.Any("user_id", userID))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.Header("Content-Disposition", `attachment; filename="time-report-`+query.From+`-`+query.To+`.csv"`)
+		c.Data(http.StatusOK, "text/csv; charset=utf-8", buf.Bytes())
+		return
+	}
+	c.JSON(http.StatusOK, timeentry.FromModelTimeReport(report))
+}
@@ -0,0 +1,255 @@
+package timeentry_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"task-api/internal/adapters/api/timeentry"
+	"task-api/internal/adapters/models"
+	"task-api/internal/domain/entities"
+	handler "task-api/internal/infrastructure/api/http/timeentry"
+	"task-api/internal/usecases"
+	"task-api/internal/usecases/mocks"
+	"testing"
+	"time"
+)
+
+func newContext(method, path string, body any, userID uuid.UUID) (*gin.Context, *httptest.ResponseRecorder) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("user_id", userID)
+	var payload []byte
+	if body != nil {
+		payload, _ = json.Marshal(body)
+	}
+	c.Request, _ = http.NewRequest(method, path, bytes.NewReader(payload))
+	c.Request.Header.Set("Content-Type", "application/json")
+	return c, w
+}
+
+func TestHandler_StartTimer_WithoutBody(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockTimeEntryUseCase(ctrl)
+	h := handler.NewTimeEntryHandler(mockUseCase)
+
+	taskID := uuid.New()
+	userID := uuid.New()
+	startedAt := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	mockUseCase.EXPECT().Start(gomock.Any(), taskID, userID, "").Return(&models.TimeEntry{
+		Entry: entities.TimeEntry{ID: uuid.New(), TaskID: taskID, UserID: userID, StartedAt: startedAt},
+		User:  entities.User{ID: userID, Name: "Anna"},
+	}, nil)
+
+	c, w := newContext(http.MethodPost, "/api/v1/tasks/"+taskID.String()+"/timer/start", nil, userID)
+	c.Params = gin.Params{{Key: "id", Value: taskID.String()}}
+
+	h.StartTimer(c)
+
+	require.Equal(t, http.StatusCreated, w.Code)
+	var res timeentry.TimeEntryResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.True(t, res.Running)
+	assert.Equal(t, int64(0), res.DurationSeconds)
+	assert.Equal(t, "Anna", res.User.Name)
+}
+
+func TestHandler_StartTimer_AlreadyRunning(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockTimeEntryUseCase(ctrl)
+	h := handler.NewTimeEntryHandler(mockUseCase)
+
+	taskID := uuid.New()
+	userID := uuid.New()
+	mockUseCase.EXPECT().Start(gomock.Any(), taskID, userID, "review").Return(nil, usecases.ErrTimerRunning)
+
+	c, w := newContext(http.MethodPost, "/api/v1/tasks/"+taskID.String()+"/timer/start", timeentry.StartTimerRequest{Note: "review"}, userID)
+	c.Params = gin.Params{{Key: "id", Value: taskID.String()}}
+
+	h.StartTimer(c)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestHandler_StopTimer_NotRunning(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockTimeEntryUseCase(ctrl)
+	h := handler.NewTimeEntryHandler(mockUseCase)
+
+	taskID := uuid.New()
+	userID := uuid.New()
+	mockUseCase.EXPECT().Stop(gomock.Any(), taskID, userID).Return(nil, usecases.ErrNoRunningTimer)
+
+	c, w := newContext(http.MethodPost, "/api/v1/tasks/"+taskID.String()+"/timer/stop", nil, userID)
+	c.Params = gin.Params{{Key: "id", Value: taskID.String()}}
+
+	h.StopTimer(c)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestHandler_CreateEntry_Duration(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockTimeEntryUseCase(ctrl)
+	h := handler.NewTimeEntryHandler(mockUseCase)
+
+	taskID := uuid.New()
+	userID := uuid.New()
+	startedAt := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	mockUseCase.EXPECT().AddEntry(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, entry *entities.TimeEntry) (*models.TimeEntry, error) {
+			assert.Equal(t, taskID, entry.TaskID)
+			assert.Equal(t, userID, entry.UserID)
+			assert.Equal(t, startedAt, entry.StartedAt)
+			assert.Equal(t, 90*time.Minute, entry.Duration())
+			entry.ID = uuid.New()
+			return &models.TimeEntry{Entry: *entry}, nil
+		})
+
+	body := map[string]any{"started_at": "2026-10-19T12:00:00+03:00", "minutes": 90, "note": "call with client"}
+	c, w := newContext(http.MethodPost, "/api/v1/tasks/"+taskID.String()+"/time-entries", body, userID)
+	c.Params = gin.Params{{Key: "id", Value: taskID.String()}}
+
+	h.CreateEntry(c)
+
+	require.Equal(t, http.StatusCreated, w.Code)
+	var res timeentry.TimeEntryResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Equal(t, int64(5400), res.DurationSeconds)
+	assert.False(t, res.Running)
+	assert.Equal(t, "call with client", res.Note)
+}
+
+func TestHandler_CreateEntry_RequiresMinutes(t *testing.T) {
+	h := handler.NewTimeEntryHandler(nil)
+
+	taskID := uuid.New()
+	c, w := newContext(http.MethodPost, "/api/v1/tasks/"+taskID.String()+"/time-entries", map[string]any{"note": "x"}, uuid.New())
+	c.Params = gin.Params{{Key: "id", Value: taskID.String()}}
+
+	h.CreateEntry(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestHandler_DeleteEntry_NotOwn(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockTimeEntryUseCase(ctrl)
+	h := handler.NewTimeEntryHandler(mockUseCase)
+
+	id := uuid.New()
+	userID := uuid.New()
+	mockUseCase.EXPECT().DeleteEntry(gomock.Any(), id, userID).Return(usecases.ErrTimeEntryNotFound)
+
+	c, w := newContext(http.MethodDelete, "/api/v1/time-entries/"+id.String(), nil, userID)
+	c.Params = gin.Params{{Key: "id", Value: id.String()}}
+
+	h.DeleteEntry(c)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestHandler_GetReport_JSON(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockTimeEntryUseCase(ctrl)
+	h := handler.NewTimeEntryHandler(mockUseCase)
+
+	userID := uuid.New()
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	mockUseCase.EXPECT().Report(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, filter models.TimeReportFilter) (*models.TimeReport, error) {
+			assert.Equal(t, userID, filter.ViewerID)
+			assert.Equal(t, from, filter.From)
+			assert.Equal(t, to, filter.To)
+			assert.Equal(t, "acme", filter.Tag)
+			assert.Nil(t, filter.UserID)
+			assert.Equal(t, []string{"tag", "day"}, filter.GroupBy)
+			return &models.TimeReport{
+				Filter: filter,
+				Rows: []*models.TimeReportRow{
+					{Tag: "acme", Day: time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC), Seconds: 5400, Entries: 2},
+				},
+				TotalSeconds: 5400,
+				TotalEntries: 2,
+			}, nil
+		})
+
+	c, w := newContext(http.MethodGet, "/api/v1/reports/time?from=2026-10-01&to=2026-10-31&tag=acme&group_by=tag&group_by=day&group_by=tag", nil, userID)
+
+	h.GetReport(c)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"from":"2026-10-01","to":"2026-10-31","group_by":["tag","day"],
+		"rows":[{"tag":"acme","day":"2026-10-05","seconds":5400,"entries":2}],
+		"total_seconds":5400,"total_entries":2}`, w.Body.String())
+}
+
+func TestHandler_GetReport_CSV(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockTimeEntryUseCase(ctrl)
+	h := handler.NewTimeEntryHandler(mockUseCase)
+
+	userID := uuid.New()
+	mockUseCase.EXPECT().Report(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, filter models.TimeReportFilter) (*models.TimeReport, error) {
+			filter.GroupBy = []string{models.TimeGroupUser, models.TimeGroupTag}
+			return &models.TimeReport{
+				Filter: filter,
+				Rows: []*models.TimeReportRow{
+					{User: entities.User{ID: userID, Name: "Anna", Email: "anna@example.com"}, Tag: "=cmd", Seconds: 5400, Entries: 2},
+					{User: entities.User{ID: userID, Name: "Anna", Email: "anna@example.com"}, Seconds: 600, Entries: 1},
+				},
+			}, nil
+		})
+
+	c, w := newContext(http.MethodGet, "/api/v1/reports/time?from=2026-10-01&to=2026-10-31&format=csv", nil, userID)
+
+	h.GetReport(c)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Header().Get("Content-Disposition"), "time-report-2026-10-01-2026-10-31.csv")
+	assert.Equal(t, "user_id,user_name,user_email,tag,seconds,hours,entries\n"+
+		userID.String()+",Anna,anna@example.com,'=cmd,5400,1.50,2\n"+
+		userID.String()+",Anna,anna@example.com,,600,0.17,1\n", w.Body.String())
+}
+
+func TestHandler_GetReport_InvalidQuery(t *testing.T) {
+	h := handler.NewTimeEntryHandler(nil)
+
+	for _, query := range []string{
+		"to=2026-10-31",
+		"from=2026-10-01&to=31.10.2026",
+		"from=2026-10-01&to=2026-10-31&group_by=project",
+		"from=2026-10-01&to=2026-10-31&user_id=anna",
+		"from=2026-10-01&to=2026-10-31&format=xlsx",
+	} {
+		t.Run(query, func(t *testing.T) {
+			c, w := newContext(http.MethodGet, "/api/v1/reports/time?"+query, nil, uuid.New())
+			h.GetReport(c)
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+}
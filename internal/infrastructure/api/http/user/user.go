@@ -9,17 +9,18 @@ import (
 	"task-api/internal/infrastructure/api/middleware"
 	"task-api/internal/infrastructure/security"
 	"task-api/internal/usecases"
-	"task-api/pkg/config"
 )
 
-func Router(r *gin.Engine, handler *Handler, cfg config.AppConfig, blackListToken *security.TokenBlacklist) {
+func Router(r *gin.Engine, handler *Handler, authMiddleware gin.HandlerFunc) {
+	read := middleware.RequireScope(security.ScopeUsersRead)
+	write := middleware.RequireScope(security.ScopeUsersWrite)
 	userRouter := r.Group("api/v1/users")
-	userRouter.Use(middleware.AuthMiddleware(cfg, blackListToken))
+	userRouter.Use(authMiddleware)
 	{
-		userRouter.GET("/:id", handler.GetByID)
-		userRouter.GET("/email/:email", handler.GetByEmail)
-		userRouter.PUT("/:id", handler.Update)
-		userRouter.DELETE("/:id", handler.Delete)
+		userRouter.GET("/:id", read, handler.GetByID)
+		userRouter.GET("/email/:email", read, handler.GetByEmail)
+		userRouter.PUT("/:id", write, handler.Update)
+		userRouter.DELETE("/:id", write, handler.Delete)
 	}
 }
 
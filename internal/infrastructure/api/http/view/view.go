@@ -0,0 +1,362 @@
+package view
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"net/http"
+	"task-api/internal/adapters/api/task"
+	"task-api/internal/adapters/api/view"
+	"task-api/internal/infrastructure/api/middleware"
+	"task-api/internal/infrastructure/security"
+	"task-api/internal/usecases"
+	"task-api/pkg/taskquery"
+)
+
+func Router(router *gin.Engine, handler *Handler, authMiddleware gin.HandlerFunc) {
+	read := middleware.RequireScope(security.ScopeViewsRead)
+	write := middleware.RequireScope(security.ScopeViewsWrite)
+	viewRouter := router.Group("/api/v1/views")
+	viewRouter.Use(authMiddleware)
+	{
+		viewRouter.GET("", read, handler.GetViews)
+		viewRouter.POST("", write, handler.Create)
+		viewRouter.GET("/:id", read, handler.GetView)
+		viewRouter.PUT("/:id", write, handler.Update)
+		viewRouter.DELETE("/:id", write, handler.Delete)
+		viewRouter.GET("/:id/tasks", read, middleware.RequireScope(security.ScopeTasksRead), handler.GetTasks)
+		viewRouter.POST("/:id/shares", write, handler.Share)
+		viewRouter.DELETE("/:id/shares/:user_id", write, handler.Unshare)
+	}
+}
+
+type Handler struct {
+	useCase usecases.ViewUseCase
+}
+
+func NewViewHandler(useCase usecases.ViewUseCase) *Handler {
+	return &Handler{useCase: useCase}
+}
+
+// GetViews godoc
+// @Summary Получить сохранённые представления
+// @Description Возвращает свои представления и представления, которыми поделились с текущим пользователем, по названию
+// @Tags views
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} view.ViewResponse
+// @Router /views [get]
+func (h *Handler) GetViews(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	views, err := h.useCase.GetViews(c, userID.(uuid.UUID))
+	if err != nil {
+		zap.L().Error("failed get views", zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	output := make([]*view.ViewResponse, 0, len(views))
+	for _, model := range views {
+		output = append(output, view.FromModelView(model))
+	}
+	c.JSON(http.StatusOK, output)
+}
+
+// Create godoc
+// @Summary Создать представление
+// @Description Сохраняет фильтр списка задач под названием
+// @Tags views
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body view.CreateViewRequest true "Название и фильтр"
+// @Success 201 {object} view.ViewResponse
+// @Failure 400 {object} map[string]any
+// @Router /views [post]
+func (h *Handler) Create(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	var request view.CreateViewRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		zap.L().Warn("invalid view request", zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	model, err := h.useCase.Create(c, request.ToEntity(userID.(uuid.UUID)))
+	var syntaxErr *taskquery.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "position": syntaxErr.Pos})
+		return
+	}
+	if err != nil {
+		zap.L().Error("failed create view", zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	zap.L().Info("view created", zap.String("view_id", model.View.ID.String()), zap.Any("user_id", userID))
+	c.JSON(http.StatusCreated, view.FromModelView(model))
+}
+
+// GetView godoc
+// @Summary Получить представление
+// @Description Возвращает своё представление или представление, которым поделились; список пользователей с доступом видит только владелец
+// @Tags views
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID представления"
+// @Success 200 {object} view.ViewResponse
+// @Failure 404 {object} map[string]string
+// @Router /views/{id} [get]
+func (h *Handler) GetView(c *gin.Context) {
+	idStr := c.Param("id")
+	userID, _ := c.Get("user_id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		zap.L().Warn("invalid view id", zap.String("view_id", idStr), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	model, err := h.useCase.GetView(c, id, userID.(uuid.UUID))
+	if errors.Is(err, usecases.ErrViewNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		zap.L().Error("failed get view", zap.String("view_id", id.String()), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, view.FromModelView(model))
+}
+
+// Update godoc
+// @Summary Изменить представление
+// @Description Заменяет название и фильтр; изменять может только владелец
+// @Tags views
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID представления"
+// @Param request body view.UpdateViewRequest true "Название и фильтр"
+// @Success 200 {object} view.ViewResponse
+// @Failure 400 {object} map[string]any
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /views/{id} [put]
+func (h *Handler) Update(c *gin.Context) {
+	idStr := c.Param("id")
+	userID, _ := c.Get("user_id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		zap.L().Warn("invalid view id", zap.String("view_id", idStr), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	var request view.UpdateViewRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		zap.L().Warn("invalid view request", zap.String("view_id", id.String()), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	model, err := h.useCase.Update(c, request.ToEntity(id, userID.(uuid.UUID)))
+	var syntaxErr *taskquery.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "position": syntaxErr.Pos})
+		return
+	}
+	if errors.Is(err, usecases.ErrViewNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, usecases.ErrViewForbidden) {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		zap.L().Error("failed update view", zap.String("view_id", id.String()), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	zap.L().Info("view updated", zap.String("view_id", id.String()), zap.Any("user_id", userID))
+	c.JSON(http.StatusOK, view.FromModelView(model))
+}
+
+// Delete godoc
+// @Summary Удалить представление
+// @Description Удаляет представление вместе с доступами к нему; удалять может только владелец
+// @Tags views
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID представления"
+// @Success 200 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /views/{id} [delete]
+func (h *Handler) Delete(c *gin.Context) {
+	idStr := c.Param("id")
+	userID, _ := c.Get("user_id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		zap.L().Warn("invalid view id", zap.String("view_id", idStr), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	err = h.useCase.Delete(c, id, userID.(uuid.UUID))
+	if errors.Is(err, usecases.ErrViewNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, usecases.ErrViewForbidden) {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		zap.L().Error("failed delete view", zap.String("view_id", id.String()), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	zap.L().Info("view deleted", zap.String("view_id", id.String()), zap.Any("user_id", userID))
+	c.JSON(http.StatusOK, gin.H{"message": "View deleted"})
+}
+
+// GetTasks godoc
+// @Summary Задачи представления
+// @Description Применяет фильтр представления к задачам текущего пользователя; чужое представление не открывает доступ к задачам владельца
+// @Tags views
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID представления"
+// @Success 200 {array} task.TaskAllResponse
+// @Failure 404 {object} map[string]string
+// @Router /views/{id}/tasks [get]
+func (h *Handler) GetTasks(c *gin.Context) {
+	idStr := c.Param("id")
+	userID, _ := c.Get("user_id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		zap.L().Warn("invalid view id", zap.String("view_id", idStr), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	tasks, err := h.useCase.GetTasks(c, id, userID.(uuid.UUID))
+	if errors.Is(err, usecases.ErrViewNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		zap.L().Error("failed get view tasks", zap.String("view_id", id.String()), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	output := make([]*task.TaskAllResponse, 0, len(tasks))
+	for _, model := range tasks {
+		output = append(output, task.FromModelTaskForAll(model))
+	}
+	c.JSON(http.StatusOK, output)
+}
+
+// Share godoc
+// @Summary Поделиться представлением
+// @Description Открывает пользователю доступ к представлению на чтение; делиться может только владелец
+// @Tags views
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID представления"
+// @Param request body view.ShareViewRequest true "Пользователь"
+// @Success 200 {object} view.ViewResponse
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Router /views/{id}/shares [post]
+func (h *Handler) Share(c *gin.Context) {
+	idStr := c.Param("id")
+	userID, _ := c.Get("user_id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		zap.L().Warn("invalid view id", zap.String("view_id", idStr), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	var request view.ShareViewRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		zap.L().Warn("invalid share view request", zap.String("view_id", id.String()), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	model, err := h.useCase.Share(c, id, userID.(uuid.UUID), request.UserID)
+	if errors.Is(err, usecases.ErrViewNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, usecases.ErrViewForbidden) {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, usecases.ErrShareWithSelf) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, usecases.ErrUserNotFound) {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		zap.L().Error("failed share view", zap.String("view_id", id.String()), zap.String("shared_with", request.UserID.String()), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	zap.L().Info("view shared", zap.String("view_id", id.String()), zap.String("shared_with", request.UserID.String()), zap.Any("user_id", userID))
+	c.JSON(http.StatusOK, view.FromModelView(model))
+}
+
+// Unshare godoc
+// @Summary Закрыть доступ к представлению
+// @Description Владелец может закрыть доступ любому пользователю, остальные — только себе
+// @Tags views
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID представления"
+// @Param user_id path string true "ID пользователя"
+// @Success 200 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /views/{id}/shares/{user_id} [delete]
+func (h *Handler) Unshare(c *gin.Context) {
+	idStr := c.Param("id")
+	userID, _ := c.Get("user_id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		zap.L().Warn("invalid view id", zap.String("view_id", idStr), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	sharedStr := c.Param("user_id")
+	sharedWith, err := uuid.Parse(sharedStr)
+	if err != nil {
+		zap.L().Warn("invalid user id", zap.String("shared_with", sharedStr), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	err = h.useCase.Unshare(c, id, userID.(uuid.UUID), sharedWith)
+	if errors.Is(err, usecases.ErrViewNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, usecases.ErrViewForbidden) {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		zap.L().Error("failed unshare view", zap.String("view_id", id.String()), zap.String("shared_with", sharedWith.String()), zap.Error(err), zap.Any("user_id", userID))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	zap.L().Info("view unshared", zap.String("view_id", id.String()), zap.String("shared_with", sharedWith.String()), zap.Any("user_id", userID))
+	c.JSON(http.StatusOK, gin.H{"message": "View unshared"})
+}
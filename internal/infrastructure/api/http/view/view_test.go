@@ -0,0 +1,183 @@
+package view_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"task-api/internal/adapters/api/task"
+	"task-api/internal/adapters/api/view"
+	"task-api/internal/adapters/models"
+	"task-api/internal/domain/entities"
+	handler "task-api/internal/infrastructure/api/http/view"
+	"task-api/internal/usecases"
+	"task-api/internal/usecases/mocks"
+	"task-api/pkg/taskquery"
+	"testing"
+)
+
+func newContext(method, path string, body any, userID uuid.UUID) (*gin.Context, *httptest.ResponseRecorder) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("user_id", userID)
+	var payload []byte
+	if body != nil {
+		payload, _ = json.Marshal(body)
+	}
+	c.Request, _ = http.NewRequest(method, path, bytes.NewReader(payload))
+	c.Request.Header.Set("Content-Type", "application/json")
+	return c, w
+}
+
+func TestHandler_Create_StoresFilter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockViewUseCase(ctrl)
+	h := handler.NewViewHandler(mockUseCase)
+
+	userID := uuid.New()
+	viewID := uuid.New()
+	filter := entities.ViewFilter{Status: "new", Tags: []string{"backend"}}
+	mockUseCase.EXPECT().Create(gomock.Any(), gomock.AssignableToTypeOf(&entities.View{})).
+		DoAndReturn(func(_ context.Context, v *entities.View) (*models.View, error) {
+			assert.Equal(t, userID, v.OwnerID)
+			assert.Equal(t, "Open backend", v.Name)
+			assert.Equal(t, filter, v.Filter)
+			v.ID = viewID
+			return &models.View{View: *v}, nil
+		})
+
+	c, w := newContext(http.MethodPost, "/api/v1/views", view.CreateViewRequest{
+		Name:   "Open backend",
+		Filter: view.ViewFilterRequest{Status: "new", Tags: []string{"backend"}},
+	}, userID)
+
+	h.Create(c)
+
+	require.Equal(t, http.StatusCreated, w.Code)
+	var res view.ViewResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Equal(t, viewID, res.ID)
+	assert.Equal(t, []string{"backend"}, res.Filter.Tags)
+}
+
+func TestHandler_Create_RequiresName(t *testing.T) {
+	h := handler.NewViewHandler(nil)
+
+	c, w := newContext(http.MethodPost, "/api/v1/views", view.CreateViewRequest{}, uuid.New())
+
+	h.Create(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestHandler_Update_SharedViewForbidden(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockViewUseCase(ctrl)
+	h := handler.NewViewHandler(mockUseCase)
+
+	userID := uuid.New()
+	viewID := uuid.New()
+	mockUseCase.EXPECT().Update(gomock.Any(), gomock.AssignableToTypeOf(&entities.View{})).Return(nil, usecases.ErrViewForbidden)
+
+	c, w := newContext(http.MethodPut, "/api/v1/views/"+viewID.String(), view.UpdateViewRequest{Name: "Mine now"}, userID)
+	c.Params = gin.Params{{Key: "id", Value: viewID.String()}}
+
+	h.Update(c)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestHandler_GetTasks_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockViewUseCase(ctrl)
+	h := handler.NewViewHandler(mockUseCase)
+
+	userID := uuid.New()
+	viewID := uuid.New()
+	mockUseCase.EXPECT().GetTasks(gomock.Any(), viewID, userID).Return(nil, usecases.ErrViewNotFound)
+
+	c, w := newContext(http.MethodGet, "/api/v1/views/"+viewID.String()+"/tasks", nil, userID)
+	c.Params = gin.Params{{Key: "id", Value: viewID.String()}}
+
+	h.GetTasks(c)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestHandler_GetTasks_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockViewUseCase(ctrl)
+	h := handler.NewViewHandler(mockUseCase)
+
+	userID := uuid.New()
+	viewID := uuid.New()
+	taskID := uuid.New()
+	mockUseCase.EXPECT().GetTasks(gomock.Any(), viewID, userID).
+		Return([]*models.TasksWishTags{{Task: entities.Task{ID: taskID, Title: "Fix login"}}}, nil)
+
+	c, w := newContext(http.MethodGet, "/api/v1/views/"+viewID.String()+"/tasks", nil, userID)
+	c.Params = gin.Params{{Key: "id", Value: viewID.String()}}
+
+	h.GetTasks(c)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var res []task.TaskAllResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	require.Len(t, res, 1)
+	assert.Equal(t, taskID, res[0].ID)
+}
+
+func TestHandler_Share_UnknownUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockViewUseCase(ctrl)
+	h := handler.NewViewHandler(mockUseCase)
+
+	userID := uuid.New()
+	viewID := uuid.New()
+	otherID := uuid.New()
+	mockUseCase.EXPECT().Share(gomock.Any(), viewID, userID, otherID).Return(nil, usecases.ErrUserNotFound)
+
+	c, w := newContext(http.MethodPost, "/api/v1/views/"+viewID.String()+"/shares", view.ShareViewRequest{UserID: otherID}, userID)
+	c.Params = gin.Params{{Key: "id", Value: viewID.String()}}
+
+	h.Share(c)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+}
+
+func TestHandler_Create_InvalidQuery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockViewUseCase(ctrl)
+	h := handler.NewViewHandler(mockUseCase)
+
+	mockUseCase.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, &taskquery.SyntaxError{Pos: 5, Msg: "missing value for tag"})
+
+	c, w := newContext(http.MethodPost, "/api/v1/views", view.CreateViewRequest{
+		Name:   "Broken",
+		Filter: view.ViewFilterRequest{Query: "tag:"},
+	}, uuid.New())
+
+	h.Create(c)
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	assert.JSONEq(t, `{"error": "syntax error at position 5: missing value for tag", "position": 5}`, w.Body.String())
+}
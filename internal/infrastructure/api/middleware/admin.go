@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"task-api/internal/domain/entities"
+	"task-api/internal/usecases"
+)
+
+// RequireAdmin lets through only users with the admin role. The role is read
+// from the database on every request, so revoking it takes effect immediately.
+func RequireAdmin(users usecases.UserUseCase) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		value, _ := ctx.Get("user_id")
+		userID, ok := value.(uuid.UUID)
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+			return
+		}
+		user, err := users.GetById(ctx, userID)
+		if err != nil || user.Role != entities.RoleAdmin {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Admin role required"})
+			return
+		}
+		ctx.Next()
+	}
+}
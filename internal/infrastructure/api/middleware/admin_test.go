@@ -0,0 +1,64 @@
+package middleware_test
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"task-api/internal/domain/entities"
+	"task-api/internal/infrastructure/api/middleware"
+	"task-api/internal/usecases/mocks"
+	"testing"
+)
+
+func newAdminRouter(users *mocks.MockUserUseCase, userID any) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/admin", func(c *gin.Context) {
+		if userID != nil {
+			c.Set("user_id", userID)
+		}
+	}, middleware.RequireAdmin(users), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	return r
+}
+
+func TestRequireAdmin(t *testing.T) {
+	userID := uuid.New()
+	tests := []struct {
+		name   string
+		user   *entities.User
+		err    error
+		status int
+	}{
+		{name: "admin", user: &entities.User{ID: userID, Role: entities.RoleAdmin}, status: http.StatusOK},
+		{name: "regular user", user: &entities.User{ID: userID, Role: entities.RoleUser}, status: http.StatusForbidden},
+		{name: "lookup error", err: errors.New("no rows"), status: http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			users := mocks.NewMockUserUseCase(ctrl)
+			users.EXPECT().GetById(gomock.Any(), userID).Return(tt.user, tt.err)
+
+			w := httptest.NewRecorder()
+			newAdminRouter(users, userID).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin", nil))
+			assert.Equal(t, tt.status, w.Code)
+		})
+	}
+}
+
+func TestRequireAdmin_NoUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	w := httptest.NewRecorder()
+	newAdminRouter(mocks.NewMockUserUseCase(ctrl), nil).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin", nil))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
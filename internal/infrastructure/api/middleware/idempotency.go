@@ -0,0 +1,128 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+	"task-api/internal/infrastructure/idempotency"
+	"time"
+)
+
+const (
+	IdempotencyKeyHeader      = "Idempotency-Key"
+	idempotentReplayedHeader  = "Idempotent-Replayed"
+	maxIdempotencyKeyLength   = 255
+	maxIdempotentRequestBytes = 1 << 20
+)
+
+type capturingWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *capturingWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *capturingWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// IdempotencyMiddleware stores the first response for an Idempotency-Key and
+// replays it for retries. It must run after AuthMiddleware: keys are scoped
+// per user so that clients cannot observe each other's responses.
+func IdempotencyMiddleware(store idempotency.Store, ttl time.Duration) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		key := ctx.GetHeader(IdempotencyKeyHeader)
+		if key == "" {
+			ctx.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Idempotency-Key is too long"})
+			return
+		}
+
+		body, err := io.ReadAll(io.LimitReader(ctx.Request.Body, maxIdempotentRequestBytes+1))
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if len(body) > maxIdempotentRequestBytes {
+			ctx.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Request body is too large"})
+			return
+		}
+		ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		scope := "ip:" + ctx.ClientIP()
+		if userID, exists := ctx.Get("user_id"); exists {
+			scope = fmt.Sprintf("user:%v", userID)
+		}
+		scope += ":" + ctx.Request.Method + " " + ctx.FullPath()
+		requestHash := hashRequest(ctx.Request.Method, ctx.Request.URL.Path, body)
+
+		existing, reserved, err := store.Reserve(ctx, scope, key, requestHash, ttl)
+		if err != nil {
+			zap.L().Error("failed reserve idempotency key", zap.Error(err), zap.String("idempotency_key", key))
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Internal Server Error"})
+			return
+		}
+		if !reserved {
+			switch {
+			case existing.RequestHash != requestHash:
+				ctx.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{"error": "Idempotency-Key was already used with a different request"})
+			case !existing.Completed():
+				ctx.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": "A request with this Idempotency-Key is still in progress"})
+			default:
+				zap.L().Info("idempotent replay", zap.String("idempotency_key", key), zap.String("scope", scope))
+				ctx.Header(idempotentReplayedHeader, "true")
+				ctx.Data(existing.StatusCode, existing.ContentType, existing.Body)
+				ctx.Abort()
+			}
+			return
+		}
+
+		// The key is released unless a response was stored, including when the
+		// handler panics, so that the client can retry with the same key.
+		stored := false
+		defer func() {
+			if stored {
+				return
+			}
+			if err := store.Release(ctx, scope, key); err != nil {
+				zap.L().Error("failed release idempotency key", zap.Error(err), zap.String("idempotency_key", key))
+			}
+		}()
+
+		writer := &capturingWriter{ResponseWriter: ctx.Writer}
+		ctx.Writer = writer
+		ctx.Next()
+
+		// Server errors are not cached so that the client can retry with the same key.
+		if writer.Status() >= http.StatusInternalServerError {
+			return
+		}
+		if err := store.Complete(ctx, scope, key, writer.Status(), writer.Header().Get("Content-Type"), writer.body.Bytes()); err != nil {
+			zap.L().Error("failed store idempotent response", zap.Error(err), zap.String("idempotency_key", key))
+			return
+		}
+		stored = true
+	}
+}
+
+func hashRequest(method, path string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(method))
+	h.Write([]byte{0})
+	h.Write([]byte(path))
+	h.Write([]byte{0})
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,115 @@
+package middleware_test
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"task-api/internal/infrastructure/api/middleware"
+	"task-api/internal/infrastructure/idempotency"
+	"testing"
+	"time"
+)
+
+type memoryStore struct {
+	mu      sync.Mutex
+	records map[string]*idempotency.Record
+}
+
+func (m *memoryStore) Reserve(_ context.Context, scope, key, requestHash string, ttl time.Duration) (*idempotency.Record, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if r, ok := m.records[scope+key]; ok && time.Now().Before(r.ExpiresAt) {
+		return r, false, nil
+	}
+	m.records[scope+key] = &idempotency.Record{Scope: scope, Key: key, RequestHash: requestHash, ExpiresAt: time.Now().Add(ttl)}
+	return nil, true, nil
+}
+
+func (m *memoryStore) Complete(_ context.Context, scope, key string, statusCode int, contentType string, body []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	r := m.records[scope+key]
+	r.StatusCode, r.ContentType, r.Body = statusCode, contentType, body
+	return nil
+}
+
+func (m *memoryStore) Release(_ context.Context, scope, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.records, scope+key)
+	return nil
+}
+
+func TestIdempotencyMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := &memoryStore{records: make(map[string]*idempotency.Record)}
+	created := 0
+	r := gin.New()
+	r.POST("/api/v1/tasks", func(c *gin.Context) { c.Set("user_id", "u1") }, middleware.IdempotencyMiddleware(store, time.Hour), func(c *gin.Context) {
+		created++
+		c.JSON(http.StatusCreated, gin.H{"n": created})
+	})
+
+	do := func(key, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/tasks", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if key != "" {
+			req.Header.Set(middleware.IdempotencyKeyHeader, key)
+		}
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	first := do("key-1", `{"title":"a"}`)
+	assert.Equal(t, http.StatusCreated, first.Code)
+
+	replay := do("key-1", `{"title":"a"}`)
+	assert.Equal(t, http.StatusCreated, replay.Code)
+	assert.Equal(t, first.Body.String(), replay.Body.String())
+	assert.Equal(t, "true", replay.Header().Get("Idempotent-Replayed"))
+	assert.Equal(t, 1, created)
+
+	mismatch := do("key-1", `{"title":"b"}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, mismatch.Code)
+
+	do("", `{"title":"a"}`)
+	do("key-2", `{"title":"a"}`)
+	assert.Equal(t, 3, created)
+}
+
+func TestIdempotencyMiddleware_ReleasesKeyOnPanic(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := &memoryStore{records: make(map[string]*idempotency.Record)}
+	calls := 0
+	r := gin.New()
+	r.Use(gin.CustomRecovery(func(c *gin.Context, _ any) {
+		c.AbortWithStatus(http.StatusInternalServerError)
+	}))
+	r.POST("/api/v1/tasks", func(c *gin.Context) { c.Set("user_id", "u1") }, middleware.IdempotencyMiddleware(store, time.Hour), func(c *gin.Context) {
+		calls++
+		if calls == 1 {
+			panic("boom")
+		}
+		c.JSON(http.StatusCreated, gin.H{"n": calls})
+	})
+
+	do := func() *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/tasks", strings.NewReader(`{"title":"a"}`))
+		req.Header.Set(middleware.IdempotencyKeyHeader, "key-1")
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	assert.Equal(t, http.StatusInternalServerError, do().Code)
+	assert.Empty(t, store.records)
+
+	retry := do()
+	assert.Equal(t, http.StatusCreated, retry.Code)
+	assert.Equal(t, 2, calls)
+}
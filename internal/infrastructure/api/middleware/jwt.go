@@ -4,10 +4,16 @@ import (
 	"github.com/gin-gonic/gin"
 	"net/http"
 	"task-api/internal/infrastructure/security"
+	"task-api/internal/usecases"
 	"task-api/pkg/config"
 )
 
-func AuthMiddleware(cfg config.AppConfig, blackListToken *security.TokenBlacklist) gin.HandlerFunc {
+const (
+	AuthTypeJWT   = "jwt"
+	AuthTypeToken = "token"
+)
+
+func AuthMiddleware(cfg config.AppConfig, blackListToken *security.TokenBlacklist, accessTokens usecases.AccessTokenUseCase) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		auth := ctx.GetHeader("Authorization")
 		if auth == "" {
@@ -19,6 +25,18 @@ func AuthMiddleware(cfg config.AppConfig, blackListToken *security.TokenBlacklis
 			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
 			return
 		}
+		if security.IsAccessToken(tokenStr) {
+			token, err := accessTokens.Authenticate(ctx, tokenStr)
+			if err != nil {
+				ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+				return
+			}
+			ctx.Set("user_id", token.UserID)
+			ctx.Set("auth_type", AuthTypeToken)
+			ctx.Set("scopes", token.Scopes)
+			ctx.Next()
+			return
+		}
 		if blackListToken.IsBlacklisted(tokenStr) {
 			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Token revoked"})
 			return
@@ -29,6 +47,36 @@ func AuthMiddleware(cfg config.AppConfig, blackListToken *security.TokenBlacklis
 			return
 		}
 		ctx.Set("user_id", claims.UserID)
+		ctx.Set("auth_type", AuthTypeJWT)
+		ctx.Next()
+	}
+}
+
+// RequireScope restricts personal access tokens to routes covered by their scopes.
+// Interactive JWT sessions are not scoped and always pass.
+func RequireScope(scope string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if ctx.GetString("auth_type") != AuthTypeToken {
+			ctx.Next()
+			return
+		}
+		for _, s := range ctx.GetStringSlice("scopes") {
+			if s == scope {
+				ctx.Next()
+				return
+			}
+		}
+		ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Insufficient scope", "required_scope": scope})
+	}
+}
+
+// RequireSession rejects personal access tokens, e.g. for token management itself.
+func RequireSession() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if ctx.GetString("auth_type") != AuthTypeJWT {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Interactive session required"})
+			return
+		}
 		ctx.Next()
 	}
 }
@@ -0,0 +1,106 @@
+package middleware_test
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"task-api/internal/domain/entities"
+	"task-api/internal/infrastructure/api/middleware"
+	"task-api/internal/infrastructure/security"
+	"task-api/internal/usecases/mocks"
+	"task-api/pkg/config"
+	"testing"
+	"time"
+)
+
+func newRouter(tokens *mocks.MockAccessTokenUseCase, cfg config.AppConfig) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	auth := middleware.AuthMiddleware(cfg, security.NewTokenBlacklist(), tokens)
+	r.GET("/tasks", auth, middleware.RequireScope(security.ScopeTasksRead), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"user_id": c.MustGet("user_id")})
+	})
+	r.POST("/tasks", auth, middleware.RequireScope(security.ScopeTasksWrite), func(c *gin.Context) {
+		c.Status(http.StatusCreated)
+	})
+	r.GET("/tokens", auth, middleware.RequireSession(), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	return r
+}
+
+func TestAuthMiddleware_AccessTokenScopes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tokens := mocks.NewMockAccessTokenUseCase(ctrl)
+	r := newRouter(tokens, config.AppConfig{})
+
+	userID := uuid.New()
+	raw := security.AccessTokenPrefix + "readonly"
+	tokens.EXPECT().Authenticate(gomock.Any(), raw).Return(&entities.AccessToken{
+		UserID: userID,
+		Scopes: []string{security.ScopeTasksRead},
+	}, nil).Times(3)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/tasks", nil)
+	req.Header.Set("Authorization", "Bearer "+raw)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), userID.String())
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodPost, "/tasks", nil)
+	req.Header.Set("Authorization", "Bearer "+raw)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), security.ScopeTasksWrite)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/tokens", nil)
+	req.Header.Set("Authorization", "Bearer "+raw)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestAuthMiddleware_InvalidAccessToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tokens := mocks.NewMockAccessTokenUseCase(ctrl)
+	r := newRouter(tokens, config.AppConfig{})
+
+	raw := security.AccessTokenPrefix + "revoked"
+	tokens.EXPECT().Authenticate(gomock.Any(), raw).Return(nil, errors.New("invalid access token"))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/tasks", nil)
+	req.Header.Set("Authorization", "Bearer "+raw)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestAuthMiddleware_JWTIsNotScoped(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := config.AppConfig{Auth: config.Auth{JWTSecret: "secret", JWTAlgorithm: "HS256", JWTExpiry: time.Minute}}
+	tokens := mocks.NewMockAccessTokenUseCase(ctrl)
+	r := newRouter(tokens, cfg)
+
+	signed, err := security.CreateAccessJWT(cfg, uuid.New())
+	assert.NoError(t, err)
+
+	for _, path := range []string{"/tasks", "/tokens"} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Authorization", "Bearer "+signed)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code, path)
+	}
+}
@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"task-api/internal/infrastructure/ratelimit"
+	"task-api/internal/infrastructure/security"
+	"task-api/pkg/config"
+	"time"
+)
+
+// RateLimitPolicy applies Limit to every route under Prefix. Policies are
+// matched in order, so more specific prefixes must come first.
+type RateLimitPolicy struct {
+	Name   string
+	Prefix string
+	Limit  ratelimit.Limit
+}
+
+func RateLimitMiddleware(cfg config.AppConfig, limiter ratelimit.Limiter, policies []RateLimitPolicy) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		policy, ok := matchPolicy(ctx.Request.URL.Path, policies)
+		if !ok {
+			ctx.Next()
+			return
+		}
+		key := policy.Name + ":" + rateLimitIdentity(cfg, ctx)
+		res, err := limiter.Allow(ctx, key, policy.Limit)
+		if err != nil {
+			// Fail open: an unavailable backend must not take the API down.
+			zap.L().Warn("rate limiter unavailable", zap.Error(err), zap.String("policy", policy.Name))
+			ctx.Next()
+			return
+		}
+
+		ctx.Header("RateLimit-Limit", strconv.Itoa(res.Limit))
+		ctx.Header("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+		ctx.Header("RateLimit-Reset", strconv.Itoa(ceilSeconds(res.ResetAfter)))
+		if !res.Allowed {
+			ctx.Header("Retry-After", strconv.Itoa(ceilSeconds(res.RetryAfter)))
+			zap.L().Warn("rate limit exceeded", zap.String("policy", policy.Name), zap.String("key", key))
+			ctx.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "Too many requests"})
+			return
+		}
+		ctx.Next()
+	}
+}
+
+func matchPolicy(path string, policies []RateLimitPolicy) (RateLimitPolicy, bool) {
+	for _, p := range policies {
+		if strings.HasPrefix(path, p.Prefix) {
+			return p, true
+		}
+	}
+	return RateLimitPolicy{}, false
+}
+
+// rateLimitIdentity runs before AuthMiddleware, so the caller is resolved from
+// the Authorization header directly: user for JWTs, token hash for personal
+// access tokens and client IP otherwise.
+func rateLimitIdentity(cfg config.AppConfig, ctx *gin.Context) string {
+	if tokenStr, err := security.TokenString(ctx.GetHeader("Authorization")); err == nil {
+		if security.IsAccessToken(tokenStr) {
+			return "token:" + security.HashAccessToken(tokenStr)
+		}
+		if claims, err := security.ParseAccessJWT(cfg, tokenStr); err == nil && claims != nil {
+			return "user:" + claims.UserID.String()
+		}
+	}
+	return "ip:" + ctx.ClientIP()
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
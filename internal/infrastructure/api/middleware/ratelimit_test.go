@@ -0,0 +1,45 @@
+package middleware_test
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"task-api/internal/infrastructure/api/middleware"
+	"task-api/internal/infrastructure/ratelimit"
+	"task-api/pkg/config"
+	"testing"
+)
+
+func TestRateLimitMiddleware_PerGroupLimits(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(middleware.RateLimitMiddleware(config.AppConfig{}, ratelimit.NewMemoryLimiter(), []middleware.RateLimitPolicy{
+		{Name: "auth", Prefix: "/api/v1/auth", Limit: ratelimit.Limit{Rate: 0.001, Burst: 1}},
+		{Name: "api", Prefix: "/api/v1", Limit: ratelimit.Limit{Rate: 0.001, Burst: 2}},
+	}))
+	r.POST("/api/v1/auth/login", func(c *gin.Context) { c.Status(http.StatusOK) })
+	r.GET("/api/v1/tasks", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	do := func(method, path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, path, nil)
+		req.RemoteAddr = "10.0.0.1:1234"
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := do(http.MethodPost, "/api/v1/auth/login")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "1", w.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "0", w.Header().Get("RateLimit-Remaining"))
+
+	w = do(http.MethodPost, "/api/v1/auth/login")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.NotEmpty(t, w.Header().Get("Retry-After"))
+
+	w = do(http.MethodGet, "/api/v1/tasks")
+	assert.Equal(t, http.StatusOK, w.Code, "auth group exhaustion must not affect other groups")
+	assert.Equal(t, "2", w.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "1", w.Header().Get("RateLimit-Remaining"))
+}
@@ -0,0 +1,46 @@
+package eventbus
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"sync"
+	"task-api/internal/domain/events"
+)
+
+// MemoryBus delivers events synchronously to in-process subscribers. A
+// panicking handler is logged and does not affect the publisher or the other
+// handlers.
+type MemoryBus struct {
+	mu       sync.RWMutex
+	handlers map[string][]events.Handler
+}
+
+var _ events.Publisher = new(MemoryBus)
+
+func NewMemoryBus() *MemoryBus {
+	return &MemoryBus{handlers: make(map[string][]events.Handler)}
+}
+
+func (b *MemoryBus) Subscribe(name string, handler events.Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[name] = append(b.handlers[name], handler)
+}
+
+func (b *MemoryBus) Publish(ctx context.Context, event events.Event) {
+	b.mu.RLock()
+	handlers := b.handlers[event.EventName()]
+	b.mu.RUnlock()
+	for _, handler := range handlers {
+		b.dispatch(ctx, handler, event)
+	}
+}
+
+func (b *MemoryBus) dispatch(ctx context.Context, handler events.Handler, event events.Event) {
+	defer func() {
+		if r := recover(); r != nil {
+			zap.L().Error("event handler panicked", zap.String("event", event.EventName()), zap.Any("panic", r))
+		}
+	}()
+	handler(ctx, event)
+}
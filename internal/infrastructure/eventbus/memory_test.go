@@ -0,0 +1,41 @@
+package eventbus_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"task-api/internal/domain/events"
+	"task-api/internal/infrastructure/eventbus"
+	"testing"
+)
+
+type otherEvent struct{}
+
+func (otherEvent) EventName() string { return "other" }
+
+func TestMemoryBus_DeliversBySubscription(t *testing.T) {
+	bus := eventbus.NewMemoryBus()
+	var got []events.Event
+	bus.Subscribe(events.CommentMentionedEvent, func(ctx context.Context, event events.Event) {
+		got = append(got, event)
+	})
+
+	bus.Publish(context.Background(), events.CommentMentioned{})
+	bus.Publish(context.Background(), otherEvent{})
+
+	assert.Len(t, got, 1)
+	assert.IsType(t, events.CommentMentioned{}, got[0])
+}
+
+func TestMemoryBus_HandlerPanicIsContained(t *testing.T) {
+	bus := eventbus.NewMemoryBus()
+	called := false
+	bus.Subscribe("other", func(ctx context.Context, event events.Event) {
+		panic("boom")
+	})
+	bus.Subscribe("other", func(ctx context.Context, event events.Event) {
+		called = true
+	})
+
+	assert.NotPanics(t, func() { bus.Publish(context.Background(), otherEvent{}) })
+	assert.True(t, called)
+}
@@ -0,0 +1,89 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"sync"
+	"time"
+)
+
+const cleanupInterval = 10 * time.Minute
+
+type PostgresStore struct {
+	pool        *pgxpool.Pool
+	mu          sync.Mutex
+	lastCleanup time.Time
+}
+
+var _ Store = new(PostgresStore)
+
+func NewPostgresStore(pool *pgxpool.Pool) *PostgresStore {
+	return &PostgresStore{pool: pool, lastCleanup: time.Now()}
+}
+
+func (p *PostgresStore) Reserve(ctx context.Context, scope, key, requestHash string, ttl time.Duration) (*Record, bool, error) {
+	if err := p.maybeCleanup(ctx); err != nil {
+		return nil, false, err
+	}
+	now := time.Now()
+	sql := `INSERT INTO service.idempotency_keys (scope, key, request_hash, created_at, expires_at)
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (scope, key) DO UPDATE
+			SET request_hash = EXCLUDED.request_hash, status_code = NULL, content_type = NULL, response_body = NULL,
+				created_at = EXCLUDED.created_at, expires_at = EXCLUDED.expires_at
+			WHERE service.idempotency_keys.expires_at < EXCLUDED.created_at
+			RETURNING scope`
+	var inserted string
+	err := p.pool.QueryRow(ctx, sql, scope, key, requestHash, now, now.Add(ttl)).Scan(&inserted)
+	if err == nil {
+		return nil, true, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, false, err
+	}
+
+	sql = `SELECT scope, key, request_hash, COALESCE(status_code, 0), COALESCE(content_type, ''), response_body, created_at, expires_at
+			FROM service.idempotency_keys WHERE scope = $1 AND key = $2`
+	record := &Record{}
+	if err := p.pool.QueryRow(ctx, sql, scope, key).Scan(
+		&record.Scope,
+		&record.Key,
+		&record.RequestHash,
+		&record.StatusCode,
+		&record.ContentType,
+		&record.Body,
+		&record.CreatedAt,
+		&record.ExpiresAt,
+	); err != nil {
+		return nil, false, err
+	}
+	return record, false, nil
+}
+
+func (p *PostgresStore) Complete(ctx context.Context, scope, key string, statusCode int, contentType string, body []byte) error {
+	sql := `UPDATE service.idempotency_keys SET status_code = $1, content_type = $2, response_body = $3 WHERE scope = $4 AND key = $5`
+	_, err := p.pool.Exec(ctx, sql, statusCode, contentType, body, scope, key)
+	return err
+}
+
+func (p *PostgresStore) Release(ctx context.Context, scope, key string) error {
+	sql := `DELETE FROM service.idempotency_keys WHERE scope = $1 AND key = $2 AND status_code IS NULL`
+	_, err := p.pool.Exec(ctx, sql, scope, key)
+	return err
+}
+
+func (p *PostgresStore) maybeCleanup(ctx context.Context) error {
+	p.mu.Lock()
+	if time.Since(p.lastCleanup) < cleanupInterval {
+		p.mu.Unlock()
+		return nil
+	}
+	p.lastCleanup = time.Now()
+	p.mu.Unlock()
+
+	sql := `DELETE FROM service.idempotency_keys WHERE expires_at < $1`
+	_, err := p.pool.Exec(ctx, sql, time.Now())
+	return err
+}
@@ -0,0 +1,31 @@
+package idempotency
+
+import (
+	"context"
+	"time"
+)
+
+// Record is a stored outcome of a request made with an Idempotency-Key.
+// A record without StatusCode is still being processed.
+type Record struct {
+	Scope       string
+	Key         string
+	RequestHash string
+	StatusCode  int
+	ContentType string
+	Body        []byte
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+}
+
+func (r *Record) Completed() bool {
+	return r.StatusCode != 0
+}
+
+type Store interface {
+	// Reserve claims the key for a new request. When the key is already taken
+	// and not expired, the existing record is returned with reserved=false.
+	Reserve(ctx context.Context, scope, key, requestHash string, ttl time.Duration) (existing *Record, reserved bool, err error)
+	Complete(ctx context.Context, scope, key string, statusCode int, contentType string, body []byte) error
+	Release(ctx context.Context, scope, key string) error
+}
@@ -0,0 +1,68 @@
+package mailer
+
+import (
+	"bytes"
+	"embed"
+	htmltemplate "html/template"
+	"task-api/internal/adapters/models"
+	"task-api/internal/domain/entities"
+	texttemplate "text/template"
+	"time"
+)
+
+//go:embed templates/digest.txt templates/digest.html
+var templates embed.FS
+
+// The functions are replaced on every render with ones bound to the recipient's time zone.
+var placeholderFuncs = map[string]any{
+	"due":  func(*entities.Task) string { return "" },
+	"date": func(time.Time) string { return "" },
+	"time": func(time.Time) string { return "" },
+}
+
+var (
+	digestText = texttemplate.Must(texttemplate.New("digest.txt").Funcs(placeholderFuncs).ParseFS(templates, "templates/digest.txt"))
+	digestHTML = htmltemplate.Must(htmltemplate.New("digest.html").Funcs(placeholderFuncs).ParseFS(templates, "templates/digest.html"))
+)
+
+// NewDigestMessage renders the digest for its user. Times are shown in the
+// digest location.
+func NewDigestMessage(d *models.Digest) (*Message, error) {
+	loc := d.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	funcs := map[string]any{
+		"due": func(t *entities.Task) string {
+			if t.DueAt == nil {
+				return ""
+			}
+			return t.DueAt.In(loc).Format("02.01.2006 15:04")
+		},
+		"date": func(t time.Time) string { return t.In(loc).Format("02.01.2006") },
+		"time": func(t time.Time) string { return t.In(loc).Format("02.01 15:04") },
+	}
+
+	textTmpl, err := digestText.Clone()
+	if err != nil {
+		return nil, err
+	}
+	var text bytes.Buffer
+	if err := textTmpl.Funcs(funcs).Execute(&text, d); err != nil {
+		return nil, err
+	}
+	htmlTmpl, err := digestHTML.Clone()
+	if err != nil {
+		return nil, err
+	}
+	var html bytes.Buffer
+	if err := htmlTmpl.Funcs(funcs).Execute(&html, d); err != nil {
+		return nil, err
+	}
+	return &Message{
+		To:      d.User.Email,
+		Subject: "Сводка по задачам на " + d.Until.In(loc).Format("02.01.2006"),
+		Text:    text.String(),
+		HTML:    html.String(),
+	}, nil
+}
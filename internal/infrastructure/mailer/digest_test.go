@@ -0,0 +1,43 @@
+package mailer_test
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"task-api/internal/adapters/models"
+	"task-api/internal/domain/entities"
+	"task-api/internal/infrastructure/mailer"
+	"testing"
+	"time"
+)
+
+func TestNewDigestMessage(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Moscow")
+	require.NoError(t, err)
+	due := time.Date(2026, 10, 19, 21, 30, 0, 0, time.UTC)
+	digest := &models.Digest{
+		User:     entities.User{Name: "Анна", Email: "anna@example.com"},
+		Location: loc,
+		Until:    time.Date(2026, 10, 19, 22, 0, 0, 0, time.UTC),
+		Overdue:  []*entities.Task{{ID: uuid.New(), Title: "Отчёт", DueAt: &due}},
+		Comments: []*models.DigestComment{{
+			TaskTitle:  "Релиз",
+			AuthorName: "Борис",
+			Content:    "<script>alert(1)</script>",
+			CreatedAt:  due,
+		}},
+	}
+
+	msg, err := mailer.NewDigestMessage(digest)
+	require.NoError(t, err)
+
+	assert.Equal(t, "anna@example.com", msg.To)
+	assert.Equal(t, "Сводка по задачам на 20.10.2026", msg.Subject)
+	assert.Contains(t, msg.Text, "Просроченные задачи:\n- Отчёт (срок: 20.10.2026 00:30)")
+	assert.Contains(t, msg.Text, "<script>alert(1)</script>")
+	assert.NotContains(t, msg.Text, "Скоро срок")
+	assert.NotContains(t, msg.Text, "Вам назначены")
+	assert.Contains(t, msg.HTML, "&lt;script&gt;alert(1)&lt;/script&gt;")
+	assert.NotContains(t, msg.HTML, "<script>")
+	assert.NotContains(t, msg.HTML, "Скоро срок")
+}
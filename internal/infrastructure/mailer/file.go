@@ -0,0 +1,47 @@
+package mailer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// FileMailer drops every message as an .eml file into a directory instead of
+// sending it. It is meant for development and for inspecting the digests.
+type FileMailer struct {
+	dir  string
+	from string
+}
+
+var _ Mailer = new(FileMailer)
+
+func NewFileMailer(dir, from string) (*FileMailer, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, err
+	}
+	return &FileMailer{dir: dir, from: from}, nil
+}
+
+// Send writes to a temporary file first so that a reader of the directory never sees a partial message.
+func (m *FileMailer) Send(_ context.Context, msg *Message) error {
+	now := time.Now()
+	data, err := encode(m.from, msg, now)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(m.dir, ".mail-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	name := now.UTC().Format("20060102T150405.000000000") + "-" + filepath.Base(tmp.Name())[len(".mail-"):] + ".eml"
+	return os.Rename(tmp.Name(), filepath.Join(m.dir, name))
+}
@@ -0,0 +1,159 @@
+package mailer_test
+
+import (
+	"bufio"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"task-api/internal/infrastructure/mailer"
+	"testing"
+)
+
+var testMessage = &mailer.Message{
+	To:      "user@example.com",
+	Subject: "Сводка по задачам",
+	Text:    "Привет!\nЗадачи ниже.",
+	HTML:    "<p>Привет!</p>",
+}
+
+// readParts decodes a multipart/alternative message into its bodies keyed by media type.
+func readParts(t *testing.T, raw io.Reader) (*mail.Message, map[string]string) {
+	t.Helper()
+	msg, err := mail.ReadMessage(raw)
+	require.NoError(t, err)
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	require.Equal(t, "multipart/alternative", mediaType)
+
+	bodies := map[string]string{}
+	reader := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		part, err := reader.NextRawPart()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		assert.Equal(t, "quoted-printable", part.Header.Get("Content-Transfer-Encoding"))
+		body, err := io.ReadAll(quotedprintable.NewReader(part))
+		require.NoError(t, err)
+		partType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		bodies[partType] = string(body)
+	}
+	return msg, bodies
+}
+
+func TestFileMailer_Send(t *testing.T) {
+	dir := t.TempDir()
+	m, err := mailer.NewFileMailer(dir, "Task API <noreply@example.com>")
+	require.NoError(t, err)
+
+	require.NoError(t, m.Send(context.Background(), testMessage))
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	f, err := os.Open(files[0])
+	require.NoError(t, err)
+	defer f.Close()
+
+	msg, bodies := readParts(t, f)
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	require.NoError(t, err)
+	assert.Equal(t, testMessage.Subject, subject)
+	assert.Equal(t, "user@example.com", msg.Header.Get("To"))
+	assert.Contains(t, msg.Header.Get("Message-ID"), "@example.com>")
+	assert.Equal(t, "Привет!\r\nЗадачи ниже.", bodies["text/plain"])
+	assert.Equal(t, testMessage.HTML, bodies["text/html"])
+}
+
+func TestFileMailer_RejectsHeaderInjection(t *testing.T) {
+	m, err := mailer.NewFileMailer(t.TempDir(), "noreply@example.com")
+	require.NoError(t, err)
+
+	err = m.Send(context.Background(), &mailer.Message{To: "user@example.com\r\nBcc: evil@example.com", Text: "x"})
+	assert.Error(t, err)
+}
+
+// fakeSMTP accepts one message without STARTTLS or authentication and
+// records the envelope and the data.
+func fakeSMTP(t *testing.T) (addr string, received chan []string) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+	received = make(chan []string, 1)
+
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		reply := func(s string) { _, _ = conn.Write([]byte(s + "\r\n")) }
+		var lines []string
+		reply("220 localhost ESMTP")
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			line = strings.TrimRight(line, "\r\n")
+			switch {
+			case strings.HasPrefix(line, "EHLO"):
+				reply("250-localhost")
+				reply("250 8BITMIME")
+			case strings.HasPrefix(line, "MAIL"), strings.HasPrefix(line, "RCPT"):
+				lines = append(lines, line)
+				reply("250 OK")
+			case line == "DATA":
+				reply("354 go ahead")
+				var data strings.Builder
+				for {
+					l, err := r.ReadString('\n')
+					if err != nil {
+						return
+					}
+					if l == ".\r\n" {
+						break
+					}
+					data.WriteString(l)
+				}
+				lines = append(lines, data.String())
+				reply("250 queued")
+			case line == "QUIT":
+				reply("221 bye")
+				received <- lines
+				return
+			default:
+				reply("502 not implemented")
+			}
+		}
+	}()
+	return ln.Addr().String(), received
+}
+
+func TestSMTPMailer_Send(t *testing.T) {
+	addr, received := fakeSMTP(t)
+	host, port, err := net.SplitHostPort(addr)
+	require.NoError(t, err)
+
+	m := mailer.NewSMTPMailer(mailer.SMTPConfig{Host: host, Port: port, From: "Task API <noreply@example.com>"})
+	require.NoError(t, m.Send(context.Background(), testMessage))
+
+	lines := <-received
+	require.Len(t, lines, 3)
+	assert.Equal(t, "MAIL FROM:<noreply@example.com> BODY=8BITMIME", lines[0])
+	assert.Equal(t, "RCPT TO:<user@example.com>", lines[1])
+	_, bodies := readParts(t, strings.NewReader(lines[2]))
+	assert.Equal(t, testMessage.HTML, bodies["text/html"])
+}
@@ -0,0 +1,46 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	HTTPRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "Total number of HTTP requests by route and status.",
+	}, []string{"method", "route", "status"})
+	HTTPRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "HTTP request latency in seconds by route and status.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	TasksCreated = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "tasks_created_total",
+		Help: "Total number of created tasks.",
+	})
+	CommentsCreated = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "comments_created_total",
+		Help: "Total number of created comments.",
+	})
+	UsersRegistered = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "users_registered_total",
+		Help: "Total number of registered users by registration method.",
+	}, []string{"method"})
+	LoginsFailed = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "auth_logins_failed_total",
+		Help: "Total number of failed login attempts by login method.",
+	}, []string{"method"})
+)
+
+// Collectors returns the application metrics that are not bound to a resource.
+func Collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		HTTPRequestsTotal,
+		HTTPRequestDuration,
+		TasksCreated,
+		CommentsCreated,
+		UsersRegistered,
+		LoginsFailed,
+	}
+}
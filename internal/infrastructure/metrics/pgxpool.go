@@ -0,0 +1,28 @@
+package metrics
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// PoolCollectors exposes pgxpool statistics. Stat is cheap, so each value reads a fresh snapshot.
+func PoolCollectors(pool *pgxpool.Pool) []prometheus.Collector {
+	return []prometheus.Collector{
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{Name: "db_pool_acquired_conns", Help: "Number of connections currently acquired from the pool."},
+			func() float64 { return float64(pool.Stat().AcquiredConns()) }),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{Name: "db_pool_idle_conns", Help: "Number of idle connections in the pool."},
+			func() float64 { return float64(pool.Stat().IdleConns()) }),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{Name: "db_pool_total_conns", Help: "Total number of connections in the pool."},
+			func() float64 { return float64(pool.Stat().TotalConns()) }),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{Name: "db_pool_max_conns", Help: "Maximum size of the pool."},
+			func() float64 { return float64(pool.Stat().MaxConns()) }),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{Name: "db_pool_acquires_total", Help: "Cumulative count of successful acquires from the pool."},
+			func() float64 { return float64(pool.Stat().AcquireCount()) }),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{Name: "db_pool_empty_acquires_total", Help: "Cumulative count of acquires that had to wait for a connection."},
+			func() float64 { return float64(pool.Stat().EmptyAcquireCount()) }),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{Name: "db_pool_canceled_acquires_total", Help: "Cumulative count of acquires canceled by a context."},
+			func() float64 { return float64(pool.Stat().CanceledAcquireCount()) }),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{Name: "db_pool_acquire_wait_seconds_total", Help: "Cumulative time spent waiting for a connection."},
+			func() float64 { return pool.Stat().AcquireDuration().Seconds() }),
+	}
+}
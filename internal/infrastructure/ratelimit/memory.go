@@ -0,0 +1,60 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const memoryCleanupInterval = time.Minute
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+type MemoryLimiter struct {
+	mu          sync.Mutex
+	buckets     map[string]*bucket
+	lastCleanup time.Time
+}
+
+var _ Limiter = new(MemoryLimiter)
+
+func NewMemoryLimiter() *MemoryLimiter {
+	return &MemoryLimiter{
+		buckets:     make(map[string]*bucket),
+		lastCleanup: time.Now(),
+	}
+}
+
+func (m *MemoryLimiter) Allow(_ context.Context, key string, limit Limit) (Result, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(m.lastCleanup) > memoryCleanupInterval {
+		m.cleanup(now)
+	}
+
+	b, exists := m.buckets[key]
+	if !exists {
+		b = &bucket{tokens: float64(limit.Burst), updated: now}
+		m.buckets[key] = b
+	}
+	tokens := refill(b.tokens, now.Sub(b.updated), limit)
+	tokens, res := take(tokens, limit)
+	b.tokens = tokens
+	b.updated = now
+	return res, nil
+}
+
+// cleanup drops buckets idle long enough to be full again; they carry no state.
+func (m *MemoryLimiter) cleanup(now time.Time) {
+	for key, b := range m.buckets {
+		if now.Sub(b.updated) > memoryCleanupInterval*10 {
+			delete(m.buckets, key)
+		}
+	}
+	m.lastCleanup = now
+}
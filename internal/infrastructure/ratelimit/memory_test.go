@@ -0,0 +1,48 @@
+package ratelimit_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"task-api/internal/infrastructure/ratelimit"
+	"testing"
+	"time"
+)
+
+func TestMemoryLimiter_BurstThenDeny(t *testing.T) {
+	limiter := ratelimit.NewMemoryLimiter()
+	limit := ratelimit.Limit{Rate: 0.001, Burst: 3}
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		res, err := limiter.Allow(ctx, "user:1", limit)
+		require.NoError(t, err)
+		assert.True(t, res.Allowed)
+		assert.Equal(t, 2-i, res.Remaining)
+		assert.Equal(t, 3, res.Limit)
+	}
+
+	res, err := limiter.Allow(ctx, "user:1", limit)
+	require.NoError(t, err)
+	assert.False(t, res.Allowed)
+	assert.Greater(t, res.RetryAfter, time.Duration(0))
+
+	res, err = limiter.Allow(ctx, "user:2", limit)
+	require.NoError(t, err)
+	assert.True(t, res.Allowed, "buckets are independent per key")
+}
+
+func TestMemoryLimiter_Refill(t *testing.T) {
+	limiter := ratelimit.NewMemoryLimiter()
+	limit := ratelimit.Limit{Rate: 100, Burst: 1}
+	ctx := context.Background()
+
+	res, _ := limiter.Allow(ctx, "ip:127.0.0.1", limit)
+	assert.True(t, res.Allowed)
+	res, _ = limiter.Allow(ctx, "ip:127.0.0.1", limit)
+	assert.False(t, res.Allowed)
+
+	time.Sleep(20 * time.Millisecond)
+	res, _ = limiter.Allow(ctx, "ip:127.0.0.1", limit)
+	assert.True(t, res.Allowed)
+}
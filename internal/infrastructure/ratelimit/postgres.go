@@ -0,0 +1,73 @@
+package ratelimit
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"sync"
+	"time"
+)
+
+const postgresCleanupInterval = 10 * time.Minute
+
+// PostgresLimiter keeps buckets in a shared table so that all replicas enforce
+// the same limits. Time is taken from the database to avoid clock skew.
+type PostgresLimiter struct {
+	pool        *pgxpool.Pool
+	mu          sync.Mutex
+	lastCleanup time.Time
+}
+
+var _ Limiter = new(PostgresLimiter)
+
+func NewPostgresLimiter(pool *pgxpool.Pool) *PostgresLimiter {
+	return &PostgresLimiter{pool: pool, lastCleanup: time.Now()}
+}
+
+func (p *PostgresLimiter) Allow(ctx context.Context, key string, limit Limit) (Result, error) {
+	if err := p.maybeCleanup(ctx); err != nil {
+		return Result{}, err
+	}
+
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		return Result{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	insert := `INSERT INTO service.rate_limits (key, tokens, updated_at) VALUES ($1, $2, now()) ON CONFLICT (key) DO NOTHING`
+	if _, err := tx.Exec(ctx, insert, key, float64(limit.Burst)); err != nil {
+		return Result{}, err
+	}
+
+	var tokens float64
+	var updatedAt, now time.Time
+	sel := `SELECT tokens, updated_at, now()::timestamp FROM service.rate_limits WHERE key = $1 FOR UPDATE`
+	if err := tx.QueryRow(ctx, sel, key).Scan(&tokens, &updatedAt, &now); err != nil {
+		return Result{}, err
+	}
+	tokens = refill(tokens, now.Sub(updatedAt), limit)
+	tokens, res := take(tokens, limit)
+
+	update := `UPDATE service.rate_limits SET tokens = $1, updated_at = $2 WHERE key = $3`
+	if _, err := tx.Exec(ctx, update, tokens, now, key); err != nil {
+		return Result{}, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return Result{}, err
+	}
+	return res, nil
+}
+
+func (p *PostgresLimiter) maybeCleanup(ctx context.Context) error {
+	p.mu.Lock()
+	if time.Since(p.lastCleanup) < postgresCleanupInterval {
+		p.mu.Unlock()
+		return nil
+	}
+	p.lastCleanup = time.Now()
+	p.mu.Unlock()
+
+	sql := `DELETE FROM service.rate_limits WHERE updated_at < now() - interval '1 hour'`
+	_, err := p.pool.Exec(ctx, sql)
+	return err
+}
@@ -0,0 +1,95 @@
+package postgres
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"task-api/internal/domain/entities"
+	"task-api/internal/domain/repositories"
+	"time"
+)
+
+type AccessTokenRepository struct {
+	pool *pgxpool.Pool
+}
+
+var _ repositories.AccessTokenRepository = new(AccessTokenRepository)
+
+func NewAccessTokenRepository(pool *pgxpool.Pool) *AccessTokenRepository {
+	return &AccessTokenRepository{pool: pool}
+}
+
+func (a *AccessTokenRepository) Create(ctx context.Context, token *entities.AccessToken) error {
+	sql := `INSERT INTO users.access_tokens (user_id, name, token_hash, prefix, scopes, expires_at, created_at) 
+			VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`
+	return a.pool.QueryRow(ctx, sql, token.UserID, token.Name, token.TokenHash, token.Prefix, token.Scopes, token.ExpiresAt, token.CreatedAt).Scan(&token.ID)
+}
+
+func (a *AccessTokenRepository) GetByHash(ctx context.Context, hash string) (*entities.AccessToken, error) {
+	sql := `SELECT id, user_id, name, token_hash, prefix, scopes, expires_at, last_used_at, revoked_at, created_at 
+			FROM users.access_tokens WHERE token_hash = $1`
+	row := a.pool.QueryRow(ctx, sql, hash)
+	token := &entities.AccessToken{}
+	if err := row.Scan(
+		&token.ID,
+		&token.UserID,
+		&token.Name,
+		&token.TokenHash,
+		&token.Prefix,
+		&token.Scopes,
+		&token.ExpiresAt,
+		&token.LastUsedAt,
+		&token.RevokedAt,
+		&token.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+func (a *AccessTokenRepository) GetAllByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.AccessToken, error) {
+	sql := `SELECT id, user_id, name, token_hash, prefix, scopes, expires_at, last_used_at, revoked_at, created_at 
+			FROM users.access_tokens WHERE user_id = $1
+			ORDER BY created_at DESC`
+	rows, err := a.pool.Query(ctx, sql, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tokens []*entities.AccessToken
+	for rows.Next() {
+		token := &entities.AccessToken{}
+		if err := rows.Scan(
+			&token.ID,
+			&token.UserID,
+			&token.Name,
+			&token.TokenHash,
+			&token.Prefix,
+			&token.Scopes,
+			&token.ExpiresAt,
+			&token.LastUsedAt,
+			&token.RevokedAt,
+			&token.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, token)
+	}
+	return tokens, nil
+}
+
+func (a *AccessTokenRepository) Revoke(ctx context.Context, id, userID uuid.UUID, revokedAt time.Time) (bool, error) {
+	sql := `UPDATE users.access_tokens SET revoked_at = $1 WHERE id = $2 AND user_id = $3 AND revoked_at IS NULL`
+	tag, err := a.pool.Exec(ctx, sql, revokedAt, id, userID)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (a *AccessTokenRepository) UpdateLastUsed(ctx context.Context, id uuid.UUID, lastUsedAt time.Time) error {
+	sql := `UPDATE users.access_tokens SET last_used_at = $1 WHERE id = $2`
+	_, err := a.pool.Exec(ctx, sql, lastUsedAt, id)
+	return err
+}
@@ -0,0 +1,107 @@
+package postgres
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"task-api/internal/domain/entities"
+	"task-api/internal/domain/repositories"
+	"time"
+)
+
+type AttachmentRepository struct {
+	pool *pgxpool.Pool
+}
+
+var _ repositories.AttachmentRepository = new(AttachmentRepository)
+
+func NewAttachmentRepository(pool *pgxpool.Pool) *AttachmentRepository {
+	return &AttachmentRepository{pool: pool}
+}
+
+// Create inserts only when the task is not in the trash and the optional comment
+// belongs to it; otherwise no row is returned and pgx.ErrNoRows is reported.
+func (r *AttachmentRepository) Create(ctx context.Context, attachment *entities.Attachment) error {
+	sql := `INSERT INTO tasks.attachments (id, task_id, comment_id, uploaded_by, file_name, content_type, size, storage_key, created_at)
+			SELECT $1, $2, $3, $4, $5, $6, $7, $8, $9
+			WHERE EXISTS (SELECT 1 FROM tasks.tasks WHERE id = $2 AND deleted_at IS NULL)
+			AND ($3::uuid IS NULL OR EXISTS (SELECT 1 FROM tasks.comments WHERE id = $3 AND task_id = $2 AND deleted_at IS NULL))
+			RETURNING id`
+	return r.pool.QueryRow(ctx, sql,
+		attachment.ID,
+		attachment.TaskID,
+		attachment.CommentID,
+		attachment.UploadedBy,
+		attachment.FileName,
+		attachment.ContentType,
+		attachment.Size,
+		attachment.StorageKey,
+		attachment.CreatedAt,
+	).Scan(&attachment.ID)
+}
+
+func (r *AttachmentRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Attachment, error) {
+	sql := `SELECT a.id, a.task_id, a.comment_id, a.uploaded_by, a.file_name, a.content_type, a.size, a.storage_key, a.created_at
+			FROM tasks.attachments a
+			JOIN tasks.tasks t ON t.id = a.task_id
+			WHERE a.id = $1 AND t.deleted_at IS NULL`
+	return scanAttachment(r.pool.QueryRow(ctx, sql, id))
+}
+
+func (r *AttachmentRepository) GetAllByTaskID(ctx context.Context, taskID uuid.UUID) ([]*entities.Attachment, error) {
+	sql := `SELECT id, task_id, comment_id, uploaded_by, file_name, content_type, size, storage_key, created_at
+			FROM tasks.attachments WHERE task_id = $1
+			ORDER BY created_at`
+	return r.query(ctx, sql, taskID)
+}
+
+func (r *AttachmentRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	sql := `DELETE FROM tasks.attachments WHERE id = $1`
+	_, err := r.pool.Exec(ctx, sql, id)
+	return err
+}
+
+// GetAllForDeletedTasks returns attachments of tasks that the trash purge is about to remove.
+func (r *AttachmentRepository) GetAllForDeletedTasks(ctx context.Context, before time.Time) ([]*entities.Attachment, error) {
+	sql := `SELECT a.id, a.task_id, a.comment_id, a.uploaded_by, a.file_name, a.content_type, a.size, a.storage_key, a.created_at
+			FROM tasks.attachments a
+			JOIN tasks.tasks t ON t.id = a.task_id
+			WHERE t.deleted_at < $1`
+	return r.query(ctx, sql, before)
+}
+
+func (r *AttachmentRepository) query(ctx context.Context, sql string, args ...any) ([]*entities.Attachment, error) {
+	rows, err := r.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var attachments []*entities.Attachment
+	for rows.Next() {
+		attachment, err := scanAttachment(rows)
+		if err != nil {
+			return nil, err
+		}
+		attachments = append(attachments, attachment)
+	}
+	return attachments, rows.Err()
+}
+
+func scanAttachment(row pgx.Row) (*entities.Attachment, error) {
+	attachment := &entities.Attachment{}
+	if err := row.Scan(
+		&attachment.ID,
+		&attachment.TaskID,
+		&attachment.CommentID,
+		&attachment.UploadedBy,
+		&attachment.FileName,
+		&attachment.ContentType,
+		&attachment.Size,
+		&attachment.StorageKey,
+		&attachment.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return attachment, nil
+}
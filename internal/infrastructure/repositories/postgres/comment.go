@@ -3,12 +3,16 @@ package postgres
 import (
 	"context"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"task-api/internal/adapters/models"
 	"task-api/internal/domain/entities"
 	"task-api/internal/domain/repositories"
+	"time"
 )
 
+const commentColumns = `c.id, c.task_id, c.parent_id, c.author_id, c.content, c.created_at, c.updated_at, c.edited_at, u.id, u.name, u.email`
+
 type CommentRepository struct {
 	pool *pgxpool.Pool
 }
@@ -20,54 +24,258 @@ func NewCommentRepository(pool *pgxpool.Pool) *CommentRepository {
 }
 
 func (c *CommentRepository) GetAll(ctx context.Context) ([]*models.CommentWish, error) {
-	sql := `SELECT c.id, c.task_id, c.author_id, c.content, c.created_at, c.updated_at, u.id, u.name, u.email 
+	sql := `SELECT ` + commentColumns + `
+			FROM tasks.comments c
+			JOIN users.users u ON u.id = c.author_id
+			WHERE c.deleted_at IS NULL`
+	return queryComments(ctx, c.pool, sql)
+}
+
+func (c *CommentRepository) Create(ctx context.Context, comment *entities.Comment) error {
+	// Comments cannot be added to a task in the trash, and a reply must point to a live
+	// comment of the same task; no row means pgx.ErrNoRows.
+	sql := `INSERT INTO tasks.comments (task_id, parent_id, author_id, content, created_at, updated_at)
+			SELECT $1, $2, $3, $4, $5, $6
+			WHERE EXISTS (SELECT 1 FROM tasks.tasks WHERE id = $1 AND deleted_at IS NULL)
+			  AND ($2::uuid IS NULL OR EXISTS (
+				SELECT 1 FROM tasks.comments WHERE id = $2 AND task_id = $1 AND deleted_at IS NULL))
+			RETURNING id`
+	return c.pool.QueryRow(ctx, sql, comment.TaskID, comment.ParentID, comment.Author, comment.Content, comment.CreatedAt, comment.UpdatedAt).Scan(&comment.ID)
+}
+
+func (c *CommentRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.CommentWish, error) {
+	sql := `SELECT ` + commentColumns + `
+			FROM tasks.comments c
+			JOIN users.users u ON u.id = c.author_id
+			WHERE c.id = $1 AND c.deleted_at IS NULL`
+	return scanComment(c.pool.QueryRow(ctx, sql, id))
+}
+
+// GetRootsByTaskID returns one page of top-level comments of the task, oldest
+// first, together with the total number of top-level comments.
+func (c *CommentRepository) GetRootsByTaskID(ctx context.Context, taskID uuid.UUID, limit, offset int) ([]*models.CommentWish, int, error) {
+	var total int
+	sql := `SELECT count(*) FROM tasks.comments WHERE task_id = $1 AND parent_id IS NULL AND deleted_at IS NULL`
+	if err := c.pool.QueryRow(ctx, sql, taskID).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+	sql = `SELECT ` + commentColumns + `
 			FROM tasks.comments c
-			JOIN users.users u ON u.id = c.author_id`
-	rows, err := c.pool.Query(ctx, sql)
+			JOIN users.users u ON u.id = c.author_id
+			WHERE c.task_id = $1 AND c.parent_id IS NULL AND c.deleted_at IS NULL
+			ORDER BY c.created_at, c.id
+			LIMIT $2 OFFSET $3`
+	comments, err := queryComments(ctx, c.pool, sql, taskID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	return comments, total, nil
+}
+
+// GetReplies returns every live descendant of the given comments. A deleted
+// reply hides its own subtree.
+func (c *CommentRepository) GetReplies(ctx context.Context, rootIDs []uuid.UUID) ([]*models.CommentWish, error) {
+	sql := `WITH RECURSIVE thread AS (
+				SELECT id FROM tasks.comments WHERE parent_id = ANY($1) AND deleted_at IS NULL
+				UNION ALL
+				SELECT r.id FROM tasks.comments r JOIN thread t ON r.parent_id = t.id WHERE r.deleted_at IS NULL
+			)
+			SELECT ` + commentColumns + `
+			FROM thread
+			JOIN tasks.comments c ON c.id = thread.id
+			JOIN users.users u ON u.id = c.author_id
+			ORDER BY c.created_at, c.id`
+	return queryComments(ctx, c.pool, sql, rootIDs)
+}
+
+// Update replaces the content and keeps the previous one as a revision. Saving
+// the same content again is a no-op. A missing comment yields pgx.ErrNoRows.
+func (c *CommentRepository) Update(ctx context.Context, comment *entities.Comment, editedBy uuid.UUID) error {
+	tx, err := c.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var previous string
+	sql := `SELECT content FROM tasks.comments WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`
+	if err := tx.QueryRow(ctx, sql, comment.ID).Scan(&previous); err != nil {
+		return err
+	}
+	if previous == comment.Content {
+		return nil
+	}
+
+	sql = `INSERT INTO tasks.comment_revisions (comment_id, content, edited_by, created_at) VALUES ($1, $2, $3, $4)`
+	if _, err := tx.Exec(ctx, sql, comment.ID, previous, editedBy, comment.UpdatedAt); err != nil {
+		return err
+	}
+	sql = `UPDATE tasks.comments SET content = $1, updated_at = $2, edited_at = $2 WHERE id = $3`
+	if _, err := tx.Exec(ctx, sql, comment.Content, comment.UpdatedAt, comment.ID); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (c *CommentRepository) GetRevisions(ctx context.Context, commentID uuid.UUID) ([]*entities.CommentRevision, error) {
+	sql := `SELECT id, comment_id, content, edited_by, created_at
+			FROM tasks.comment_revisions WHERE comment_id = $1
+			ORDER BY created_at DESC`
+	rows, err := c.pool.Query(ctx, sql, commentID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var comments []*models.CommentWish
+	var revisions []*entities.CommentRevision
 	for rows.Next() {
-		row := &models.CommentWish{}
+		revision := &entities.CommentRevision{}
 		if err := rows.Scan(
-			&row.Comment.ID,
-			&row.Comment.TaskID,
-			&row.Comment.Author,
-			&row.Comment.Content,
-			&row.Comment.CreatedAt,
-			&row.Comment.UpdatedAt,
-			&row.Author.ID,
-			&row.Author.Name,
-			&row.Author.Email,
+			&revision.ID,
+			&revision.CommentID,
+			&revision.Content,
+			&revision.EditedBy,
+			&revision.CreatedAt,
 		); err != nil {
 			return nil, err
 		}
-		comments = append(comments, row)
+		revisions = append(revisions, revision)
 	}
-	return comments, nil
+	return revisions, rows.Err()
 }
 
-func (c *CommentRepository) Create(ctx context.Context, comment *entities.Comment) error {
-	sql := `INSERT INTO tasks.comments (task_id, author_id, content, created_at, updated_at) VALUES ($1, $2, $3, $4, $5) RETURNING id`
-	return c.pool.QueryRow(ctx, sql, comment.TaskID, comment.Author, comment.Content, comment.CreatedAt, comment.UpdatedAt).Scan(&comment.ID)
+// SetMentions makes userIDs the mentioned users of the comment and returns the
+// ones that were not mentioned before.
+func (c *CommentRepository) SetMentions(ctx context.Context, commentID uuid.UUID, userIDs []uuid.UUID) ([]uuid.UUID, error) {
+	if userIDs == nil {
+		// A nil slice is sent as NULL, which would match nothing in the DELETE below.
+		userIDs = []uuid.UUID{}
+	}
+	tx, err := c.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	sql := `DELETE FROM tasks.comment_mentions WHERE comment_id = $1 AND NOT (user_id = ANY($2))`
+	if _, err := tx.Exec(ctx, sql, commentID, userIDs); err != nil {
+		return nil, err
+	}
+	sql = `INSERT INTO tasks.comment_mentions (comment_id, user_id)
+			SELECT $1, unnest($2::uuid[])
+			ON CONFLICT DO NOTHING
+			RETURNING user_id`
+	rows, err := tx.Query(ctx, sql, commentID, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	added, err := pgx.CollectRows(rows, pgx.RowTo[uuid.UUID])
+	if err != nil {
+		return nil, err
+	}
+	return added, tx.Commit(ctx)
 }
 
-func (c *CommentRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.CommentWish, error) {
-	sql := `SELECT c.id, c.task_id, c.author_id, c.content, c.created_at, c.updated_at, u.id, u.name, u.email
-			FROM tasks.comments c
-			JOIN users.users u ON u.id = c.author_id
-			WHERE c.id = $1`
-	row := c.pool.QueryRow(ctx, sql, id)
+// AddReaction returns false when the user has already reacted with this emoji.
+func (c *CommentRepository) AddReaction(ctx context.Context, reaction *entities.CommentReaction) (bool, error) {
+	sql := `INSERT INTO tasks.comment_reactions (comment_id, user_id, emoji, created_at)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT DO NOTHING`
+	tag, err := c.pool.Exec(ctx, sql, reaction.CommentID, reaction.UserID, reaction.Emoji, reaction.CreatedAt)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (c *CommentRepository) RemoveReaction(ctx context.Context, commentID, userID uuid.UUID, emoji string) (bool, error) {
+	sql := `DELETE FROM tasks.comment_reactions WHERE comment_id = $1 AND user_id = $2 AND emoji = $3`
+	tag, err := c.pool.Exec(ctx, sql, commentID, userID, emoji)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// GetReactions aggregates reactions of the given comments per emoji, ordered by
+// the first time each emoji was used on a comment.
+func (c *CommentRepository) GetReactions(ctx context.Context, commentIDs []uuid.UUID, viewerID uuid.UUID) ([]*models.ReactionSummary, error) {
+	sql := `SELECT comment_id, emoji, count(*), bool_or(user_id = $2)
+			FROM tasks.comment_reactions
+			WHERE comment_id = ANY($1)
+			GROUP BY comment_id, emoji
+			ORDER BY comment_id, min(created_at), emoji`
+	rows, err := c.pool.Query(ctx, sql, commentIDs, viewerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var reactions []*models.ReactionSummary
+	for rows.Next() {
+		reaction := &models.ReactionSummary{}
+		if err := rows.Scan(
+			&reaction.CommentID,
+			&reaction.Emoji,
+			&reaction.Count,
+			&reaction.ReactedByMe,
+		); err != nil {
+			return nil, err
+		}
+		reactions = append(reactions, reaction)
+	}
+	return reactions, rows.Err()
+}
+
+func (c *CommentRepository) Delete(ctx context.Context, id uuid.UUID, deletedAt time.Time) error {
+	sql := `UPDATE tasks.comments SET deleted_at = $2 WHERE id = $1 AND deleted_at IS NULL`
+	_, err := c.pool.Exec(ctx, sql, id, deletedAt)
+	return err
+}
+
+// PurgeDeleted removes comments deleted before the given time. Comments with a
+// live reply anywhere below them are kept, since removing them would cascade
+// to the reply; they are purged once the replies are gone.
+func (c *CommentRepository) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	sql := `WITH RECURSIVE kept AS (
+				SELECT parent_id AS id FROM tasks.comments WHERE deleted_at IS NULL AND parent_id IS NOT NULL
+				UNION
+				SELECT c.parent_id FROM tasks.comments c JOIN kept k ON c.id = k.id WHERE c.parent_id IS NOT NULL
+			)
+			DELETE FROM tasks.comments WHERE deleted_at < $1 AND id NOT IN (SELECT id FROM kept)`
+	tag, err := c.pool.Exec(ctx, sql, before)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+func queryComments(ctx context.Context, pool *pgxpool.Pool, sql string, args ...any) ([]*models.CommentWish, error) {
+	rows, err := pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var comments []*models.CommentWish
+	for rows.Next() {
+		comment, err := scanComment(rows)
+		if err != nil {
+			return nil, err
+		}
+		comments = append(comments, comment)
+	}
+	return comments, rows.Err()
+}
+
+func scanComment(row pgx.Row) (*models.CommentWish, error) {
 	res := &models.CommentWish{}
 	if err := row.Scan(
 		&res.Comment.ID,
 		&res.Comment.TaskID,
+		&res.Comment.ParentID,
 		&res.Comment.Author,
 		&res.Comment.Content,
 		&res.Comment.CreatedAt,
 		&res.Comment.UpdatedAt,
+		&res.Comment.EditedAt,
 		&res.Author.ID,
 		&res.Author.Name,
 		&res.Author.Email,
@@ -76,15 +284,3 @@ func (c *CommentRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.
 	}
 	return res, nil
 }
-
-func (c *CommentRepository) Update(ctx context.Context, comment *entities.Comment) error {
-	sql := `UPDATE tasks.comments SET content = $1, updated_at = $2 WHERE id = $3`
-	_, err := c.pool.Exec(ctx, sql, comment.Content, comment.UpdatedAt, comment.ID)
-	return err
-}
-
-func (c *CommentRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	sql := `DELETE FROM tasks.comments WHERE id = $1`
-	_, err := c.pool.Exec(ctx, sql, id)
-	return err
-}
@@ -0,0 +1,77 @@
+package postgres
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"task-api/migrations"
+	"task-api/pkg/migrator"
+	"testing"
+	"time"
+)
+
+// newTestPool connects to the database in TEST_DATABASE_URL and migrates it.
+// Tests that need a database are skipped when it is not set.
+func newTestPool(t *testing.T) *pgxpool.Pool {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+	ctx := context.Background()
+	pool, err := pgxpool.New(ctx, dsn)
+	require.NoError(t, err)
+	t.Cleanup(pool.Close)
+
+	m, err := migrator.New(migrations.FS, stdlib.OpenDBFromPool(pool), pool.Config().ConnConfig.Database)
+	require.NoError(t, err)
+	defer m.Close()
+	require.NoError(t, m.UpLocked(ctx))
+	return pool
+}
+
+func TestCommentRepository_PurgeDeleted_KeepsLiveReplies(t *testing.T) {
+	pool := newTestPool(t)
+	ctx := context.Background()
+	repo := NewCommentRepository(pool)
+
+	var userID, taskID uuid.UUID
+	require.NoError(t, pool.QueryRow(ctx, `INSERT INTO users.users (name, email, password) VALUES ('anna', $1, 'x') RETURNING id`,
+		uuid.NewString()+"@example.com").Scan(&userID))
+	t.Cleanup(func() { pool.Exec(context.Background(), `DELETE FROM users.users WHERE id = $1`, userID) })
+	require.NoError(t, pool.QueryRow(ctx, `INSERT INTO tasks.tasks (title, created_by, rank) VALUES ('task', $1, 'V') RETURNING id`,
+		userID).Scan(&taskID))
+
+	comment := func(parentID *uuid.UUID, deletedAt *time.Time) uuid.UUID {
+		var id uuid.UUID
+		require.NoError(t, pool.QueryRow(ctx, `INSERT INTO tasks.comments (task_id, parent_id, author_id, content, deleted_at)
+			VALUES ($1, $2, $3, 'text', $4) RETURNING id`, taskID, parentID, userID, deletedAt).Scan(&id))
+		return id
+	}
+	exists := func(id uuid.UUID) bool {
+		var found bool
+		require.NoError(t, pool.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM tasks.comments WHERE id = $1)`, id).Scan(&found))
+		return found
+	}
+
+	deletedAt := time.Now().Add(-2 * time.Hour)
+	parent := comment(nil, &deletedAt)
+	reply := comment(&parent, nil)
+	root := comment(nil, &deletedAt)
+	deletedReply := comment(&root, &deletedAt)
+	liveReply := comment(&deletedReply, nil)
+	lonely := comment(nil, &deletedAt)
+
+	_, err := repo.PurgeDeleted(ctx, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+
+	assert.True(t, exists(parent), "a parent with a live reply is kept")
+	assert.True(t, exists(reply))
+	assert.True(t, exists(root), "an ancestor of a live reply is kept")
+	assert.True(t, exists(deletedReply))
+	assert.True(t, exists(liveReply))
+	assert.False(t, exists(lonely))
+}
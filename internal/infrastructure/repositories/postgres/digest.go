@@ -0,0 +1,185 @@
+package postgres
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"task-api/internal/adapters/models"
+	"task-api/internal/domain/entities"
+	"task-api/internal/domain/repositories"
+	"time"
+)
+
+// participantTasks selects the tasks a user follows: created by them or assigned to them.
+const participantTasks = `(t.created_by = $1 OR EXISTS (
+				SELECT 1 FROM tasks.task_assignees a WHERE a.task_id = t.id AND a.user_id = $1))`
+
+type DigestRepository struct {
+	pool *pgxpool.Pool
+}
+
+var _ repositories.DigestRepository = new(DigestRepository)
+
+func NewDigestRepository(pool *pgxpool.Pool) *DigestRepository {
+	return &DigestRepository{pool: pool}
+}
+
+// GetSettings returns pgx.ErrNoRows when the user never changed the defaults.
+func (r *DigestRepository) GetSettings(ctx context.Context, userID uuid.UUID) (*entities.DigestSettings, error) {
+	sql := `SELECT user_id, enabled, timezone, last_sent_at FROM notifications.digest_settings WHERE user_id = $1`
+	settings := &entities.DigestSettings{}
+	if err := r.pool.QueryRow(ctx, sql, userID).Scan(
+		&settings.UserID,
+		&settings.Enabled,
+		&settings.Timezone,
+		&settings.LastSentAt,
+	); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+func (r *DigestRepository) SetSettings(ctx context.Context, settings *entities.DigestSettings) error {
+	sql := `INSERT INTO notifications.digest_settings (user_id, enabled, timezone, updated_at)
+			VALUES ($1, $2, $3, now())
+			ON CONFLICT (user_id) DO UPDATE SET enabled = EXCLUDED.enabled, timezone = EXCLUDED.timezone, updated_at = EXCLUDED.updated_at`
+	_, err := r.pool.Exec(ctx, sql, settings.UserID, settings.Enabled, settings.Timezone)
+	return err
+}
+
+// GetRecipients returns every user who has not opted out of the digest,
+// together with their settings or the defaults.
+func (r *DigestRepository) GetRecipients(ctx context.Context) ([]*models.DigestRecipient, error) {
+	sql := `SELECT u.id, u.name, u.email, COALESCE(s.enabled, true), COALESCE(s.timezone, $1), s.last_sent_at
+			FROM users.users u
+			LEFT JOIN notifications.digest_settings s ON s.user_id = u.id
+			WHERE COALESCE(s.enabled, true)
+			ORDER BY u.id`
+	rows, err := r.pool.Query(ctx, sql, entities.DefaultDigestTimezone)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var recipients []*models.DigestRecipient
+	for rows.Next() {
+		recipient := &models.DigestRecipient{}
+		if err := rows.Scan(
+			&recipient.User.ID,
+			&recipient.User.Name,
+			&recipient.User.Email,
+			&recipient.Settings.Enabled,
+			&recipient.Settings.Timezone,
+			&recipient.Settings.LastSentAt,
+		); err != nil {
+			return nil, err
+		}
+		recipient.Settings.UserID = recipient.User.ID
+		recipients = append(recipients, recipient)
+	}
+	return recipients, rows.Err()
+}
+
+// GetDueTasks returns the open tasks of the user that are due before dueBefore,
+// overdue ones included, earliest first.
+func (r *DigestRepository) GetDueTasks(ctx context.Context, userID uuid.UUID, dueBefore time.Time) ([]*entities.Task, error) {
+	sql := `SELECT t.id, t.title, t.description, t.status, t.created_by, t.created_at, t.updated_at, t.due_at
+			FROM tasks.tasks t
+			WHERE ` + participantTasks + `
+			  AND t.due_at < $2 AND t.status <> $3
+			  AND t.deleted_at IS NULL AND t.archived_at IS NULL
+			ORDER BY t.due_at, t.id`
+	rows, err := r.pool.Query(ctx, sql, userID, dueBefore, entities.TaskStatusDone)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var tasks []*entities.Task
+	for rows.Next() {
+		task := &entities.Task{}
+		if err := rows.Scan(
+			&task.ID,
+			&task.Title,
+			&task.Description,
+			&task.Status,
+			&task.CreatedBy,
+			&task.CreatedAt,
+			&task.UpdatedAt,
+			&task.DueAt,
+		); err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, rows.Err()
+}
+
+// GetNewComments returns comments left by other users on the tasks the user follows.
+func (r *DigestRepository) GetNewComments(ctx context.Context, userID uuid.UUID, since time.Time) ([]*models.DigestComment, error) {
+	sql := `SELECT t.id, t.title, u.name, c.content, c.created_at
+			FROM tasks.comments c
+			JOIN tasks.tasks t ON t.id = c.task_id
+			JOIN users.users u ON u.id = c.author_id
+			WHERE ` + participantTasks + `
+			  AND c.author_id <> $1 AND c.created_at > $2
+			  AND c.deleted_at IS NULL AND t.deleted_at IS NULL
+			ORDER BY c.created_at, c.id`
+	rows, err := r.pool.Query(ctx, sql, userID, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var comments []*models.DigestComment
+	for rows.Next() {
+		comment := &models.DigestComment{}
+		if err := rows.Scan(
+			&comment.TaskID,
+			&comment.TaskTitle,
+			&comment.AuthorName,
+			&comment.Content,
+			&comment.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		comments = append(comments, comment)
+	}
+	return comments, rows.Err()
+}
+
+// GetNewAssignments returns the tasks other users assigned to the user.
+func (r *DigestRepository) GetNewAssignments(ctx context.Context, userID uuid.UUID, since time.Time) ([]*models.DigestAssignment, error) {
+	sql := `SELECT t.id, t.title, COALESCE(u.name, ''), a.assigned_at
+			FROM tasks.task_assignees a
+			JOIN tasks.tasks t ON t.id = a.task_id
+			LEFT JOIN users.users u ON u.id = a.assigned_by
+			WHERE a.user_id = $1 AND a.assigned_at > $2
+			  AND a.assigned_by IS DISTINCT FROM $1
+			  AND t.deleted_at IS NULL
+			ORDER BY a.assigned_at, t.id`
+	rows, err := r.pool.Query(ctx, sql, userID, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var assignments []*models.DigestAssignment
+	for rows.Next() {
+		assignment := &models.DigestAssignment{}
+		if err := rows.Scan(
+			&assignment.TaskID,
+			&assignment.TaskTitle,
+			&assignment.AssignedByName,
+			&assignment.AssignedAt,
+		); err != nil {
+			return nil, err
+		}
+		assignments = append(assignments, assignment)
+	}
+	return assignments, rows.Err()
+}
+
+func (r *DigestRepository) MarkSent(ctx context.Context, userID uuid.UUID, sentAt time.Time) error {
+	sql := `INSERT INTO notifications.digest_settings (user_id, last_sent_at)
+			VALUES ($1, $2)
+			ON CONFLICT (user_id) DO UPDATE SET last_sent_at = EXCLUDED.last_sent_at`
+	_, err := r.pool.Exec(ctx, sql, userID, sentAt)
+	return err
+}
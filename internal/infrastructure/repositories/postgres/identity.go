@@ -0,0 +1,39 @@
+package postgres
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"task-api/internal/domain/entities"
+	"task-api/internal/domain/repositories"
+)
+
+type IdentityRepository struct {
+	pool *pgxpool.Pool
+}
+
+var _ repositories.IdentityRepository = new(IdentityRepository)
+
+func NewIdentityRepository(pool *pgxpool.Pool) *IdentityRepository {
+	return &IdentityRepository{pool: pool}
+}
+
+func (i *IdentityRepository) Create(ctx context.Context, identity *entities.Identity) error {
+	sql := `INSERT INTO users.identities (user_id, provider, subject, email, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
+	return i.pool.QueryRow(ctx, sql, identity.UserID, identity.Provider, identity.Subject, identity.Email, identity.CreatedAt, identity.UpdatedAt).Scan(&identity.ID)
+}
+
+func (i *IdentityRepository) GetByProviderSubject(ctx context.Context, provider, subject string) (*entities.Identity, error) {
+	sql := `SELECT id, user_id, provider, subject, email, created_at, updated_at FROM users.identities WHERE provider = $1 AND subject = $2`
+	row := i.pool.QueryRow(ctx, sql, provider, subject)
+	identity := &entities.Identity{}
+	if err := row.Scan(&identity.ID, &identity.UserID, &identity.Provider, &identity.Subject, &identity.Email, &identity.CreatedAt, &identity.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return identity, nil
+}
+
+func (i *IdentityRepository) Update(ctx context.Context, identity *entities.Identity) error {
+	sql := `UPDATE users.identities SET email = $1, updated_at = $2 WHERE id = $3`
+	_, err := i.pool.Exec(ctx, sql, identity.Email, identity.UpdatedAt, identity.ID)
+	return err
+}
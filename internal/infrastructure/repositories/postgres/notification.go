@@ -0,0 +1,146 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"task-api/internal/domain/entities"
+	"task-api/internal/domain/repositories"
+	"time"
+)
+
+type NotificationRepository struct {
+	pool *pgxpool.Pool
+}
+
+var _ repositories.NotificationRepository = new(NotificationRepository)
+
+func NewNotificationRepository(pool *pgxpool.Pool) *NotificationRepository {
+	return &NotificationRepository{pool: pool}
+}
+
+// Create stores the notification unless the user has turned its type off, in
+// which case it returns false.
+func (r *NotificationRepository) Create(ctx context.Context, notification *entities.Notification) (bool, error) {
+	data := notification.Data
+	if data == nil {
+		data = map[string]string{}
+	}
+	sql := `INSERT INTO notifications.notifications (user_id, type, task_id, comment_id, actor_id, data, created_at)
+			SELECT $1, $2, $3, $4, $5, $6, $7
+			WHERE NOT EXISTS (
+				SELECT 1 FROM notifications.preferences WHERE user_id = $1 AND type = $2 AND NOT enabled)
+			RETURNING id`
+	err := r.pool.QueryRow(ctx, sql,
+		notification.UserID,
+		notification.Type,
+		notification.TaskID,
+		notification.CommentID,
+		notification.ActorID,
+		data,
+		notification.CreatedAt,
+	).Scan(&notification.ID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *NotificationRepository) GetByUserID(ctx context.Context, userID uuid.UUID, unreadOnly bool, limit, offset int) ([]*entities.Notification, error) {
+	sql := `SELECT id, user_id, type, task_id, comment_id, actor_id, data, created_at, read_at
+			FROM notifications.notifications
+			WHERE user_id = $1 AND ($2 = false OR read_at IS NULL)
+			ORDER BY created_at DESC, id
+			LIMIT $3 OFFSET $4`
+	rows, err := r.pool.Query(ctx, sql, userID, unreadOnly, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var notifications []*entities.Notification
+	for rows.Next() {
+		notification := &entities.Notification{}
+		if err := rows.Scan(
+			&notification.ID,
+			&notification.UserID,
+			&notification.Type,
+			&notification.TaskID,
+			&notification.CommentID,
+			&notification.ActorID,
+			&notification.Data,
+			&notification.CreatedAt,
+			&notification.ReadAt,
+		); err != nil {
+			return nil, err
+		}
+		notifications = append(notifications, notification)
+	}
+	return notifications, rows.Err()
+}
+
+func (r *NotificationRepository) CountUnread(ctx context.Context, userID uuid.UUID) (int, error) {
+	var count int
+	sql := `SELECT count(*) FROM notifications.notifications WHERE user_id = $1 AND read_at IS NULL`
+	err := r.pool.QueryRow(ctx, sql, userID).Scan(&count)
+	return count, err
+}
+
+// MarkRead returns false when the notification does not exist or belongs to another user.
+func (r *NotificationRepository) MarkRead(ctx context.Context, id, userID uuid.UUID, readAt time.Time) (bool, error) {
+	sql := `UPDATE notifications.notifications SET read_at = COALESCE(read_at, $3) WHERE id = $1 AND user_id = $2`
+	tag, err := r.pool.Exec(ctx, sql, id, userID, readAt)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *NotificationRepository) MarkAllRead(ctx context.Context, userID uuid.UUID, readAt time.Time) (int64, error) {
+	sql := `UPDATE notifications.notifications SET read_at = $2 WHERE user_id = $1 AND read_at IS NULL`
+	tag, err := r.pool.Exec(ctx, sql, userID, readAt)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+func (r *NotificationRepository) GetPreferences(ctx context.Context, userID uuid.UUID) ([]*entities.NotificationPreference, error) {
+	sql := `SELECT user_id, type, enabled FROM notifications.preferences WHERE user_id = $1`
+	rows, err := r.pool.Query(ctx, sql, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var preferences []*entities.NotificationPreference
+	for rows.Next() {
+		preference := &entities.NotificationPreference{}
+		if err := rows.Scan(&preference.UserID, &preference.Type, &preference.Enabled); err != nil {
+			return nil, err
+		}
+		preferences = append(preferences, preference)
+	}
+	return preferences, rows.Err()
+}
+
+func (r *NotificationRepository) SetPreferences(ctx context.Context, preferences []*entities.NotificationPreference) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	sql := `INSERT INTO notifications.preferences (user_id, type, enabled, updated_at)
+			VALUES ($1, $2, $3, now())
+			ON CONFLICT (user_id, type) DO UPDATE SET enabled = EXCLUDED.enabled, updated_at = EXCLUDED.updated_at`
+	for _, preference := range preferences {
+		if _, err := tx.Exec(ctx, sql, preference.UserID, preference.Type, preference.Enabled); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
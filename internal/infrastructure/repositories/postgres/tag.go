@@ -3,12 +3,21 @@ package postgres
 import (
 	"context"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"task-api/internal/adapters/models"
 	"task-api/internal/domain/entities"
 	"task-api/internal/domain/repositories"
 	"time"
 )
 
+const tagColumns = `g.id, g.owner_id, g.title, COALESCE(g.color, ''), g.description, g.created_at, g.updated_at`
+
+// tagUsage counts the tasks outside of the trash a tag is attached to.
+const tagUsage = `(SELECT count(*) FROM tasks.tasks_tags tt
+				JOIN tasks.tasks t ON t.id = tt.task_id
+				WHERE tt.tag_id = g.id AND t.deleted_at IS NULL)`
+
 type TagRepository struct {
 	pool *pgxpool.Pool
 }
@@ -19,44 +28,76 @@ func NewTagPostgresRepository(pool *pgxpool.Pool) *TagRepository {
 	return &TagRepository{pool: pool}
 }
 
-func (t *TagRepository) GetAllTags(ctx context.Context) ([]*entities.Tag, error) {
-	sql := `SELECT id, title, created_at, updated_at FROM tasks.tags`
-	rows, err := t.pool.Query(ctx, sql)
+func scanTag(row pgx.Row) (*entities.Tag, error) {
+	tag := &entities.Tag{}
+	if err := row.Scan(&tag.ID, &tag.OwnerID, &tag.Title, &tag.Color, &tag.Description, &tag.CreatedAt, &tag.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return tag, nil
+}
+
+func scanTagUsage(row pgx.Row) (*models.TagUsage, error) {
+	usage := &models.TagUsage{}
+	tag := &usage.Tag
+	if err := row.Scan(&tag.ID, &tag.OwnerID, &tag.Title, &tag.Color, &tag.Description, &tag.CreatedAt, &tag.UpdatedAt, &usage.UsageCount); err != nil {
+		return nil, err
+	}
+	return usage, nil
+}
+
+// ListTags returns the owner's tags whose title starts with prefix, ignoring
+// case, the most used first. A zero limit returns all of them.
+func (t *TagRepository) ListTags(ctx context.Context, ownerID uuid.UUID, prefix string, limit int) ([]*models.TagUsage, error) {
+	sql := `SELECT ` + tagColumns + `, ` + tagUsage + ` AS usage_count
+			FROM tasks.tags g
+			WHERE g.owner_id = $1 AND starts_with(lower(g.title), lower($2))
+			ORDER BY usage_count DESC, g.title
+			LIMIT NULLIF($3, 0)`
+	rows, err := t.pool.Query(ctx, sql, ownerID, prefix, limit)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var tags []*entities.Tag
+	var tags []*models.TagUsage
 	for rows.Next() {
-		tag := &entities.Tag{}
-		if err := rows.Scan(&tag.ID, &tag.Title, &tag.CreatedAt, &tag.UpdatedAt); err != nil {
+		tag, err := scanTagUsage(rows)
+		if err != nil {
 			return nil, err
 		}
 		tags = append(tags, tag)
 	}
-	return tags, nil
+	return tags, rows.Err()
 }
 
+func (t *TagRepository) GetTagUsage(ctx context.Context, id uuid.UUID) (*models.TagUsage, error) {
+	sql := `SELECT ` + tagColumns + `, ` + tagUsage + ` FROM tasks.tags g WHERE g.id = $1`
+	return scanTagUsage(t.pool.QueryRow(ctx, sql, id))
+}
+
+// CreateTag returns pgx.ErrNoRows when the owner already has a tag with this title.
 func (t *TagRepository) CreateTag(ctx context.Context, tag *entities.Tag) error {
-	sql := `INSERT INTO tasks.tags (title, created_at, updated_at) VALUES ($1, $2, $3) RETURNING id`
-	return t.pool.QueryRow(ctx, sql, tag.Title, tag.CreatedAt, tag.UpdatedAt).Scan(&tag.ID)
+	sql := `INSERT INTO tasks.tags (owner_id, title, color, description, created_at, updated_at)
+			VALUES ($1, $2, NULLIF($3, ''), $4, $5, $6)
+			ON CONFLICT (owner_id, title) DO NOTHING
+			RETURNING id`
+	return t.pool.QueryRow(ctx, sql, tag.OwnerID, tag.Title, tag.Color, tag.Description, tag.CreatedAt, tag.UpdatedAt).Scan(&tag.ID)
 }
 
 func (t *TagRepository) GetTagByID(ctx context.Context, id uuid.UUID) (*entities.Tag, error) {
-	sql := `SELECT id, title, created_at, updated_at FROM tasks.tags WHERE id = $1`
-	row := t.pool.QueryRow(ctx, sql, id)
-	tag := &entities.Tag{}
-	if err := row.Scan(&tag.ID, &tag.Title, &tag.CreatedAt, &tag.UpdatedAt); err != nil {
-		return nil, err
-	}
-	return tag, nil
+	sql := `SELECT ` + tagColumns + ` FROM tasks.tags g WHERE g.id = $1`
+	return scanTag(t.pool.QueryRow(ctx, sql, id))
+}
+
+func (t *TagRepository) GetTagByTitle(ctx context.Context, ownerID uuid.UUID, title string) (*entities.Tag, error) {
+	sql := `SELECT ` + tagColumns + ` FROM tasks.tags g WHERE g.owner_id = $1 AND g.title = $2`
+	return scanTag(t.pool.QueryRow(ctx, sql, ownerID, title))
 }
 
 func (t *TagRepository) UpdateTag(ctx context.Context, tag *entities.Tag) error {
-	sql := `UPDATE tasks.tags SET title = $1, updated_at = $2 WHERE id = $3`
+	sql := `UPDATE tasks.tags SET title = $1, color = NULLIF($2, ''), description = $3, updated_at = $4 WHERE id = $5`
 	tag.UpdatedAt = time.Now()
-	_, err := t.pool.Exec(ctx, sql, tag.Title, tag.UpdatedAt, tag.ID)
+	_, err := t.pool.Exec(ctx, sql, tag.Title, tag.Color, tag.Description, tag.UpdatedAt, tag.ID)
 	return err
 }
 
@@ -65,3 +106,37 @@ func (t *TagRepository) DeleteTag(ctx context.Context, id uuid.UUID) error {
 	_, err := t.pool.Exec(ctx, sql, id)
 	return err
 }
+
+// MergeTags moves every task from the source tag to the target tag and deletes
+// the source, all in one transaction. It returns false when ownerID does not
+// own both tags.
+func (t *TagRepository) MergeTags(ctx context.Context, sourceID, targetID, ownerID uuid.UUID) (bool, error) {
+	tx, err := t.pool.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	var owned int
+	sql := `SELECT count(*) FROM (
+				SELECT id FROM tasks.tags WHERE id = ANY($1) AND owner_id = $2 ORDER BY id FOR UPDATE
+			) g`
+	if err := tx.QueryRow(ctx, sql, []uuid.UUID{sourceID, targetID}, ownerID).Scan(&owned); err != nil {
+		return false, err
+	}
+	if owned != 2 {
+		return false, nil
+	}
+	sql = `INSERT INTO tasks.tasks_tags (task_id, tag_id)
+			SELECT task_id, $2 FROM tasks.tasks_tags WHERE tag_id = $1
+			ON CONFLICT DO NOTHING`
+	if _, err := tx.Exec(ctx, sql, sourceID, targetID); err != nil {
+		return false, err
+	}
+	// The remaining links of the source go away through ON DELETE CASCADE.
+	sql = `DELETE FROM tasks.tags WHERE id = $1`
+	if _, err := tx.Exec(ctx, sql, sourceID); err != nil {
+		return false, err
+	}
+	return true, tx.Commit(ctx)
+}
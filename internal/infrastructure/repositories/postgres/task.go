@@ -2,13 +2,20 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"task-api/internal/adapters/models"
 	"task-api/internal/domain/entities"
 	"task-api/internal/domain/repositories"
+	"task-api/pkg/rank"
+	"time"
 )
 
+const taskColumns = `t.id, t.title, t.description, t.status, t.priority, t.parent_id, t.rank, t.created_by, t.created_at, t.updated_at,
+			t.due_at, t.completed_at, t.archived_at, COALESCE(t.recurrence, '')`
+
 type TaskRepository struct {
 	pool *pgxpool.Pool
 }
@@ -19,10 +26,34 @@ func NewTaskPostgresRepository(pool *pgxpool.Pool) *TaskRepository {
 	return &TaskRepository{pool: pool}
 }
 
+func scanTask(row pgx.Row) (*entities.Task, error) {
+	task := &entities.Task{}
+	if err := row.Scan(
+		&task.ID,
+		&task.Title,
+		&task.Description,
+		&task.Status,
+		&task.Priority,
+		&task.ParentID,
+		&task.Rank,
+		&task.CreatedBy,
+		&task.CreatedAt,
+		&task.UpdatedAt,
+		&task.DueAt,
+		&task.CompletedAt,
+		&task.ArchivedAt,
+		&task.Recurrence,
+	); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
 func (r *TaskRepository) GetAllTasks(ctx context.Context) ([]*models.Task, error) {
 	sql := `SELECT t.id, t.title, t.description, t.status, t.created_by, t.created_at, t.updated_at, u.id, u.name, u.email
 			FROM tasks.tasks t
-			JOIN users.users u ON u.id = t.created_by`
+			JOIN users.users u ON u.id = t.created_by
+			WHERE t.deleted_at IS NULL`
 	rows, err := r.pool.Query(ctx, sql)
 	if err != nil {
 		return nil, err
@@ -51,10 +82,30 @@ func (r *TaskRepository) GetAllTasks(ctx context.Context) ([]*models.Task, error
 	return tasks, nil
 }
 
-func (r *TaskRepository) GetAllTasksByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.Task, error) {
-	sql := `SELECT id, title, description, status, created_by, created_at, updated_at
-			FROM tasks.tasks WHERE created_by = $1`
-	rows, err := r.pool.Query(ctx, sql, userID)
+func (r *TaskRepository) GetAllTasksByUserID(ctx context.Context, userID uuid.UUID, filter models.TaskFilter) ([]*entities.Task, error) {
+	sql := `SELECT ` + taskColumns + `
+			FROM tasks.tasks t
+			WHERE t.created_by = $1 AND t.deleted_at IS NULL
+			  AND ($2::text = '' OR t.status = $2)
+			  AND NOT EXISTS (
+				SELECT 1 FROM unnest($3::text[]) AS want(title)
+				WHERE NOT EXISTS (
+					SELECT 1 FROM tasks.tasks_tags tt
+					JOIN tasks.tags g ON g.id = tt.tag_id
+					WHERE tt.task_id = t.id AND g.owner_id = $1 AND g.title = want.title))`
+	switch {
+	case filter.ArchivedOnly:
+		sql += ` AND t.archived_at IS NOT NULL`
+	case !filter.IncludeArchived:
+		sql += ` AND t.archived_at IS NULL`
+	}
+	args := []any{userID, filter.Status, filter.Tags}
+	if filter.Query != nil {
+		var cond string
+		cond, args = compileTaskQuery(filter.Query, "$1", args)
+		sql += ` AND ` + cond
+	}
+	rows, err := r.pool.Query(ctx, sql, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -62,16 +113,8 @@ func (r *TaskRepository) GetAllTasksByUserID(ctx context.Context, userID uuid.UU
 
 	var tasks []*entities.Task
 	for rows.Next() {
-		task := &entities.Task{}
-		if err := rows.Scan(
-			&task.ID,
-			&task.Title,
-			&task.Description,
-			&task.Status,
-			&task.CreatedBy,
-			&task.CreatedAt,
-			&task.UpdatedAt,
-		); err != nil {
+		task, err := scanTask(rows)
+		if err != nil {
 			return nil, err
 		}
 		tasks = append(tasks, task)
@@ -79,16 +122,214 @@ func (r *TaskRepository) GetAllTasksByUserID(ctx context.Context, userID uuid.UU
 	return tasks, nil
 }
 
-func (r *TaskRepository) CreateTask(ctx context.Context, task *entities.Task) error {
-	sql := `INSERT INTO tasks.tasks (title, description, status, created_by, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
-	return r.pool.QueryRow(ctx, sql, task.Title, task.Description, task.Status, task.CreatedBy, task.CreatedAt, task.UpdatedAt).Scan(&task.ID)
+// GetTasksByTagID returns the tasks with the tag that the user created or is
+// assigned to, leaving out archived tasks and the trash.
+func (r *TaskRepository) GetTasksByTagID(ctx context.Context, tagID, userID uuid.UUID) ([]*entities.Task, error) {
+	sql := `SELECT ` + taskColumns + `
+			FROM tasks.tasks t
+			JOIN tasks.tasks_tags tt ON tt.task_id = t.id
+			WHERE tt.tag_id = $1 AND t.deleted_at IS NULL AND t.archived_at IS NULL
+			  AND (t.created_by = $2 OR EXISTS (
+				SELECT 1 FROM tasks.task_assignees a WHERE a.task_id = t.id AND a.user_id = $2))
+			ORDER BY t.created_at DESC, t.id`
+	rows, err := r.pool.Query(ctx, sql, tagID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tasks []*entities.Task
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, rows.Err()
+}
+
+// CreateTask stores the task and tags it with the creator's tags of the given
+// titles, creating the missing ones, in one transaction.
+func (r *TaskRepository) CreateTask(ctx context.Context, task *entities.Task, tags []string) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := insertTask(ctx, tx, task); err != nil {
+		return err
+	}
+	if len(tags) > 0 {
+		if err := linkTagsByTitle(ctx, tx, task.ID, task.CreatedBy, tags, false); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+// CreateTaskWithSubtasks stores the task and its subtasks, all tagged with the
+// creator's tags of the given titles, in one transaction.
+func (r *TaskRepository) CreateTaskWithSubtasks(ctx context.Context, task *entities.Task, subtasks []*entities.Task, tags []string) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := insertTask(ctx, tx, task); err != nil {
+		return err
+	}
+	for _, subtask := range subtasks {
+		subtask.ParentID = &task.ID
+		if err := insertTask(ctx, tx, subtask); err != nil {
+			return err
+		}
+	}
+	if len(tags) > 0 {
+		for _, t := range append([]*entities.Task{task}, subtasks...) {
+			if err := linkTagsByTitle(ctx, tx, t.ID, task.CreatedBy, tags, false); err != nil {
+				return err
+			}
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+// insertTask puts the new task at the end of its creator's board.
+func insertTask(ctx context.Context, tx pgx.Tx, task *entities.Task) error {
+	if err := lockBoard(ctx, tx, task.CreatedBy); err != nil {
+		return err
+	}
+	var last string
+	sql := `SELECT COALESCE(max(rank), '') FROM tasks.tasks WHERE created_by = $1`
+	if err := tx.QueryRow(ctx, sql, task.CreatedBy).Scan(&last); err != nil {
+		return err
+	}
+	var err error
+	if task.Rank, err = rank.Between(last, ""); err != nil {
+		return err
+	}
+	sql = `INSERT INTO tasks.tasks (title, description, status, priority, parent_id, rank, created_by, created_at, updated_at, due_at, recurrence)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NULLIF($11, '')) RETURNING id`
+	return tx.QueryRow(ctx, sql, task.Title, task.Description, task.Status, task.Priority, task.ParentID, task.Rank,
+		task.CreatedBy, task.CreatedAt, task.UpdatedAt, task.DueAt, task.Recurrence).Scan(&task.ID)
+}
+
+// lockBoard serializes changes to the order of the owner's board until the
+// transaction ends, so that concurrent moves never pick the same rank.
+func lockBoard(ctx context.Context, tx pgx.Tx, ownerID uuid.UUID) error {
+	_, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtextextended('tasks.board:' || $1::text, 0))`, ownerID)
+	return err
+}
+
+// GetBoardTasks returns the user's tasks that are neither archived nor in the
+// trash, in board order.
+func (r *TaskRepository) GetBoardTasks(ctx context.Context, userID uuid.UUID) ([]*entities.Task, error) {
+	sql := `SELECT ` + taskColumns + `
+			FROM tasks.tasks t
+			WHERE t.created_by = $1 AND t.deleted_at IS NULL AND t.archived_at IS NULL
+			ORDER BY t.rank, t.id`
+	rows, err := r.pool.Query(ctx, sql, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tasks []*entities.Task
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, rows.Err()
+}
+
+// MoveTask puts the task into the status column of its creator's board,
+// right after the afterID task, right before the beforeID task or, without
+// either, at the end of the column. It returns the status the task had, or
+// pgx.ErrNoRows when the task does not exist or is in the trash. It returns
+// false when the anchor task is not in that column.
+func (r *TaskRepository) MoveTask(ctx context.Context, id uuid.UUID, status string, afterID, beforeID *uuid.UUID, movedAt time.Time) (string, bool, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return "", false, err
+	}
+	defer tx.Rollback(ctx)
+
+	var ownerID uuid.UUID
+	var previous string
+	sql := `SELECT created_by, status FROM tasks.tasks WHERE id = $1 AND deleted_at IS NULL`
+	if err := tx.QueryRow(ctx, sql, id).Scan(&ownerID, &previous); err != nil {
+		return "", false, err
+	}
+	if err := lockBoard(ctx, tx, ownerID); err != nil {
+		return "", false, err
+	}
+
+	// The column is read after the lock, so the neighbours are still neighbours
+	// when the new rank is stored.
+	column := `FROM tasks.tasks
+			WHERE created_by = $1 AND status = $2 AND id <> $3 AND deleted_at IS NULL AND archived_at IS NULL`
+	var lower, upper string
+	switch {
+	case afterID != nil:
+		sql = `SELECT rank ` + column + ` AND id = $4`
+		if lower, err = scanRank(ctx, tx, sql, ownerID, status, id, *afterID); err != nil || lower == "" {
+			return "", false, err
+		}
+		sql = `SELECT COALESCE(min(rank), '') ` + column + ` AND rank > $4`
+		if upper, err = scanRank(ctx, tx, sql, ownerID, status, id, lower); err != nil {
+			return "", false, err
+		}
+	case beforeID != nil:
+		sql = `SELECT rank ` + column + ` AND id = $4`
+		if upper, err = scanRank(ctx, tx, sql, ownerID, status, id, *beforeID); err != nil || upper == "" {
+			return "", false, err
+		}
+		sql = `SELECT COALESCE(max(rank), '') ` + column + ` AND rank < $4`
+		if lower, err = scanRank(ctx, tx, sql, ownerID, status, id, upper); err != nil {
+			return "", false, err
+		}
+	default:
+		sql = `SELECT COALESCE(max(rank), '') ` + column
+		if lower, err = scanRank(ctx, tx, sql, ownerID, status, id); err != nil {
+			return "", false, err
+		}
+	}
+	next, err := rank.Between(lower, upper)
+	if err != nil {
+		return "", false, err
+	}
+
+	sql = `UPDATE tasks.tasks
+			SET status = $2, rank = $3, updated_at = $4,
+				completed_at = CASE WHEN $2 = $5 THEN COALESCE(completed_at, $4) END
+			WHERE id = $1`
+	if _, err := tx.Exec(ctx, sql, id, status, next, movedAt, entities.TaskStatusDone); err != nil {
+		return "", false, err
+	}
+	return previous, true, tx.Commit(ctx)
+}
+
+// scanRank returns an empty rank when the query finds no row.
+func scanRank(ctx context.Context, tx pgx.Tx, sql string, args ...any) (string, error) {
+	var key string
+	err := tx.QueryRow(ctx, sql, args...).Scan(&key)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", nil
+	}
+	return key, err
 }
 
 func (r *TaskRepository) GetTaskByID(ctx context.Context, id uuid.UUID) (*models.Task, error) {
-	sql := `SELECT t.id, t.title, t.description, t.status, t.created_by, t.created_at, t.updated_at, u.id, u.name, u.email
+	sql := `SELECT t.id, t.title, t.description, t.status, t.created_by, t.created_at, t.updated_at, t.due_at, t.completed_at, t.archived_at, COALESCE(t.recurrence, ''), t.priority, t.parent_id, t.rank, u.id, u.name, u.email
 			FROM tasks.tasks t
 			JOIN users.users u ON u.id = t.created_by
-			WHERE t.id = $1`
+			WHERE t.id = $1 AND t.deleted_at IS NULL`
 	row := r.pool.QueryRow(ctx, sql, id)
 	task := &models.Task{}
 	if err := row.Scan(
@@ -99,6 +340,13 @@ func (r *TaskRepository) GetTaskByID(ctx context.Context, id uuid.UUID) (*models
 		&task.Task.CreatedBy,
 		&task.Task.CreatedAt,
 		&task.Task.UpdatedAt,
+		&task.Task.DueAt,
+		&task.Task.CompletedAt,
+		&task.Task.ArchivedAt,
+		&task.Task.Recurrence,
+		&task.Task.Priority,
+		&task.Task.ParentID,
+		&task.Task.Rank,
 		&task.User.ID,
 		&task.User.Name,
 		&task.User.Email,
@@ -109,23 +357,281 @@ func (r *TaskRepository) GetTaskByID(ctx context.Context, id uuid.UUID) (*models
 
 }
 
-func (r *TaskRepository) UpdateTask(ctx context.Context, task *entities.Task) error {
-	sql := `UPDATE tasks.tasks 
-			SET title = $1, description = $2, status = $3, updated_at = $4 
-			WHERE id = $5`
-	_, err := r.pool.Exec(ctx, sql, task.Title, task.Description, task.Status, task.UpdatedAt, task.ID)
-	return err
+// IsVisibleTo reports whether the task exists, is not in the trash and the user
+// is its creator or one of its assignees.
+func (r *TaskRepository) IsVisibleTo(ctx context.Context, id, userID uuid.UUID) (bool, error) {
+	var visible bool
+	sql := `SELECT EXISTS (
+				SELECT 1 FROM tasks.tasks t
+				WHERE t.id = $1 AND t.deleted_at IS NULL
+				  AND (t.created_by = $2 OR EXISTS (
+					SELECT 1 FROM tasks.task_assignees a WHERE a.task_id = t.id AND a.user_id = $2)))`
+	err := r.pool.QueryRow(ctx, sql, id, userID).Scan(&visible)
+	return visible, err
 }
 
-func (r *TaskRepository) DeleteTask(ctx context.Context, id uuid.UUID) error {
-	sql := `DELETE FROM tasks.tasks WHERE id = $1`
-	_, err := r.pool.Exec(ctx, sql, id)
-	return err
+// UpdateTask returns the status the task had before the update, or
+// pgx.ErrNoRows when the task does not exist or is in the trash. Unless tags
+// is nil, the actor's tags on the task are replaced in the same transaction.
+func (r *TaskRepository) UpdateTask(ctx context.Context, task *entities.Task, actorID uuid.UUID, tags []string) (string, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
+	// completed_at keeps the moment the task first became done and is cleared when it is reopened.
+	sql := `WITH old AS (
+				SELECT id, status FROM tasks.tasks WHERE id = $5 AND deleted_at IS NULL FOR UPDATE
+			)
+			UPDATE tasks.tasks t
+			SET title = $1, description = $2, status = $3, updated_at = $4, due_at = $7, recurrence = NULLIF($8, ''), priority = $9,
+				completed_at = CASE WHEN $3 = $6 THEN COALESCE(t.completed_at, $4) END
+			FROM old
+			WHERE t.id = old.id
+			RETURNING old.status`
+	var previous string
+	if err := tx.QueryRow(ctx, sql, task.Title, task.Description, task.Status, task.UpdatedAt, task.ID, entities.TaskStatusDone, task.DueAt, task.Recurrence, task.Priority).Scan(&previous); err != nil {
+		return "", err
+	}
+	if tags != nil {
+		if err := linkTagsByTitle(ctx, tx, task.ID, actorID, tags, true); err != nil {
+			return "", err
+		}
+	}
+	return previous, tx.Commit(ctx)
 }
 
-func (r *TaskRepository) AddTags(ctx context.Context, taskID, tagID uuid.UUID) error {
-	sql := `INSERT INTO tasks.tasks_tags (task_id, tag_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`
-	_, err := r.pool.Exec(ctx, sql, taskID, tagID)
+// DeleteTask moves the task of its creator to the trash. Its comments get the
+// same deleted_at, which is how RestoreTask tells them apart from comments
+// deleted on their own.
+func (r *TaskRepository) DeleteTask(ctx context.Context, id, userID uuid.UUID, deletedAt time.Time) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	sql := `UPDATE tasks.tasks SET deleted_at = $3 WHERE id = $1 AND created_by = $2 AND deleted_at IS NULL`
+	tag, err := tx.Exec(ctx, sql, id, userID, deletedAt)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	sql = `UPDATE tasks.comments SET deleted_at = $2 WHERE task_id = $1 AND deleted_at IS NULL`
+	if _, err := tx.Exec(ctx, sql, id, deletedAt); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *TaskRepository) GetDeletedTasksByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.Task, error) {
+	sql := `SELECT id, title, description, status, created_by, created_at, updated_at, deleted_at
+			FROM tasks.tasks WHERE created_by = $1 AND deleted_at IS NOT NULL
+			ORDER BY deleted_at DESC`
+	rows, err := r.pool.Query(ctx, sql, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tasks []*entities.Task
+	for rows.Next() {
+		task := &entities.Task{}
+		if err := rows.Scan(
+			&task.ID,
+			&task.Title,
+			&task.Description,
+			&task.Status,
+			&task.CreatedBy,
+			&task.CreatedAt,
+			&task.UpdatedAt,
+			&task.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, nil
+}
+
+// RestoreTask takes the task out of the trash together with the comments that
+// were deleted with it. Tag links are never removed by a soft delete.
+func (r *TaskRepository) RestoreTask(ctx context.Context, id, userID uuid.UUID) (bool, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	var deletedAt time.Time
+	sql := `SELECT deleted_at FROM tasks.tasks WHERE id = $1 AND created_by = $2 AND deleted_at IS NOT NULL FOR UPDATE`
+	if err := tx.QueryRow(ctx, sql, id, userID).Scan(&deletedAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+	sql = `UPDATE tasks.comments SET deleted_at = NULL WHERE task_id = $1 AND deleted_at = $2`
+	if _, err := tx.Exec(ctx, sql, id, deletedAt); err != nil {
+		return false, err
+	}
+	sql = `UPDATE tasks.tasks SET deleted_at = NULL WHERE id = $1`
+	if _, err := tx.Exec(ctx, sql, id); err != nil {
+		return false, err
+	}
+	return true, tx.Commit(ctx)
+}
+
+func (r *TaskRepository) PurgeDeletedTasks(ctx context.Context, before time.Time) (int64, error) {
+	sql := `DELETE FROM tasks.tasks WHERE deleted_at < $1`
+	tag, err := r.pool.Exec(ctx, sql, before)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// SetArchived archives the task when archivedAt is set and unarchives it otherwise.
+// An already archived task keeps its original archived_at.
+func (r *TaskRepository) SetArchived(ctx context.Context, id uuid.UUID, archivedAt *time.Time) (bool, error) {
+	sql := `UPDATE tasks.tasks
+			SET archived_at = CASE WHEN $2::timestamp IS NULL THEN NULL ELSE COALESCE(archived_at, $2) END
+			WHERE id = $1 AND deleted_at IS NULL`
+	tag, err := r.pool.Exec(ctx, sql, id, archivedAt)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *TaskRepository) ArchiveCompletedTasks(ctx context.Context, completedBefore, archivedAt time.Time) (int64, error) {
+	sql := `UPDATE tasks.tasks SET archived_at = $2
+			WHERE status = $3 AND completed_at < $1 AND archived_at IS NULL AND deleted_at IS NULL`
+	tag, err := r.pool.Exec(ctx, sql, completedBefore, archivedAt, entities.TaskStatusDone)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// GetPendingRecurrences returns the recurring tasks that are due by now and
+// have not spawned their next instance yet.
+func (r *TaskRepository) GetPendingRecurrences(ctx context.Context, now time.Time) ([]*entities.Task, error) {
+	sql := `SELECT id FROM tasks.tasks
+			WHERE recurrence IS NOT NULL AND recurrence_spawned_at IS NULL AND deleted_at IS NULL AND due_at <= $1
+			ORDER BY due_at`
+	rows, err := r.pool.Query(ctx, sql, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tasks []*entities.Task
+	for rows.Next() {
+		task := &entities.Task{}
+		if err := rows.Scan(&task.ID); err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, rows.Err()
+}
+
+// SpawnRecurrence marks the recurring task as spawned and stores next, the
+// following instance of the series, with the tags and assignees of the source.
+// A nil next only marks the source, which ends the series. It returns false
+// when the source has already spawned or is in the trash, so that concurrent
+// schedulers create each instance once.
+func (r *TaskRepository) SpawnRecurrence(ctx context.Context, sourceID uuid.UUID, next *entities.Task, spawnedAt time.Time) (bool, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	sql := `UPDATE tasks.tasks SET recurrence_spawned_at = $2
+			WHERE id = $1 AND recurrence IS NOT NULL AND recurrence_spawned_at IS NULL AND deleted_at IS NULL`
+	tag, err := tx.Exec(ctx, sql, sourceID, spawnedAt)
+	if err != nil {
+		return false, err
+	}
+	if tag.RowsAffected() == 0 {
+		return false, nil
+	}
+	if next != nil {
+		if err := insertTask(ctx, tx, next); err != nil {
+			return false, err
+		}
+		sql = `INSERT INTO tasks.tasks_tags (task_id, tag_id)
+				SELECT $2, tag_id FROM tasks.tasks_tags WHERE task_id = $1`
+		if _, err := tx.Exec(ctx, sql, sourceID, next.ID); err != nil {
+			return false, err
+		}
+		sql = `INSERT INTO tasks.task_assignees (task_id, user_id, assigned_by, assigned_at)
+				SELECT $2, user_id, assigned_by, $3 FROM tasks.task_assignees WHERE task_id = $1`
+		if _, err := tx.Exec(ctx, sql, sourceID, next.ID, spawnedAt); err != nil {
+			return false, err
+		}
+	}
+	return true, tx.Commit(ctx)
+}
+
+// AddTags links the tag to the task and returns false when ownerID does not own the tag.
+func (r *TaskRepository) AddTags(ctx context.Context, taskID, tagID, ownerID uuid.UUID) (bool, error) {
+	sql := `WITH tag AS (
+				SELECT id FROM tasks.tags WHERE id = $2 AND owner_id = $3
+			), linked AS (
+				INSERT INTO tasks.tasks_tags (task_id, tag_id) SELECT $1, id FROM tag ON CONFLICT DO NOTHING
+			)
+			SELECT EXISTS (SELECT 1 FROM tag)`
+	var found bool
+	err := r.pool.QueryRow(ctx, sql, taskID, tagID, ownerID).Scan(&found)
+	return found, err
+}
+
+// ReplaceTags makes the owner's tags on the task exactly the given titles,
+// creating the missing tags. Tags of other users stay on the task.
+func (r *TaskRepository) ReplaceTags(ctx context.Context, taskID, ownerID uuid.UUID, tags []string) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := linkTagsByTitle(ctx, tx, taskID, ownerID, tags, true); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// linkTagsByTitle creates the owner's tags that do not exist yet and links them
+// to the task. With replace, the owner's tags not in titles are unlinked.
+func linkTagsByTitle(ctx context.Context, tx pgx.Tx, taskID, ownerID uuid.UUID, titles []string, replace bool) error {
+	if titles == nil {
+		titles = []string{}
+	}
+	now := time.Now()
+	sql := `INSERT INTO tasks.tags (owner_id, title, created_at, updated_at)
+			SELECT $1, title, $3, $3 FROM unnest($2::text[]) AS title
+			ON CONFLICT (owner_id, title) DO NOTHING`
+	if _, err := tx.Exec(ctx, sql, ownerID, titles, now); err != nil {
+		return err
+	}
+	if replace {
+		sql = `DELETE FROM tasks.tasks_tags tt
+				USING tasks.tags g
+				WHERE tt.task_id = $1 AND g.id = tt.tag_id AND g.owner_id = $2 AND NOT g.title = ANY($3)`
+		if _, err := tx.Exec(ctx, sql, taskID, ownerID, titles); err != nil {
+			return err
+		}
+	}
+	sql = `INSERT INTO tasks.tasks_tags (task_id, tag_id)
+			SELECT $1, id FROM tasks.tags WHERE owner_id = $2 AND title = ANY($3)
+			ON CONFLICT DO NOTHING`
+	_, err := tx.Exec(ctx, sql, taskID, ownerID, titles)
 	return err
 }
 
@@ -136,7 +642,7 @@ func (r *TaskRepository) RemoveTags(ctx context.Context, taskID, tagID uuid.UUID
 }
 
 func (r *TaskRepository) GetTags(ctx context.Context, taskID uuid.UUID) ([]*entities.Tag, error) {
-	sql := `SELECT t.id, t.title 
+	sql := `SELECT t.id, t.title, COALESCE(t.color, '')
 			FROM tasks.tags t
 			JOIN tasks.tasks_tags tt ON t.id = tt.tag_id
 			WHERE tt.task_id = $1`
@@ -148,7 +654,7 @@ func (r *TaskRepository) GetTags(ctx context.Context, taskID uuid.UUID) ([]*enti
 	var tags []*entities.Tag
 	for rows.Next() {
 		tag := &entities.Tag{}
-		if err := rows.Scan(&tag.ID, &tag.Title); err != nil {
+		if err := rows.Scan(&tag.ID, &tag.Title, &tag.Color); err != nil {
 			return nil, err
 		}
 		tags = append(tags, tag)
@@ -157,10 +663,10 @@ func (r *TaskRepository) GetTags(ctx context.Context, taskID uuid.UUID) ([]*enti
 }
 
 func (r *TaskRepository) GetTagsForManyTasks(ctx context.Context, taskIDs []uuid.UUID) ([]*models.TagWishTaskID, error) {
-	sql := `SELECT tt.task_id, t.id, t.title 
+	sql := `SELECT tt.task_id, t.id, t.title, COALESCE(t.color, '')
 			FROM tasks.tags t
-			LEFT JOIN tasks.tasks_tags tt ON t.id = tt.tag_id
-			WHERE tt.task_id = ANY($1) OR tt.task_id IS NULL`
+			JOIN tasks.tasks_tags tt ON t.id = tt.tag_id
+			WHERE tt.task_id = ANY($1)`
 	rows, err := r.pool.Query(ctx, sql, taskIDs)
 	if err != nil {
 		return nil, err
@@ -169,7 +675,7 @@ func (r *TaskRepository) GetTagsForManyTasks(ctx context.Context, taskIDs []uuid
 	var tags []*models.TagWishTaskID
 	for rows.Next() {
 		tag := &models.TagWishTaskID{}
-		if err := rows.Scan(&tag.TaskID, &tag.Tag.ID, &tag.Tag.Title); err != nil {
+		if err := rows.Scan(&tag.TaskID, &tag.Tag.ID, &tag.Tag.Title, &tag.Tag.Color); err != nil {
 			return nil, err
 		}
 		tags = append(tags, tag)
@@ -178,32 +684,142 @@ func (r *TaskRepository) GetTagsForManyTasks(ctx context.Context, taskIDs []uuid
 }
 
 func (r *TaskRepository) GetComments(ctx context.Context, taskID uuid.UUID) ([]*models.CommentWish, error) {
-	sql := `SELECT c.id, c.task_id, c.author_id, c.content, c.created_at, c.updated_at, u.id, u.name, u.email 
+	sql := `SELECT ` + commentColumns + `
 			FROM tasks.comments c
 			JOIN users.users u ON u.id = c.author_id
-			WHERE c.task_id = $1`
+			WHERE c.task_id = $1 AND c.deleted_at IS NULL`
+	return queryComments(ctx, r.pool, sql, taskID)
+}
+
+func (r *TaskRepository) GetAttachments(ctx context.Context, taskID uuid.UUID) ([]*entities.Attachment, error) {
+	sql := `SELECT id, task_id, comment_id, uploaded_by, file_name, content_type, size, storage_key, created_at
+			FROM tasks.attachments WHERE task_id = $1
+			ORDER BY created_at`
 	rows, err := r.pool.Query(ctx, sql, taskID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var comments []*models.CommentWish
+	var attachments []*entities.Attachment
 	for rows.Next() {
-		row := &models.CommentWish{}
-		if err := rows.Scan(
-			&row.Comment.ID,
-			&row.Comment.TaskID,
-			&row.Comment.Author,
-			&row.Comment.Content,
-			&row.Comment.CreatedAt,
-			&row.Comment.UpdatedAt,
-			&row.Author.ID,
-			&row.Author.Name,
-			&row.Author.Email,
-		); err != nil {
+		attachment, err := scanAttachment(rows)
+		if err != nil {
+			return nil, err
+		}
+		attachments = append(attachments, attachment)
+	}
+	return attachments, nil
+}
+
+// AddAssignee returns pgx.ErrNoRows when the user does not exist and false
+// when the user is already assigned.
+func (r *TaskRepository) AddAssignee(ctx context.Context, taskID, userID, assignedBy uuid.UUID, assignedAt time.Time) (bool, error) {
+	var exists bool
+	sql := `SELECT EXISTS (SELECT 1 FROM users.users WHERE id = $1)`
+	if err := r.pool.QueryRow(ctx, sql, userID).Scan(&exists); err != nil {
+		return false, err
+	}
+	if !exists {
+		return false, pgx.ErrNoRows
+	}
+	sql = `INSERT INTO tasks.task_assignees (task_id, user_id, assigned_by, assigned_at)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT DO NOTHING`
+	tag, err := r.pool.Exec(ctx, sql, taskID, userID, assignedBy, assignedAt)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *TaskRepository) RemoveAssignee(ctx context.Context, taskID, userID uuid.UUID) (bool, error) {
+	sql := `DELETE FROM tasks.task_assignees WHERE task_id = $1 AND user_id = $2`
+	tag, err := r.pool.Exec(ctx, sql, taskID, userID)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *TaskRepository) GetAssignees(ctx context.Context, taskID uuid.UUID) ([]*entities.User, error) {
+	sql := `SELECT u.id, u.name, u.email
+			FROM tasks.task_assignees a
+			JOIN users.users u ON u.id = a.user_id
+			WHERE a.task_id = $1
+			ORDER BY a.assigned_at`
+	rows, err := r.pool.Query(ctx, sql, taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var users []*entities.User
+	for rows.Next() {
+		user := &entities.User{}
+		if err := rows.Scan(&user.ID, &user.Name, &user.Email); err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	return users, rows.Err()
+}
+
+// GetSubtasks returns the subtasks of the task that are not in the trash, in
+// the order they were created.
+func (r *TaskRepository) GetSubtasks(ctx context.Context, taskID uuid.UUID) ([]*entities.Task, error) {
+	sql := `SELECT id, title, status, priority, due_at
+			FROM tasks.tasks WHERE parent_id = $1 AND deleted_at IS NULL
+			ORDER BY created_at, id`
+	rows, err := r.pool.Query(ctx, sql, taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tasks []*entities.Task
+	for rows.Next() {
+		task := &entities.Task{ParentID: &taskID}
+		if err := rows.Scan(&task.ID, &task.Title, &task.Status, &task.Priority, &task.DueAt); err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, rows.Err()
+}
+
+// GetTimeTotals returns the time each user tracked on the task with finished
+// entries, most first.
+func (r *TaskRepository) GetTimeTotals(ctx context.Context, taskID uuid.UUID) ([]*models.TimeTotal, error) {
+	sql := `SELECT u.id, u.name, u.email, sum(EXTRACT(EPOCH FROM e.ended_at - e.started_at))::bigint AS seconds
+			FROM tasks.time_entries e
+			JOIN users.users u ON u.id = e.user_id
+			WHERE e.task_id = $1 AND e.ended_at IS NOT NULL
+			GROUP BY u.id
+			ORDER BY seconds DESC, u.name`
+	rows, err := r.pool.Query(ctx, sql, taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var totals []*models.TimeTotal
+	for rows.Next() {
+		total := &models.TimeTotal{}
+		if err := rows.Scan(&total.User.ID, &total.User.Name, &total.User.Email, &total.Seconds); err != nil {
 			return nil, err
 		}
-		comments = append(comments, row)
+		totals = append(totals, total)
+	}
+	return totals, rows.Err()
+}
+
+// GetParticipants returns the creator and the assignees of the task: the users
+// who follow what happens to it.
+func (r *TaskRepository) GetParticipants(ctx context.Context, taskID uuid.UUID) ([]uuid.UUID, error) {
+	sql := `SELECT created_by FROM tasks.tasks WHERE id = $1
+			UNION
+			SELECT user_id FROM tasks.task_assignees WHERE task_id = $1`
+	rows, err := r.pool.Query(ctx, sql, taskID)
+	if err != nil {
+		return nil, err
 	}
-	return comments, nil
+	return pgx.CollectRows(rows, pgx.RowTo[uuid.UUID])
 }
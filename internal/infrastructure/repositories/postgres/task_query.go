@@ -0,0 +1,101 @@
+package postgres
+
+import (
+	"strconv"
+	"strings"
+	"task-api/pkg/taskquery"
+)
+
+// taskQuery compiles a search query into a condition on tasks.tasks aliased
+// as t. Every value is passed as a query argument, never spliced into the SQL.
+type taskQuery struct {
+	args []any
+	// owner is the placeholder of the user whose tags tag: terms match.
+	owner string
+}
+
+// compileTaskQuery appends the arguments of expr after args and returns the
+// condition together with the full argument list.
+func compileTaskQuery(expr taskquery.Expr, owner string, args []any) (string, []any) {
+	q := &taskQuery{args: args, owner: owner}
+	return q.compile(expr), q.args
+}
+
+func (q *taskQuery) arg(value any) string {
+	q.args = append(q.args, value)
+	return "$" + strconv.Itoa(len(q.args))
+}
+
+func (q *taskQuery) compile(expr taskquery.Expr) string {
+	switch e := expr.(type) {
+	case taskquery.And:
+		return q.join(e, " AND ")
+	case taskquery.Or:
+		return q.join(e, " OR ")
+	case taskquery.Not:
+		// Comparisons with NULL dates are unknown; negating them must still
+		// match, so -due<2026-01-01 includes tasks without a due date.
+		return "NOT COALESCE(" + q.compile(e.Expr) + ", false)"
+	case *taskquery.Term:
+		return q.term(e)
+	default:
+		return "false"
+	}
+}
+
+func (q *taskQuery) join(exprs []taskquery.Expr, sep string) string {
+	parts := make([]string, len(exprs))
+	for i, e := range exprs {
+		parts[i] = q.compile(e)
+	}
+	return "(" + strings.Join(parts, sep) + ")"
+}
+
+func (q *taskQuery) term(t *taskquery.Term) string {
+	switch t.Field {
+	case taskquery.FieldText:
+		p := q.arg(likePattern(t.Value))
+		return "(t.title ILIKE " + p + " OR t.description ILIKE " + p + ")"
+	case taskquery.FieldTitle:
+		return "t.title ILIKE " + q.arg(likePattern(t.Value))
+	case taskquery.FieldStatus:
+		return "t.status = " + q.arg(t.Value)
+	case taskquery.FieldTag:
+		return `EXISTS (SELECT 1 FROM tasks.tasks_tags tt
+				JOIN tasks.tags g ON g.id = tt.tag_id
+				WHERE tt.task_id = t.id AND g.owner_id = ` + q.owner + ` AND lower(g.title) = lower(` + q.arg(t.Value) + `))`
+	case taskquery.FieldDue:
+		return q.date("t.due_at", t)
+	case taskquery.FieldCreated:
+		return q.date("t.created_at", t)
+	default:
+		return "false"
+	}
+}
+
+// date compares a timestamp column with a whole day: due:D is any time that
+// day, due<D is before it starts and due<=D is before the next day starts.
+func (q *taskQuery) date(column string, t *taskquery.Term) string {
+	if t.Empty {
+		return column + " IS NULL"
+	}
+	start, end := t.Date, t.Date.AddDate(0, 0, 1)
+	switch t.Op {
+	case taskquery.OpLt:
+		return column + " < " + q.arg(start)
+	case taskquery.OpLe:
+		return column + " < " + q.arg(end)
+	case taskquery.OpGt:
+		return column + " >= " + q.arg(end)
+	case taskquery.OpGe:
+		return column + " >= " + q.arg(start)
+	default:
+		return "(" + column + " >= " + q.arg(start) + " AND " + column + " < " + q.arg(end) + ")"
+	}
+}
+
+// likePattern matches the value anywhere, with LIKE wildcards taken literally.
+func likePattern(value string) string {
+	escaped := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+	return "%" + escaped + "%"
+}
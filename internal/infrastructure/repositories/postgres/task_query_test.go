@@ -0,0 +1,58 @@
+package postgres
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"task-api/pkg/taskquery"
+	"testing"
+	"time"
+)
+
+func TestCompileTaskQuery(t *testing.T) {
+	expr, err := taskquery.Parse("status:in_progress tag:backend due<2026-11-01 -tag:wontfix")
+	require.NoError(t, err)
+
+	cond, args := compileTaskQuery(expr, "$1", []any{"user"})
+
+	assert.Equal(t, `(t.status = $2 AND EXISTS (SELECT 1 FROM tasks.tasks_tags tt
+				JOIN tasks.tags g ON g.id = tt.tag_id
+				WHERE tt.task_id = t.id AND g.owner_id = $1 AND lower(g.title) = lower($3)) AND t.due_at < $4 AND NOT COALESCE(EXISTS (SELECT 1 FROM tasks.tasks_tags tt
+				JOIN tasks.tags g ON g.id = tt.tag_id
+				WHERE tt.task_id = t.id AND g.owner_id = $1 AND lower(g.title) = lower($5)), false))`, cond)
+	assert.Equal(t, []any{"user", "in_progress", "backend", time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC), "wontfix"}, args)
+}
+
+func TestCompileTaskQuery_Dates(t *testing.T) {
+	day := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	next := day.AddDate(0, 0, 1)
+	tests := []struct {
+		query string
+		cond  string
+		args  []any
+	}{
+		{query: "due:2026-03-01", cond: "(t.due_at >= $1 AND t.due_at < $2)", args: []any{day, next}},
+		{query: "due<=2026-03-01", cond: "t.due_at < $1", args: []any{next}},
+		{query: "created>2026-03-01", cond: "t.created_at >= $1", args: []any{next}},
+		{query: "created>=2026-03-01", cond: "t.created_at >= $1", args: []any{day}},
+		{query: "due:none", cond: "t.due_at IS NULL", args: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			expr, err := taskquery.Parse(tt.query)
+			require.NoError(t, err)
+			cond, args := compileTaskQuery(expr, "$0", nil)
+			assert.Equal(t, tt.cond, cond)
+			assert.Equal(t, tt.args, args)
+		})
+	}
+}
+
+func TestCompileTaskQuery_TextIsParameterized(t *testing.T) {
+	expr, err := taskquery.Parse(`"50%_off'; DROP TABLE tasks.tasks; --" OR title:a\b`)
+	require.NoError(t, err)
+
+	cond, args := compileTaskQuery(expr, "$1", nil)
+
+	assert.Equal(t, "((t.title ILIKE $1 OR t.description ILIKE $1) OR t.title ILIKE $2)", cond)
+	assert.Equal(t, []any{`%50\%\_off'; DROP TABLE tasks.tasks; --%`, `%a\\b%`}, args)
+}
@@ -0,0 +1,87 @@
+package postgres
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"task-api/internal/domain/entities"
+	"task-api/internal/domain/repositories"
+)
+
+const templateColumns = `id, owner_id, name, title, description, priority, tags, subtasks, created_at, updated_at`
+
+type TemplateRepository struct {
+	pool *pgxpool.Pool
+}
+
+var _ repositories.TemplateRepository = new(TemplateRepository)
+
+func NewTemplateRepository(pool *pgxpool.Pool) *TemplateRepository {
+	return &TemplateRepository{pool: pool}
+}
+
+func scanTemplate(row pgx.Row) (*entities.Template, error) {
+	template := &entities.Template{}
+	if err := row.Scan(
+		&template.ID,
+		&template.OwnerID,
+		&template.Name,
+		&template.Title,
+		&template.Description,
+		&template.Priority,
+		&template.Tags,
+		&template.Subtasks,
+		&template.CreatedAt,
+		&template.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+func (r *TemplateRepository) CreateTemplate(ctx context.Context, template *entities.Template) error {
+	sql := `INSERT INTO tasks.templates (owner_id, name, title, description, priority, tags, subtasks, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id`
+	return r.pool.QueryRow(ctx, sql, template.OwnerID, template.Name, template.Title, template.Description, template.Priority,
+		template.Tags, template.Subtasks, template.CreatedAt, template.UpdatedAt).Scan(&template.ID)
+}
+
+func (r *TemplateRepository) GetTemplateByID(ctx context.Context, id uuid.UUID) (*entities.Template, error) {
+	sql := `SELECT ` + templateColumns + ` FROM tasks.templates WHERE id = $1`
+	return scanTemplate(r.pool.QueryRow(ctx, sql, id))
+}
+
+func (r *TemplateRepository) GetTemplatesByOwner(ctx context.Context, ownerID uuid.UUID) ([]*entities.Template, error) {
+	sql := `SELECT ` + templateColumns + ` FROM tasks.templates WHERE owner_id = $1 ORDER BY name, created_at`
+	rows, err := r.pool.Query(ctx, sql, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var templates []*entities.Template
+	for rows.Next() {
+		template, err := scanTemplate(rows)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, template)
+	}
+	return templates, rows.Err()
+}
+
+func (r *TemplateRepository) UpdateTemplate(ctx context.Context, template *entities.Template) error {
+	sql := `UPDATE tasks.templates
+			SET name = $1, title = $2, description = $3, priority = $4, tags = $5, subtasks = $6, updated_at = $7
+			WHERE id = $8`
+	_, err := r.pool.Exec(ctx, sql, template.Name, template.Title, template.Description, template.Priority,
+		template.Tags, template.Subtasks, template.UpdatedAt, template.ID)
+	return err
+}
+
+func (r *TemplateRepository) DeleteTemplate(ctx context.Context, id uuid.UUID) error {
+	sql := `DELETE FROM tasks.templates WHERE id = $1`
+	_, err := r.pool.Exec(ctx, sql, id)
+	return err
+}
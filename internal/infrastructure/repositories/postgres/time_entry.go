@@ -0,0 +1,211 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"strconv"
+	"strings"
+	"task-api/internal/adapters/models"
+	"task-api/internal/domain/entities"
+	"task-api/internal/domain/repositories"
+	"time"
+)
+
+const timeEntryColumns = `e.id, e.task_id, e.user_id, e.started_at, e.ended_at, e.note, e.created_at`
+
+type TimeEntryRepository struct {
+	pool *pgxpool.Pool
+}
+
+var _ repositories.TimeEntryRepository = new(TimeEntryRepository)
+
+func NewTimeEntryRepository(pool *pgxpool.Pool) *TimeEntryRepository {
+	return &TimeEntryRepository{pool: pool}
+}
+
+func scanTimeEntry(row pgx.Row, dest ...any) (*entities.TimeEntry, error) {
+	entry := &entities.TimeEntry{}
+	dest = append([]any{&entry.ID, &entry.TaskID, &entry.UserID, &entry.StartedAt, &entry.EndedAt, &entry.Note, &entry.CreatedAt}, dest...)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func scanTimeEntryWithUser(row pgx.Row) (*models.TimeEntry, error) {
+	model := &models.TimeEntry{}
+	entry, err := scanTimeEntry(row, &model.User.ID, &model.User.Name, &model.User.Email)
+	if err != nil {
+		return nil, err
+	}
+	model.Entry = *entry
+	return model, nil
+}
+
+// StartTimer returns false when the user already has a running timer.
+func (r *TimeEntryRepository) StartTimer(ctx context.Context, entry *entities.TimeEntry) (bool, error) {
+	sql := `INSERT INTO tasks.time_entries (task_id, user_id, started_at, note, created_at)
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (user_id) WHERE ended_at IS NULL DO NOTHING
+			RETURNING id`
+	err := r.pool.QueryRow(ctx, sql, entry.TaskID, entry.UserID, entry.StartedAt, entry.Note, entry.CreatedAt).Scan(&entry.ID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// StopTimer returns pgx.ErrNoRows when the user has no timer running on the task.
+func (r *TimeEntryRepository) StopTimer(ctx context.Context, taskID, userID uuid.UUID, endedAt time.Time) (*entities.TimeEntry, error) {
+	sql := `UPDATE tasks.time_entries e SET ended_at = GREATEST($3, e.started_at)
+			WHERE e.task_id = $1 AND e.user_id = $2 AND e.ended_at IS NULL
+			RETURNING ` + timeEntryColumns
+	return scanTimeEntry(r.pool.QueryRow(ctx, sql, taskID, userID, endedAt))
+}
+
+func (r *TimeEntryRepository) CreateEntry(ctx context.Context, entry *entities.TimeEntry) error {
+	sql := `INSERT INTO tasks.time_entries (task_id, user_id, started_at, ended_at, note, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
+	return r.pool.QueryRow(ctx, sql, entry.TaskID, entry.UserID, entry.StartedAt, entry.EndedAt, entry.Note, entry.CreatedAt).Scan(&entry.ID)
+}
+
+func (r *TimeEntryRepository) GetEntryByID(ctx context.Context, id uuid.UUID) (*models.TimeEntry, error) {
+	sql := `SELECT ` + timeEntryColumns + `, u.id, u.name, u.email
+			FROM tasks.time_entries e
+			JOIN users.users u ON u.id = e.user_id
+			WHERE e.id = $1`
+	return scanTimeEntryWithUser(r.pool.QueryRow(ctx, sql, id))
+}
+
+// GetRunningEntry returns pgx.ErrNoRows when the user has no running timer.
+func (r *TimeEntryRepository) GetRunningEntry(ctx context.Context, userID uuid.UUID) (*models.TimeEntry, error) {
+	sql := `SELECT ` + timeEntryColumns + `, u.id, u.name, u.email
+			FROM tasks.time_entries e
+			JOIN users.users u ON u.id = e.user_id
+			WHERE e.user_id = $1 AND e.ended_at IS NULL`
+	return scanTimeEntryWithUser(r.pool.QueryRow(ctx, sql, userID))
+}
+
+// GetEntriesByTask returns the entries of all users on the task, latest first.
+func (r *TimeEntryRepository) GetEntriesByTask(ctx context.Context, taskID uuid.UUID) ([]*models.TimeEntry, error) {
+	sql := `SELECT ` + timeEntryColumns + `, u.id, u.name, u.email
+			FROM tasks.time_entries e
+			JOIN users.users u ON u.id = e.user_id
+			WHERE e.task_id = $1
+			ORDER BY e.started_at DESC, e.id`
+	rows, err := r.pool.Query(ctx, sql, taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []*models.TimeEntry
+	for rows.Next() {
+		entry, err := scanTimeEntryWithUser(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
+
+func (r *TimeEntryRepository) DeleteEntry(ctx context.Context, id uuid.UUID) error {
+	sql := `DELETE FROM tasks.time_entries WHERE id = $1`
+	_, err := r.pool.Exec(ctx, sql, id)
+	return err
+}
+
+// GetTimeReport sums the finished entries matching the filter. Rows are
+// ordered by the groupings in the order they were given: users by name, tags
+// by title and days by date.
+func (r *TimeEntryRepository) GetTimeReport(ctx context.Context, filter models.TimeReportFilter) ([]*models.TimeReportRow, error) {
+	args := []any{filter.ViewerID, filter.From, filter.To}
+	arg := func(value any) string {
+		args = append(args, value)
+		return "$" + strconv.Itoa(len(args))
+	}
+
+	from := `tasks.time_entries e
+			JOIN tasks.tasks t ON t.id = e.task_id`
+	where := []string{
+		`t.deleted_at IS NULL`,
+		`(t.created_by = $1 OR EXISTS (
+				SELECT 1 FROM tasks.task_assignees a WHERE a.task_id = t.id AND a.user_id = $1))`,
+		`e.ended_at IS NOT NULL`,
+		`e.started_at >= $2 AND e.started_at < $3`,
+	}
+	if filter.UserID != nil {
+		where = append(where, `e.user_id = `+arg(*filter.UserID))
+	}
+	if filter.Tag != "" {
+		where = append(where, `EXISTS (SELECT 1 FROM tasks.tasks_tags tt
+				JOIN tasks.tags g ON g.id = tt.tag_id
+				WHERE tt.task_id = t.id AND g.owner_id = $1 AND lower(g.title) = lower(`+arg(filter.Tag)+`))`)
+	}
+
+	var columns, groups, order []string
+	for _, group := range filter.GroupBy {
+		switch group {
+		case models.TimeGroupUser:
+			from += `
+			JOIN users.users u ON u.id = e.user_id`
+			columns = append(columns, `u.id`, `u.name`, `u.email`)
+			groups = append(groups, `u.id`)
+			order = append(order, `u.name`, `u.id`)
+		case models.TimeGroupTag:
+			// One row per tag of the viewer on the task, or a single row
+			// without a title for tasks that have none.
+			from += `
+			LEFT JOIN LATERAL (
+				SELECT g.title FROM tasks.tasks_tags tt
+				JOIN tasks.tags g ON g.id = tt.tag_id
+				WHERE tt.task_id = t.id AND g.owner_id = $1) tag ON true`
+			columns = append(columns, `COALESCE(tag.title, '')`)
+			groups = append(groups, `COALESCE(tag.title, '')`)
+			order = append(order, `COALESCE(tag.title, '')`)
+		case models.TimeGroupDay:
+			columns = append(columns, `date_trunc('day', e.started_at)`)
+			groups = append(groups, `date_trunc('day', e.started_at)`)
+			order = append(order, `date_trunc('day', e.started_at)`)
+		}
+	}
+
+	sql := `SELECT ` + strings.Join(append(columns, `COALESCE(sum(EXTRACT(EPOCH FROM e.ended_at - e.started_at)), 0)::bigint`, `count(*)`), ", ") + `
+			FROM ` + from + `
+			WHERE ` + strings.Join(where, " AND ")
+	if len(groups) > 0 {
+		sql += `
+			GROUP BY ` + strings.Join(groups, ", ") + `
+			ORDER BY ` + strings.Join(order, ", ")
+	}
+	rows, err := r.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var report []*models.TimeReportRow
+	for rows.Next() {
+		row := &models.TimeReportRow{}
+		var dest []any
+		for _, group := range filter.GroupBy {
+			switch group {
+			case models.TimeGroupUser:
+				dest = append(dest, &row.User.ID, &row.User.Name, &row.User.Email)
+			case models.TimeGroupTag:
+				dest = append(dest, &row.Tag)
+			case models.TimeGroupDay:
+				dest = append(dest, &row.Day)
+			}
+		}
+		if err := rows.Scan(append(dest, &row.Seconds, &row.Entries)...); err != nil {
+			return nil, err
+		}
+		report = append(report, row)
+	}
+	return report, rows.Err()
+}
@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"task-api/internal/domain/entities"
 	"task-api/internal/domain/repositories"
@@ -25,20 +26,20 @@ func (u *UserRepository) Create(ctx context.Context, user *entities.User) error
 }
 
 func (u *UserRepository) GetById(ctx context.Context, id uuid.UUID) (*entities.User, error) {
-	sql := `SELECT id, name, email, password, created_at, updated_at FROM users.users WHERE id = $1`
+	sql := `SELECT id, name, email, password, role, created_at, updated_at FROM users.users WHERE id = $1`
 	row := u.pool.QueryRow(ctx, sql, id)
 	user := &entities.User{}
-	if err := row.Scan(&user.ID, &user.Name, &user.Email, &user.Password, &user.CreatedAt, &user.UpdatedAt); err != nil {
+	if err := row.Scan(&user.ID, &user.Name, &user.Email, &user.Password, &user.Role, &user.CreatedAt, &user.UpdatedAt); err != nil {
 		return nil, err
 	}
 	return user, nil
 }
 
 func (u *UserRepository) GetByEmail(ctx context.Context, email string) (*entities.User, error) {
-	sql := `SELECT id, name, email, password, created_at, updated_at FROM users.users WHERE email = $1`
+	sql := `SELECT id, name, email, password, role, created_at, updated_at FROM users.users WHERE email = $1`
 	row := u.pool.QueryRow(ctx, sql, email)
 	user := &entities.User{}
-	if err := row.Scan(&user.ID, &user.Name, &user.Email, &user.Password, &user.CreatedAt, &user.UpdatedAt); err != nil {
+	if err := row.Scan(&user.ID, &user.Name, &user.Email, &user.Password, &user.Role, &user.CreatedAt, &user.UpdatedAt); err != nil {
 		return nil, err
 	}
 	return user, nil
@@ -58,3 +59,25 @@ func (u *UserRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	_, err := u.pool.Exec(ctx, sql, id)
 	return err
 }
+
+// ResolveMentions maps lower-cased @handles to user IDs. A handle with an @ is
+// matched against the e-mail address; any other handle against the name or the
+// local part of the e-mail, and only when exactly one user matches.
+func (u *UserRepository) ResolveMentions(ctx context.Context, handles []string) ([]uuid.UUID, error) {
+	sql := `SELECT u.id
+			FROM unnest($1::text[]) AS h
+			JOIN users.users u ON lower(u.email) = h
+			WHERE position('@' in h) > 0
+			UNION
+			SELECT min(u.id::text)::uuid
+			FROM unnest($1::text[]) AS h
+			JOIN users.users u ON lower(u.name) = h OR lower(split_part(u.email, '@', 1)) = h
+			WHERE position('@' in h) = 0
+			GROUP BY h
+			HAVING count(DISTINCT u.id) = 1`
+	rows, err := u.pool.Query(ctx, sql, handles)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowTo[uuid.UUID])
+}
@@ -0,0 +1,134 @@
+package postgres
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"task-api/internal/domain/entities"
+	"task-api/internal/domain/repositories"
+	"time"
+)
+
+const viewColumns = `v.id, v.owner_id, v.name, v.filter, v.created_at, v.updated_at`
+
+type ViewRepository struct {
+	pool *pgxpool.Pool
+}
+
+var _ repositories.ViewRepository = new(ViewRepository)
+
+func NewViewRepository(pool *pgxpool.Pool) *ViewRepository {
+	return &ViewRepository{pool: pool}
+}
+
+func scanView(row pgx.Row) (*entities.View, error) {
+	view := &entities.View{}
+	if err := row.Scan(&view.ID, &view.OwnerID, &view.Name, &view.Filter, &view.CreatedAt, &view.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return view, nil
+}
+
+func (r *ViewRepository) CreateView(ctx context.Context, view *entities.View) error {
+	sql := `INSERT INTO tasks.views (owner_id, name, filter, created_at, updated_at) VALUES ($1, $2, $3, $4, $5) RETURNING id`
+	return r.pool.QueryRow(ctx, sql, view.OwnerID, view.Name, view.Filter, view.CreatedAt, view.UpdatedAt).Scan(&view.ID)
+}
+
+func (r *ViewRepository) GetViewByID(ctx context.Context, id uuid.UUID) (*entities.View, error) {
+	sql := `SELECT ` + viewColumns + ` FROM tasks.views v WHERE v.id = $1`
+	return scanView(r.pool.QueryRow(ctx, sql, id))
+}
+
+// GetViewsForUser returns the views the user owns or that are shared with
+// them, ordered by name.
+func (r *ViewRepository) GetViewsForUser(ctx context.Context, userID uuid.UUID) ([]*entities.View, error) {
+	sql := `SELECT ` + viewColumns + ` FROM tasks.views v
+			WHERE v.owner_id = $1 OR EXISTS (
+				SELECT 1 FROM tasks.view_shares s WHERE s.view_id = v.id AND s.user_id = $1)
+			ORDER BY v.name, v.created_at`
+	rows, err := r.pool.Query(ctx, sql, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var views []*entities.View
+	for rows.Next() {
+		view, err := scanView(rows)
+		if err != nil {
+			return nil, err
+		}
+		views = append(views, view)
+	}
+	return views, rows.Err()
+}
+
+func (r *ViewRepository) UpdateView(ctx context.Context, view *entities.View) error {
+	sql := `UPDATE tasks.views SET name = $1, filter = $2, updated_at = $3 WHERE id = $4`
+	_, err := r.pool.Exec(ctx, sql, view.Name, view.Filter, view.UpdatedAt, view.ID)
+	return err
+}
+
+func (r *ViewRepository) DeleteView(ctx context.Context, id uuid.UUID) error {
+	sql := `DELETE FROM tasks.views WHERE id = $1`
+	_, err := r.pool.Exec(ctx, sql, id)
+	return err
+}
+
+func (r *ViewRepository) IsSharedWith(ctx context.Context, viewID, userID uuid.UUID) (bool, error) {
+	var shared bool
+	sql := `SELECT EXISTS (SELECT 1 FROM tasks.view_shares WHERE view_id = $1 AND user_id = $2)`
+	err := r.pool.QueryRow(ctx, sql, viewID, userID).Scan(&shared)
+	return shared, err
+}
+
+func (r *ViewRepository) GetShares(ctx context.Context, viewID uuid.UUID) ([]entities.User, error) {
+	sql := `SELECT u.id, u.name, u.email
+			FROM tasks.view_shares s
+			JOIN users.users u ON u.id = s.user_id
+			WHERE s.view_id = $1
+			ORDER BY s.shared_at`
+	rows, err := r.pool.Query(ctx, sql, viewID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []entities.User
+	for rows.Next() {
+		var user entities.User
+		if err := rows.Scan(&user.ID, &user.Name, &user.Email); err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	return users, rows.Err()
+}
+
+// ShareView returns pgx.ErrNoRows when the user does not exist. Sharing again
+// with the same user changes nothing.
+func (r *ViewRepository) ShareView(ctx context.Context, viewID, userID uuid.UUID, sharedAt time.Time) error {
+	var exists bool
+	sql := `SELECT EXISTS (SELECT 1 FROM users.users WHERE id = $1)`
+	if err := r.pool.QueryRow(ctx, sql, userID).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		return pgx.ErrNoRows
+	}
+	sql = `INSERT INTO tasks.view_shares (view_id, user_id, shared_at)
+			VALUES ($1, $2, $3)
+			ON CONFLICT DO NOTHING`
+	_, err := r.pool.Exec(ctx, sql, viewID, userID, sharedAt)
+	return err
+}
+
+func (r *ViewRepository) UnshareView(ctx context.Context, viewID, userID uuid.UUID) (bool, error) {
+	sql := `DELETE FROM tasks.view_shares WHERE view_id = $1 AND user_id = $2`
+	tag, err := r.pool.Exec(ctx, sql, viewID, userID)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
@@ -0,0 +1,76 @@
+package security
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+const AccessTokenPrefix = "tapi_"
+
+const (
+	ScopeTasksRead          = "tasks:read"
+	ScopeTasksWrite         = "tasks:write"
+	ScopeTagsRead           = "tags:read"
+	ScopeTagsWrite          = "tags:write"
+	ScopeCommentsRead       = "comments:read"
+	ScopeCommentsWrite      = "comments:write"
+	ScopeUsersRead          = "users:read"
+	ScopeUsersWrite         = "users:write"
+	ScopeNotificationsRead  = "notifications:read"
+	ScopeNotificationsWrite = "notifications:write"
+	ScopeViewsRead          = "views:read"
+	ScopeViewsWrite         = "views:write"
+	ScopeTemplatesRead      = "templates:read"
+	ScopeTemplatesWrite     = "templates:write"
+	ScopeTimeRead           = "time:read"
+	ScopeTimeWrite          = "time:write"
+)
+
+var Scopes = []string{
+	ScopeTasksRead,
+	ScopeTasksWrite,
+	ScopeTagsRead,
+	ScopeTagsWrite,
+	ScopeCommentsRead,
+	ScopeCommentsWrite,
+	ScopeUsersRead,
+	ScopeUsersWrite,
+	ScopeNotificationsRead,
+	ScopeNotificationsWrite,
+	ScopeViewsRead,
+	ScopeViewsWrite,
+	ScopeTemplatesRead,
+	ScopeTemplatesWrite,
+	ScopeTimeRead,
+	ScopeTimeWrite,
+}
+
+func IsValidScope(scope string) bool {
+	for _, s := range Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+func IsAccessToken(tokenStr string) bool {
+	return strings.HasPrefix(tokenStr, AccessTokenPrefix)
+}
+
+// GenerateAccessToken returns the raw token shown to the user once, a short
+// display prefix and the hash that is persisted instead of the token itself.
+func GenerateAccessToken() (raw, prefix, hash string, err error) {
+	secret, err := RandomString(32)
+	if err != nil {
+		return "", "", "", err
+	}
+	raw = AccessTokenPrefix + secret
+	return raw, raw[:len(AccessTokenPrefix)+6], HashAccessToken(raw), nil
+}
+
+func HashAccessToken(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
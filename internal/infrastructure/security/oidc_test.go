@@ -0,0 +1,159 @@
+package security_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"task-api/internal/infrastructure/security"
+	"task-api/pkg/config"
+	"testing"
+	"time"
+)
+
+// mockOIDCProvider is a minimal in-process identity provider: discovery, JWKS and token endpoints.
+type mockOIDCProvider struct {
+	server    *httptest.Server
+	key       *rsa.PrivateKey
+	clientID  string
+	challenge string
+	nonce     string
+	subject   string
+}
+
+func newMockOIDCProvider(t *testing.T, clientID string) *mockOIDCProvider {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	p := &mockOIDCProvider{key: key, clientID: clientID, subject: "employee-42"}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 p.server.URL,
+			"authorization_endpoint": p.server.URL + "/authorize",
+			"token_endpoint":         p.server.URL + "/token",
+			"jwks_uri":               p.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"keys": []map[string]string{{
+				"kid": "test-key",
+				"kty": "RSA",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		if r.PostForm.Get("code") != "valid-code" || security.PKCEChallenge(r.PostForm.Get("code_verifier")) != p.challenge {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		claims := security.OIDCClaims{
+			Nonce:         p.nonce,
+			Email:         "employee@corp.example",
+			EmailVerified: true,
+			Name:          "Employee",
+			RegisteredClaims: jwt.RegisteredClaims{
+				Issuer:    p.server.URL,
+				Subject:   p.subject,
+				Audience:  jwt.ClaimStrings{p.clientID},
+				ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+				IssuedAt:  jwt.NewNumericDate(time.Now()),
+			},
+		}
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+		token.Header["kid"] = "test-key"
+		signed, err := token.SignedString(key)
+		require.NoError(t, err)
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token": "provider-access-token",
+			"token_type":   "Bearer",
+			"id_token":     signed,
+			"expires_in":   60,
+		})
+	})
+	p.server = httptest.NewServer(mux)
+	t.Cleanup(p.server.Close)
+	return p
+}
+
+func TestOIDCProvider_AuthorizationCodeFlow(t *testing.T) {
+	mock := newMockOIDCProvider(t, "task-api")
+	provider := security.NewOIDCProvider(config.OIDC{
+		Provider:    "corporate",
+		IssuerURL:   mock.server.URL,
+		ClientID:    "task-api",
+		RedirectURL: "http://localhost:8080/api/v1/auth/oidc/callback",
+		Scopes:      []string{"openid", "email", "profile"},
+	})
+	ctx := context.Background()
+
+	verifier, err := security.RandomString(64)
+	require.NoError(t, err)
+	mock.challenge = security.PKCEChallenge(verifier)
+	mock.nonce = "nonce-1"
+
+	authURL, err := provider.AuthCodeURL(ctx, "state-1", mock.nonce, mock.challenge)
+	require.NoError(t, err)
+	parsed, err := url.Parse(authURL)
+	require.NoError(t, err)
+	assert.Equal(t, "/authorize", parsed.Path)
+	assert.Equal(t, "S256", parsed.Query().Get("code_challenge_method"))
+	assert.Equal(t, mock.challenge, parsed.Query().Get("code_challenge"))
+	assert.Equal(t, "state-1", parsed.Query().Get("state"))
+	assert.Equal(t, "openid email profile", parsed.Query().Get("scope"))
+
+	token, err := provider.Exchange(ctx, "valid-code", verifier)
+	require.NoError(t, err)
+
+	claims, err := provider.VerifyIDToken(ctx, token.IDToken, mock.nonce)
+	require.NoError(t, err)
+	assert.Equal(t, "employee-42", claims.Subject)
+	assert.Equal(t, "employee@corp.example", claims.Email)
+	assert.True(t, claims.EmailVerified)
+}
+
+func TestOIDCProvider_RejectsWrongVerifierAndNonce(t *testing.T) {
+	mock := newMockOIDCProvider(t, "task-api")
+	provider := security.NewOIDCProvider(config.OIDC{
+		IssuerURL: mock.server.URL,
+		ClientID:  "task-api",
+	})
+	ctx := context.Background()
+	mock.challenge = security.PKCEChallenge("expected-verifier")
+	mock.nonce = "nonce-1"
+
+	_, err := provider.Exchange(ctx, "valid-code", "other-verifier")
+	assert.Error(t, err)
+
+	token, err := provider.Exchange(ctx, "valid-code", "expected-verifier")
+	require.NoError(t, err)
+	_, err = provider.VerifyIDToken(ctx, token.IDToken, "another-nonce")
+	assert.Error(t, err)
+}
+
+func TestOIDCStateStore_PopOnce(t *testing.T) {
+	store := security.NewOIDCStateStore()
+	store.Save("state", security.OIDCState{Nonce: "n", CodeVerifier: "v", ExpiresAt: time.Now().Add(time.Minute)})
+	store.Save("expired", security.OIDCState{ExpiresAt: time.Now().Add(-time.Minute)})
+
+	value, ok := store.Pop("state")
+	require.True(t, ok)
+	assert.Equal(t, "v", value.CodeVerifier)
+
+	_, ok = store.Pop("state")
+	assert.False(t, ok)
+	_, ok = store.Pop("expired")
+	assert.False(t, ok)
+}
@@ -0,0 +1,40 @@
+package storage_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"strings"
+	"task-api/internal/infrastructure/storage"
+	"testing"
+)
+
+func TestLocalStore_RoundTrip(t *testing.T) {
+	store, err := storage.NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	require.NoError(t, store.Put(ctx, "tasks/1/a", strings.NewReader("log line"), 8, "text/plain"))
+
+	rc, err := store.Get(ctx, "tasks/1/a")
+	require.NoError(t, err)
+	got, err := io.ReadAll(rc)
+	require.NoError(t, err)
+	rc.Close()
+	assert.Equal(t, "log line", string(got))
+
+	require.NoError(t, store.Delete(ctx, "tasks/1/a"))
+	require.NoError(t, store.Delete(ctx, "tasks/1/a"))
+	_, err = store.Get(ctx, "tasks/1/a")
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+}
+
+func TestLocalStore_RejectsTraversal(t *testing.T) {
+	store, err := storage.NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+
+	for _, key := range []string{"../x", "/abs", "a/../../x", "a//b", ""} {
+		assert.Error(t, store.Put(context.Background(), key, strings.NewReader(""), 0, ""), key)
+	}
+}
@@ -0,0 +1,92 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"task-api/internal/domain/entities"
+	"task-api/internal/domain/repositories"
+	"task-api/internal/infrastructure/metrics"
+	"task-api/internal/infrastructure/security"
+	"time"
+)
+
+var (
+	ErrAccessTokenInvalid  = errors.New("invalid access token")
+	ErrAccessTokenNotFound = errors.New("access token not found")
+)
+
+// lastUsedResolution limits how often last_used_at is written for a busy token.
+const lastUsedResolution = time.Minute
+
+type AccessTokenUseCase interface {
+	Create(ctx context.Context, token *entities.AccessToken) (string, *entities.AccessToken, error)
+	GetAll(ctx context.Context, userID uuid.UUID) ([]*entities.AccessToken, error)
+	Revoke(ctx context.Context, userID, id uuid.UUID) error
+	Authenticate(ctx context.Context, raw string) (*entities.AccessToken, error)
+}
+
+type accessTokenUseCase struct {
+	repo repositories.AccessTokenRepository
+}
+
+func NewAccessTokenUseCase(repo repositories.AccessTokenRepository) AccessTokenUseCase {
+	return &accessTokenUseCase{repo: repo}
+}
+
+func (a *accessTokenUseCase) Create(ctx context.Context, token *entities.AccessToken) (string, *entities.AccessToken, error) {
+	if len(token.Scopes) == 0 {
+		return "", nil, errors.New("at least one scope is required")
+	}
+	for _, scope := range token.Scopes {
+		if !security.IsValidScope(scope) {
+			return "", nil, errors.New("unknown scope: " + scope)
+		}
+	}
+	raw, prefix, hash, err := security.GenerateAccessToken()
+	if err != nil {
+		return "", nil, err
+	}
+	token.Prefix = prefix
+	token.TokenHash = hash
+	token.CreatedAt = time.Now()
+	if err := a.repo.Create(ctx, token); err != nil {
+		return "", nil, err
+	}
+	return raw, token, nil
+}
+
+func (a *accessTokenUseCase) GetAll(ctx context.Context, userID uuid.UUID) ([]*entities.AccessToken, error) {
+	return a.repo.GetAllByUserID(ctx, userID)
+}
+
+func (a *accessTokenUseCase) Revoke(ctx context.Context, userID, id uuid.UUID) error {
+	revoked, err := a.repo.Revoke(ctx, id, userID, time.Now())
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return ErrAccessTokenNotFound
+	}
+	return nil
+}
+
+func (a *accessTokenUseCase) Authenticate(ctx context.Context, raw string) (*entities.AccessToken, error) {
+	token, err := a.repo.GetByHash(ctx, security.HashAccessToken(raw))
+	if err != nil {
+		metrics.LoginsFailed.WithLabelValues("access_token").Inc()
+		return nil, ErrAccessTokenInvalid
+	}
+	now := time.Now()
+	if token.RevokedAt != nil || now.After(token.ExpiresAt) {
+		metrics.LoginsFailed.WithLabelValues("access_token").Inc()
+		return nil, ErrAccessTokenInvalid
+	}
+	if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) > lastUsedResolution {
+		if err := a.repo.UpdateLastUsed(ctx, token.ID, now); err != nil {
+			return nil, err
+		}
+		token.LastUsedAt = &now
+	}
+	return token, nil
+}
@@ -0,0 +1,150 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"io"
+	"task-api/internal/domain/entities"
+	"task-api/internal/domain/repositories"
+	"task-api/internal/infrastructure/storage"
+	"time"
+)
+
+var (
+	ErrAttachmentNotFound  = errors.New("attachment not found")
+	ErrAttachmentForbidden = errors.New("only the uploader or the task creator can delete an attachment")
+)
+
+type AttachmentUseCase interface {
+	Upload(ctx context.Context, attachment *entities.Attachment, content io.Reader) (*entities.Attachment, error)
+	GetAllByTaskID(ctx context.Context, taskID, userID uuid.UUID) ([]*entities.Attachment, error)
+	Open(ctx context.Context, id, userID uuid.UUID) (*entities.Attachment, io.ReadCloser, error)
+	Delete(ctx context.Context, id, userID uuid.UUID) error
+	PurgeForDeletedTasks(ctx context.Context, before time.Time) (int, error)
+}
+
+type attachmentUseCase struct {
+	repo  repositories.AttachmentRepository
+	tasks repositories.TaskRepository
+	blobs storage.BlobStore
+}
+
+func NewAttachmentUseCase(repo repositories.AttachmentRepository, tasks repositories.TaskRepository, blobs storage.BlobStore) AttachmentUseCase {
+	return &attachmentUseCase{repo: repo, tasks: tasks, blobs: blobs}
+}
+
+// Upload attaches a file on behalf of attachment.UploadedBy, who must see the
+// task. The content is stored before the metadata row, so a listed attachment
+// always has a blob; the blob is removed again if the row cannot be created.
+func (a *attachmentUseCase) Upload(ctx context.Context, attachment *entities.Attachment, content io.Reader) (*entities.Attachment, error) {
+	if err := a.checkTaskVisible(ctx, attachment.TaskID, attachment.UploadedBy); err != nil {
+		return nil, err
+	}
+	attachment.ID = uuid.New()
+	attachment.StorageKey = "tasks/" + attachment.TaskID.String() + "/" + attachment.ID.String()
+	attachment.CreatedAt = time.Now()
+
+	if err := a.blobs.Put(ctx, attachment.StorageKey, content, attachment.Size, attachment.ContentType); err != nil {
+		return nil, err
+	}
+	if err := a.repo.Create(ctx, attachment); err != nil {
+		_ = a.blobs.Delete(context.WithoutCancel(ctx), attachment.StorageKey)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrTaskNotFound
+		}
+		return nil, err
+	}
+	return attachment, nil
+}
+
+func (a *attachmentUseCase) GetAllByTaskID(ctx context.Context, taskID, userID uuid.UUID) ([]*entities.Attachment, error) {
+	if err := a.checkTaskVisible(ctx, taskID, userID); err != nil {
+		return nil, err
+	}
+	return a.repo.GetAllByTaskID(ctx, taskID)
+}
+
+func (a *attachmentUseCase) Open(ctx context.Context, id, userID uuid.UUID) (*entities.Attachment, io.ReadCloser, error) {
+	attachment, err := a.get(ctx, id, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	content, err := a.blobs.Get(ctx, attachment.StorageKey)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, nil, ErrAttachmentNotFound
+		}
+		return nil, nil, err
+	}
+	return attachment, content, nil
+}
+
+// Delete removes an attachment of a task the user can see; only the uploader
+// and the creator of the task may do so.
+func (a *attachmentUseCase) Delete(ctx context.Context, id, userID uuid.UUID) error {
+	attachment, err := a.get(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+	if attachment.UploadedBy != userID {
+		task, err := a.tasks.GetTaskByID(ctx, attachment.TaskID)
+		if err != nil {
+			return err
+		}
+		if task.Task.CreatedBy != userID {
+			return ErrAttachmentForbidden
+		}
+	}
+	if err := a.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+	return a.blobs.Delete(ctx, attachment.StorageKey)
+}
+
+// get returns the attachment when the user can see its task; otherwise it is
+// reported as not found.
+func (a *attachmentUseCase) get(ctx context.Context, id, userID uuid.UUID) (*entities.Attachment, error) {
+	attachment, err := a.repo.GetByID(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrAttachmentNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	visible, err := a.tasks.IsVisibleTo(ctx, attachment.TaskID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !visible {
+		return nil, ErrAttachmentNotFound
+	}
+	return attachment, nil
+}
+
+func (a *attachmentUseCase) checkTaskVisible(ctx context.Context, taskID, userID uuid.UUID) error {
+	visible, err := a.tasks.IsVisibleTo(ctx, taskID, userID)
+	if err != nil {
+		return err
+	}
+	if !visible {
+		return ErrTaskNotFound
+	}
+	return nil
+}
+
+// PurgeForDeletedTasks removes blobs of tasks that are about to be purged from the
+// trash. The rows themselves go away with the task through ON DELETE CASCADE.
+func (a *attachmentUseCase) PurgeForDeletedTasks(ctx context.Context, before time.Time) (int, error) {
+	attachments, err := a.repo.GetAllForDeletedTasks(ctx, before)
+	if err != nil {
+		return 0, err
+	}
+	for _, attachment := range attachments {
+		if err := a.blobs.Delete(ctx, attachment.StorageKey); err != nil {
+			return 0, err
+		}
+	}
+	return len(attachments), nil
+}
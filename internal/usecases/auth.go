@@ -4,35 +4,41 @@ import (
 	"context"
 	"errors"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"golang.org/x/crypto/bcrypt"
 	"task-api/internal/domain/entities"
 	"task-api/internal/domain/repositories"
+	"task-api/internal/infrastructure/metrics"
 	"time"
 )
 
 type AuthUseCase interface {
 	Login(ctx context.Context, email, password string) (*entities.User, error)
 	Register(ctx context.Context, user *entities.User) (*entities.User, error)
+	LoginExternal(ctx context.Context, identity *entities.Identity, profile *entities.User, emailVerified bool) (*entities.User, error)
 	CreateRefreshToken(ctx context.Context, userID uuid.UUID, ExpiresAt time.Time) (*entities.RefreshToken, error)
 	GetRefreshToken(ctx context.Context, tokenID string) (*entities.RefreshToken, error)
 	DeleteRefreshToken(ctx context.Context, token string) error
 }
 
 type authUseCase struct {
-	repoUser   repositories.UserRepository
-	repoRefTok repositories.RefreshTokenRepository
+	repoUser     repositories.UserRepository
+	repoRefTok   repositories.RefreshTokenRepository
+	repoIdentity repositories.IdentityRepository
 }
 
-func NewAuthUseCase(repoUser repositories.UserRepository, repoRefTok repositories.RefreshTokenRepository) AuthUseCase {
-	return &authUseCase{repoUser: repoUser, repoRefTok: repoRefTok}
+func NewAuthUseCase(repoUser repositories.UserRepository, repoRefTok repositories.RefreshTokenRepository, repoIdentity repositories.IdentityRepository) AuthUseCase {
+	return &authUseCase{repoUser: repoUser, repoRefTok: repoRefTok, repoIdentity: repoIdentity}
 }
 
 func (a *authUseCase) Login(ctx context.Context, email, password string) (*entities.User, error) {
 	user, err := a.repoUser.GetByEmail(ctx, email)
 	if err != nil || user == nil {
+		metrics.LoginsFailed.WithLabelValues("password").Inc()
 		return nil, errors.New("invalid credentials")
 	}
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
+		metrics.LoginsFailed.WithLabelValues("password").Inc()
 		return nil, errors.New("invalid credentials")
 	}
 	return user, nil
@@ -52,6 +58,7 @@ func (a *authUseCase) Register(ctx context.Context, user *entities.User) (*entit
 	if err := a.repoUser.Create(ctx, user); err != nil {
 		return nil, err
 	}
+	metrics.UsersRegistered.WithLabelValues("password").Inc()
 	create, err := a.repoUser.GetById(ctx, user.ID)
 	if err != nil {
 		return nil, err
@@ -59,6 +66,53 @@ func (a *authUseCase) Register(ctx context.Context, user *entities.User) (*entit
 	return create, nil
 }
 
+func (a *authUseCase) LoginExternal(ctx context.Context, identity *entities.Identity, profile *entities.User, emailVerified bool) (*entities.User, error) {
+	existing, err := a.repoIdentity.GetByProviderSubject(ctx, identity.Provider, identity.Subject)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+	if existing != nil {
+		if existing.Email != identity.Email {
+			existing.Email = identity.Email
+			existing.UpdatedAt = time.Now()
+			if err := a.repoIdentity.Update(ctx, existing); err != nil {
+				return nil, err
+			}
+		}
+		return a.repoUser.GetById(ctx, existing.UserID)
+	}
+
+	user, err := a.repoUser.GetByEmail(ctx, profile.Email)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+	if user != nil && !emailVerified {
+		metrics.LoginsFailed.WithLabelValues("oidc").Inc()
+		return nil, errors.New("email already exists")
+	}
+	if user == nil {
+		// Users provisioned via SSO have no local password and can only sign in through the provider
+		user = &entities.User{
+			Name:      profile.Name,
+			Email:     profile.Email,
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		}
+		if err := a.repoUser.Create(ctx, user); err != nil {
+			return nil, err
+		}
+		metrics.UsersRegistered.WithLabelValues("oidc").Inc()
+	}
+
+	identity.UserID = user.ID
+	identity.CreatedAt = time.Now()
+	identity.UpdatedAt = time.Now()
+	if err := a.repoIdentity.Create(ctx, identity); err != nil {
+		return nil, err
+	}
+	return a.repoUser.GetById(ctx, user.ID)
+}
+
 func (a *authUseCase) CreateRefreshToken(ctx context.Context, userID uuid.UUID, ExpiresAt time.Time) (*entities.RefreshToken, error) {
 	refreshToken := &entities.RefreshToken{
 		Token:     uuid.New(),
@@ -2,58 +2,374 @@ package usecases
 
 import (
 	"context"
+	"errors"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"task-api/internal/adapters/models"
 	"task-api/internal/domain/entities"
+	"task-api/internal/domain/events"
 	"task-api/internal/domain/repositories"
+	"task-api/internal/infrastructure/metrics"
+	emojis "task-api/pkg/emoji"
+	"task-api/pkg/mention"
+	"time"
+)
+
+var (
+	ErrCommentNotFound       = errors.New("comment not found")
+	ErrParentCommentNotFound = errors.New("parent comment not found in this task")
+	ErrInvalidEmoji          = errors.New("reaction must be a single emoji")
+	ErrCommentForbidden      = errors.New("not allowed to change this comment")
 )
 
 type CommentUseCase interface {
-	GetAll(ctx context.Context) ([]*models.CommentWish, error)
+	GetAll(ctx context.Context, viewerID uuid.UUID) ([]*models.CommentWish, error)
 	Create(ctx context.Context, comment *entities.Comment) (*models.CommentWish, error)
-	GetByID(ctx context.Context, id uuid.UUID) (*models.CommentWish, error)
-	Update(ctx context.Context, comment *entities.Comment) (*models.CommentWish, error)
-	Delete(ctx context.Context, id uuid.UUID) error
+	GetByID(ctx context.Context, id, viewerID uuid.UUID) (*models.CommentWish, error)
+	GetThread(ctx context.Context, taskID, viewerID uuid.UUID, limit, offset int) ([]*models.CommentThread, int, error)
+	Update(ctx context.Context, comment *entities.Comment, editedBy uuid.UUID) (*models.CommentWish, error)
+	GetRevisions(ctx context.Context, id, viewerID uuid.UUID) ([]*entities.CommentRevision, error)
+	AddReaction(ctx context.Context, commentID, userID uuid.UUID, emoji string) ([]models.ReactionSummary, error)
+	RemoveReaction(ctx context.Context, commentID, userID uuid.UUID, emoji string) ([]models.ReactionSummary, error)
+	Delete(ctx context.Context, id, actorID uuid.UUID) error
+	PurgeDeleted(ctx context.Context, before time.Time) (int64, error)
 }
 
 type commentUseCase struct {
-	repo repositories.CommentRepository
+	repo      repositories.CommentRepository
+	tasks     repositories.TaskRepository
+	users     repositories.UserRepository
+	publisher events.Publisher
 }
 
-func NewCommentUseCase(repo repositories.CommentRepository) CommentUseCase {
-	return &commentUseCase{repo: repo}
+func NewCommentUseCase(repo repositories.CommentRepository, tasks repositories.TaskRepository, users repositories.UserRepository, publisher events.Publisher) CommentUseCase {
+	return &commentUseCase{repo: repo, tasks: tasks, users: users, publisher: publisher}
 }
 
-func (c *commentUseCase) GetAll(ctx context.Context) ([]*models.CommentWish, error) {
-	return c.repo.GetAll(ctx)
+func (c *commentUseCase) GetAll(ctx context.Context, viewerID uuid.UUID) ([]*models.CommentWish, error) {
+	comments, err := c.repo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.withReactions(ctx, viewerID, comments...); err != nil {
+		return nil, err
+	}
+	return comments, nil
 }
 
+// Create adds a comment on behalf of its author, who must be able to see the task.
 func (c *commentUseCase) Create(ctx context.Context, comment *entities.Comment) (*models.CommentWish, error) {
+	if err := c.checkTaskVisible(ctx, comment.TaskID, comment.Author); err != nil {
+		return nil, err
+	}
+	mentioned, err := c.resolveMentions(ctx, comment)
+	if err != nil {
+		return nil, err
+	}
 	if err := c.repo.Create(ctx, comment); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			if comment.ParentID != nil {
+				return nil, ErrParentCommentNotFound
+			}
+			return nil, ErrTaskNotFound
+		}
+		return nil, err
+	}
+	metrics.CommentsCreated.Inc()
+	if err := c.setMentions(ctx, comment, mentioned); err != nil {
 		return nil, err
 	}
-	res, err := c.repo.GetByID(ctx, comment.ID)
+	c.publisher.Publish(ctx, events.CommentCreated{
+		CommentID:  comment.ID,
+		TaskID:     comment.TaskID,
+		AuthorID:   comment.Author,
+		Mentioned:  mentioned,
+		OccurredAt: time.Now(),
+	})
+	return c.GetByID(ctx, comment.ID, comment.Author)
+}
+
+func (c *commentUseCase) GetByID(ctx context.Context, id, viewerID uuid.UUID) (*models.CommentWish, error) {
+	res, err := c.getVisible(ctx, id, viewerID)
 	if err != nil {
 		return nil, err
 	}
+	if err := c.withReactions(ctx, viewerID, res); err != nil {
+		return nil, err
+	}
 	return res, nil
 }
 
-func (c *commentUseCase) GetByID(ctx context.Context, id uuid.UUID) (*models.CommentWish, error) {
-	return c.repo.GetByID(ctx, id)
+// GetThread returns one page of top-level comments of the task with all of
+// their replies nested under them, and the total number of top-level comments.
+func (c *commentUseCase) GetThread(ctx context.Context, taskID, viewerID uuid.UUID, limit, offset int) ([]*models.CommentThread, int, error) {
+	if err := c.checkTaskVisible(ctx, taskID, viewerID); err != nil {
+		return nil, 0, err
+	}
+	roots, total, err := c.repo.GetRootsByTaskID(ctx, taskID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(roots) == 0 {
+		return nil, total, nil
+	}
+	rootIDs := make([]uuid.UUID, 0, len(roots))
+	for _, root := range roots {
+		rootIDs = append(rootIDs, root.Comment.ID)
+	}
+	replies, err := c.repo.GetReplies(ctx, rootIDs)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := c.withReactions(ctx, viewerID, append(roots, replies...)...); err != nil {
+		return nil, 0, err
+	}
+	return buildThreads(roots, replies), total, nil
 }
 
-func (c *commentUseCase) Update(ctx context.Context, comment *entities.Comment) (*models.CommentWish, error) {
-	if err := c.repo.Update(ctx, comment); err != nil {
+// Update changes the content of a comment. Only its author may edit it, so
+// mentions are never sent under someone else's name.
+func (c *commentUseCase) Update(ctx context.Context, comment *entities.Comment, editedBy uuid.UUID) (*models.CommentWish, error) {
+	current, err := c.getVisible(ctx, comment.ID, editedBy)
+	if err != nil {
 		return nil, err
 	}
-	res, err := c.repo.GetByID(ctx, comment.ID)
+	if current.Comment.Author != editedBy {
+		return nil, ErrCommentForbidden
+	}
+	comment.TaskID = current.Comment.TaskID
+	comment.Author = current.Comment.Author
+
+	mentioned, err := c.resolveMentions(ctx, comment)
 	if err != nil {
 		return nil, err
 	}
-	return res, nil
+	if err := c.repo.Update(ctx, comment, editedBy); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrCommentNotFound
+		}
+		return nil, err
+	}
+	if err := c.setMentions(ctx, comment, mentioned); err != nil {
+		return nil, err
+	}
+	return c.GetByID(ctx, comment.ID, editedBy)
+}
+
+func (c *commentUseCase) GetRevisions(ctx context.Context, id, viewerID uuid.UUID) ([]*entities.CommentRevision, error) {
+	if _, err := c.getVisible(ctx, id, viewerID); err != nil {
+		return nil, err
+	}
+	return c.repo.GetRevisions(ctx, id)
+}
+
+// AddReaction is idempotent: reacting twice with the same emoji changes nothing
+// and publishes no event. It returns the updated reactions of the comment.
+func (c *commentUseCase) AddReaction(ctx context.Context, commentID, userID uuid.UUID, emoji string) ([]models.ReactionSummary, error) {
+	if !emojis.Valid(emoji) {
+		return nil, ErrInvalidEmoji
+	}
+	comment, err := c.getVisible(ctx, commentID, userID)
+	if err != nil {
+		return nil, err
+	}
+	added, err := c.repo.AddReaction(ctx, &entities.CommentReaction{
+		CommentID: commentID,
+		UserID:    userID,
+		Emoji:     emoji,
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if added {
+		c.publisher.Publish(ctx, events.CommentReactionAdded{
+			CommentID:  commentID,
+			TaskID:     comment.Comment.TaskID,
+			UserID:     userID,
+			Emoji:      emoji,
+			OccurredAt: time.Now(),
+		})
+	}
+	return c.reactions(ctx, commentID, userID)
 }
 
-func (c *commentUseCase) Delete(ctx context.Context, id uuid.UUID) error {
-	return c.repo.Delete(ctx, id)
+func (c *commentUseCase) RemoveReaction(ctx context.Context, commentID, userID uuid.UUID, emoji string) ([]models.ReactionSummary, error) {
+	comment, err := c.getVisible(ctx, commentID, userID)
+	if err != nil {
+		return nil, err
+	}
+	removed, err := c.repo.RemoveReaction(ctx, commentID, userID, emoji)
+	if err != nil {
+		return nil, err
+	}
+	if removed {
+		c.publisher.Publish(ctx, events.CommentReactionRemoved{
+			CommentID:  commentID,
+			TaskID:     comment.Comment.TaskID,
+			UserID:     userID,
+			Emoji:      emoji,
+			OccurredAt: time.Now(),
+		})
+	}
+	return c.reactions(ctx, commentID, userID)
+}
+
+// Delete removes a comment on behalf of its author or the creator of the task.
+func (c *commentUseCase) Delete(ctx context.Context, id, actorID uuid.UUID) error {
+	current, err := c.getVisible(ctx, id, actorID)
+	if err != nil {
+		return err
+	}
+	if current.Comment.Author != actorID {
+		task, err := c.tasks.GetTaskByID(ctx, current.Comment.TaskID)
+		if err != nil {
+			return err
+		}
+		if task.Task.CreatedBy != actorID {
+			return ErrCommentForbidden
+		}
+	}
+	return c.repo.Delete(ctx, id, time.Now())
+}
+
+func (c *commentUseCase) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	return c.repo.PurgeDeleted(ctx, before)
+}
+
+func (c *commentUseCase) get(ctx context.Context, id uuid.UUID) (*models.CommentWish, error) {
+	res, err := c.repo.GetByID(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrCommentNotFound
+	}
+	return res, err
+}
+
+// getVisible returns the comment when the user can see its task; comments on
+// other tasks are reported as not found.
+func (c *commentUseCase) getVisible(ctx context.Context, id, userID uuid.UUID) (*models.CommentWish, error) {
+	current, err := c.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.checkTaskVisible(ctx, current.Comment.TaskID, userID); err != nil {
+		if errors.Is(err, ErrTaskNotFound) {
+			return nil, ErrCommentNotFound
+		}
+		return nil, err
+	}
+	return current, nil
+}
+
+func (c *commentUseCase) reactions(ctx context.Context, commentID, viewerID uuid.UUID) ([]models.ReactionSummary, error) {
+	comment := &models.CommentWish{Comment: entities.Comment{ID: commentID}}
+	if err := c.withReactions(ctx, viewerID, comment); err != nil {
+		return nil, err
+	}
+	return comment.Reactions, nil
+}
+
+// withReactions loads aggregated reactions for all comments in one query.
+func (c *commentUseCase) withReactions(ctx context.Context, viewerID uuid.UUID, comments ...*models.CommentWish) error {
+	if len(comments) == 0 {
+		return nil
+	}
+	ids := make([]uuid.UUID, 0, len(comments))
+	byID := make(map[uuid.UUID]*models.CommentWish, len(comments))
+	for _, comment := range comments {
+		ids = append(ids, comment.Comment.ID)
+		byID[comment.Comment.ID] = comment
+	}
+	reactions, err := c.repo.GetReactions(ctx, ids, viewerID)
+	if err != nil {
+		return err
+	}
+	for _, reaction := range reactions {
+		if comment, ok := byID[reaction.CommentID]; ok {
+			comment.Reactions = append(comment.Reactions, *reaction)
+		}
+	}
+	return nil
+}
+
+func (c *commentUseCase) checkTaskVisible(ctx context.Context, taskID, userID uuid.UUID) error {
+	visible, err := c.tasks.IsVisibleTo(ctx, taskID, userID)
+	if err != nil {
+		return err
+	}
+	if !visible {
+		return ErrTaskNotFound
+	}
+	return nil
+}
+
+// resolveMentions returns the users @mentioned in the comment, leaving out the
+// author and the users who cannot see the task, so mentions neither notify
+// them about it nor reveal which accounts exist.
+func (c *commentUseCase) resolveMentions(ctx context.Context, comment *entities.Comment) ([]uuid.UUID, error) {
+	handles := mention.Parse(comment.Content)
+	if len(handles) == 0 {
+		return nil, nil
+	}
+	ids, err := c.users.ResolveMentions(ctx, handles)
+	if err != nil {
+		return nil, err
+	}
+	mentioned := ids[:0]
+	for _, id := range ids {
+		if id == comment.Author {
+			continue
+		}
+		visible, err := c.tasks.IsVisibleTo(ctx, comment.TaskID, id)
+		if err != nil {
+			return nil, err
+		}
+		if visible {
+			mentioned = append(mentioned, id)
+		}
+	}
+	return mentioned, nil
+}
+
+// setMentions stores the mentions and notifies only the users that were not
+// mentioned in the comment before, so editing a comment does not repeat them.
+func (c *commentUseCase) setMentions(ctx context.Context, comment *entities.Comment, userIDs []uuid.UUID) error {
+	added, err := c.repo.SetMentions(ctx, comment.ID, userIDs)
+	if err != nil {
+		return err
+	}
+	if len(added) == 0 {
+		return nil
+	}
+	c.publisher.Publish(ctx, events.CommentMentioned{
+		CommentID:  comment.ID,
+		TaskID:     comment.TaskID,
+		AuthorID:   comment.Author,
+		UserIDs:    added,
+		OccurredAt: time.Now(),
+	})
+	return nil
+}
+
+// buildThreads nests replies under their parents. Replies come ordered by
+// creation time, so every level of the tree stays in chronological order.
+func buildThreads(roots, replies []*models.CommentWish) []*models.CommentThread {
+	nodes := make(map[uuid.UUID]*models.CommentThread, len(roots)+len(replies))
+	threads := make([]*models.CommentThread, 0, len(roots))
+	for _, root := range roots {
+		node := &models.CommentThread{CommentWish: *root}
+		nodes[root.Comment.ID] = node
+		threads = append(threads, node)
+	}
+	for _, reply := range replies {
+		nodes[reply.Comment.ID] = &models.CommentThread{CommentWish: *reply}
+	}
+	for _, reply := range replies {
+		parent, ok := nodes[*reply.Comment.ParentID]
+		if !ok {
+			continue
+		}
+		parent.Replies = append(parent.Replies, nodes[reply.Comment.ID])
+	}
+	return threads
 }
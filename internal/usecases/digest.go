@@ -0,0 +1,143 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"task-api/internal/adapters/models"
+	"task-api/internal/domain/entities"
+	"task-api/internal/domain/repositories"
+	"task-api/internal/infrastructure/mailer"
+	"time"
+)
+
+var ErrInvalidTimezone = errors.New("invalid timezone")
+
+type DigestUseCase interface {
+	GetSettings(ctx context.Context, userID uuid.UUID) (*entities.DigestSettings, error)
+	UpdateSettings(ctx context.Context, settings *entities.DigestSettings) (*entities.DigestSettings, error)
+	SendDue(ctx context.Context, now time.Time) (int, error)
+}
+
+type digestUseCase struct {
+	repo     repositories.DigestRepository
+	mailer   mailer.Mailer
+	sendHour int
+	dueSoon  time.Duration
+}
+
+// NewDigestUseCase creates the daily digest sender. A user gets the digest once
+// per local day, on the first run after sendHour in their time zone; tasks due
+// within dueSoon are reported as due soon.
+func NewDigestUseCase(repo repositories.DigestRepository, mailer mailer.Mailer, sendHour int, dueSoon time.Duration) DigestUseCase {
+	return &digestUseCase{repo: repo, mailer: mailer, sendHour: sendHour, dueSoon: dueSoon}
+}
+
+func (d *digestUseCase) GetSettings(ctx context.Context, userID uuid.UUID) (*entities.DigestSettings, error) {
+	settings, err := d.repo.GetSettings(ctx, userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return &entities.DigestSettings{UserID: userID, Enabled: true, Timezone: entities.DefaultDigestTimezone}, nil
+	}
+	return settings, err
+}
+
+func (d *digestUseCase) UpdateSettings(ctx context.Context, settings *entities.DigestSettings) (*entities.DigestSettings, error) {
+	if settings.Timezone == "" {
+		settings.Timezone = entities.DefaultDigestTimezone
+	}
+	if _, err := time.LoadLocation(settings.Timezone); err != nil {
+		return nil, ErrInvalidTimezone
+	}
+	if err := d.repo.SetSettings(ctx, settings); err != nil {
+		return nil, err
+	}
+	return d.GetSettings(ctx, settings.UserID)
+}
+
+// SendDue sends the digest to every recipient whose send time has come and
+// returns how many emails went out. A failure for one user does not stop the
+// others; it is reported and the user is retried on the next run.
+func (d *digestUseCase) SendDue(ctx context.Context, now time.Time) (int, error) {
+	now = now.UTC()
+	recipients, err := d.repo.GetRecipients(ctx)
+	if err != nil {
+		return 0, err
+	}
+	var sent int
+	var errs []error
+	for _, recipient := range recipients {
+		if ctx.Err() != nil {
+			return sent, ctx.Err()
+		}
+		ok, err := d.send(ctx, recipient, now)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("digest for user %s: %w", recipient.User.ID, err))
+			continue
+		}
+		if ok {
+			sent++
+		}
+	}
+	return sent, errors.Join(errs...)
+}
+
+func (d *digestUseCase) send(ctx context.Context, recipient *models.DigestRecipient, now time.Time) (bool, error) {
+	loc, err := time.LoadLocation(recipient.Settings.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	local := now.In(loc)
+	if local.Hour() < d.sendHour {
+		return false, nil
+	}
+	since := now.Add(-24 * time.Hour)
+	if last := recipient.Settings.LastSentAt; last != nil {
+		y, m, day := last.In(loc).Date()
+		if ly, lm, lday := local.Date(); y == ly && m == lm && day == lday {
+			return false, nil
+		}
+		since = *last
+	}
+
+	digest, err := d.collect(ctx, recipient.User, now, since)
+	if err != nil {
+		return false, err
+	}
+	digest.Location = loc
+	if digest.IsEmpty() {
+		// Nothing to report today; the next digest still covers everything since now.
+		return false, d.repo.MarkSent(ctx, recipient.User.ID, now)
+	}
+	msg, err := mailer.NewDigestMessage(digest)
+	if err != nil {
+		return false, err
+	}
+	if err := d.mailer.Send(ctx, msg); err != nil {
+		return false, err
+	}
+	return true, d.repo.MarkSent(ctx, recipient.User.ID, now)
+}
+
+func (d *digestUseCase) collect(ctx context.Context, user entities.User, now, since time.Time) (*models.Digest, error) {
+	digest := &models.Digest{User: user, Since: since, Until: now}
+	tasks, err := d.repo.GetDueTasks(ctx, user.ID, now.Add(d.dueSoon))
+	if err != nil {
+		return nil, err
+	}
+	for _, task := range tasks {
+		if task.DueAt.Before(now) {
+			digest.Overdue = append(digest.Overdue, task)
+		} else {
+			digest.DueSoon = append(digest.DueSoon, task)
+		}
+	}
+	if digest.Comments, err = d.repo.GetNewComments(ctx, user.ID, since); err != nil {
+		return nil, err
+	}
+	if digest.Assignments, err = d.repo.GetNewAssignments(ctx, user.ID, since); err != nil {
+		return nil, err
+	}
+	return digest, nil
+}
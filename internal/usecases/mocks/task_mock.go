@@ -14,6 +14,7 @@ import (
 	reflect "reflect"
 	models "task-api/internal/adapters/models"
 	entities "task-api/internal/domain/entities"
+	time "time"
 
 	uuid "github.com/google/uuid"
 	gomock "go.uber.org/mock/gomock"
@@ -43,62 +44,137 @@ func (m *MockTaskUseCase) EXPECT() *MockTaskUseCaseMockRecorder {
 	return m.recorder
 }
 
+// AddAssignee mocks base method.
+func (m *MockTaskUseCase) AddAssignee(ctx context.Context, taskID uuid.UUID, userID uuid.UUID, actorID uuid.UUID) (*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddAssignee", ctx, taskID, userID, actorID)
+	ret0, _ := ret[0].(*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddAssignee indicates an expected call of AddAssignee.
+func (mr *MockTaskUseCaseMockRecorder) AddAssignee(ctx, taskID, userID, actorID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAssignee", reflect.TypeOf((*MockTaskUseCase)(nil).AddAssignee), ctx, taskID, userID, actorID)
+}
+
 // AddTags mocks base method.
-func (m *MockTaskUseCase) AddTags(ctx context.Context, taskID uuid.UUID, tags []*entities.Tag) error {
+func (m *MockTaskUseCase) AddTags(ctx context.Context, taskID uuid.UUID, actorID uuid.UUID, tags []*entities.Tag) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddTags", ctx, taskID, tags)
+	ret := m.ctrl.Call(m, "AddTags", ctx, taskID, actorID, tags)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // AddTags indicates an expected call of AddTags.
-func (mr *MockTaskUseCaseMockRecorder) AddTags(ctx, taskID, tags any) *gomock.Call {
+func (mr *MockTaskUseCaseMockRecorder) AddTags(ctx, taskID, actorID, tags any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTags", reflect.TypeOf((*MockTaskUseCase)(nil).AddTags), ctx, taskID, actorID, tags)
+}
+
+// Archive mocks base method.
+func (m *MockTaskUseCase) Archive(ctx context.Context, id uuid.UUID, actorID uuid.UUID) (*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Archive", ctx, id, actorID)
+	ret0, _ := ret[0].(*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Archive indicates an expected call of Archive.
+func (mr *MockTaskUseCaseMockRecorder) Archive(ctx, id, actorID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTags", reflect.TypeOf((*MockTaskUseCase)(nil).AddTags), ctx, taskID, tags)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Archive", reflect.TypeOf((*MockTaskUseCase)(nil).Archive), ctx, id, actorID)
+}
+
+// AutoArchive mocks base method.
+func (m *MockTaskUseCase) AutoArchive(ctx context.Context, completedBefore time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AutoArchive", ctx, completedBefore)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AutoArchive indicates an expected call of AutoArchive.
+func (mr *MockTaskUseCaseMockRecorder) AutoArchive(ctx, completedBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AutoArchive", reflect.TypeOf((*MockTaskUseCase)(nil).AutoArchive), ctx, completedBefore)
 }
 
 // Create mocks base method.
-func (m *MockTaskUseCase) Create(ctx context.Context, task *entities.Task) (*models.Task, error) {
+func (m *MockTaskUseCase) Create(ctx context.Context, task *entities.Task, tags []string) (*models.Task, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", ctx, task)
+	ret := m.ctrl.Call(m, "Create", ctx, task, tags)
 	ret0, _ := ret[0].(*models.Task)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockTaskUseCaseMockRecorder) Create(ctx, task any) *gomock.Call {
+func (mr *MockTaskUseCaseMockRecorder) Create(ctx, task, tags any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTaskUseCase)(nil).Create), ctx, task)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTaskUseCase)(nil).Create), ctx, task, tags)
 }
 
 // Delete mocks base method.
-func (m *MockTaskUseCase) Delete(ctx context.Context, id uuid.UUID) error {
+func (m *MockTaskUseCase) Delete(ctx context.Context, id uuid.UUID, actorID uuid.UUID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret := m.ctrl.Call(m, "Delete", ctx, id, actorID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockTaskUseCaseMockRecorder) Delete(ctx, id any) *gomock.Call {
+func (mr *MockTaskUseCaseMockRecorder) Delete(ctx, id, actorID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTaskUseCase)(nil).Delete), ctx, id, actorID)
+}
+
+// GetBoard mocks base method.
+func (m *MockTaskUseCase) GetBoard(ctx context.Context, userID uuid.UUID, statuses []string) ([]models.BoardColumn, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoard", ctx, userID, statuses)
+	ret0, _ := ret[0].([]models.BoardColumn)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoard indicates an expected call of GetBoard.
+func (mr *MockTaskUseCaseMockRecorder) GetBoard(ctx, userID, statuses any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTaskUseCase)(nil).Delete), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoard", reflect.TypeOf((*MockTaskUseCase)(nil).GetBoard), ctx, userID, statuses)
+}
+
+// GetOccurrences mocks base method.
+func (m *MockTaskUseCase) GetOccurrences(ctx context.Context, taskID uuid.UUID, userID uuid.UUID, limit int) ([]time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOccurrences", ctx, taskID, userID, limit)
+	ret0, _ := ret[0].([]time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOccurrences indicates an expected call of GetOccurrences.
+func (mr *MockTaskUseCaseMockRecorder) GetOccurrences(ctx, taskID, userID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOccurrences", reflect.TypeOf((*MockTaskUseCase)(nil).GetOccurrences), ctx, taskID, userID, limit)
 }
 
 // GetTask mocks base method.
-func (m *MockTaskUseCase) GetTask(ctx context.Context, id uuid.UUID) (*models.Task, error) {
+func (m *MockTaskUseCase) GetTask(ctx context.Context, id uuid.UUID, viewerID uuid.UUID) (*models.Task, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTask", ctx, id)
+	ret := m.ctrl.Call(m, "GetTask", ctx, id, viewerID)
 	ret0, _ := ret[0].(*models.Task)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetTask indicates an expected call of GetTask.
-func (mr *MockTaskUseCaseMockRecorder) GetTask(ctx, id any) *gomock.Call {
+func (mr *MockTaskUseCaseMockRecorder) GetTask(ctx, id, viewerID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTask", reflect.TypeOf((*MockTaskUseCase)(nil).GetTask), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTask", reflect.TypeOf((*MockTaskUseCase)(nil).GetTask), ctx, id, viewerID)
 }
 
 // GetTasks mocks base method.
@@ -117,45 +193,180 @@ func (mr *MockTaskUseCaseMockRecorder) GetTasks(ctx any) *gomock.Call {
 }
 
 // GetTasksByUserID mocks base method.
-func (m *MockTaskUseCase) GetTasksByUserID(ctx context.Context, userID uuid.UUID) ([]*models.TasksWishTags, error) {
+func (m *MockTaskUseCase) GetTasksByUserID(ctx context.Context, userID uuid.UUID, filter models.TaskFilter) ([]*models.TasksWishTags, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTasksByUserID", ctx, userID)
+	ret := m.ctrl.Call(m, "GetTasksByUserID", ctx, userID, filter)
 	ret0, _ := ret[0].([]*models.TasksWishTags)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetTasksByUserID indicates an expected call of GetTasksByUserID.
-func (mr *MockTaskUseCaseMockRecorder) GetTasksByUserID(ctx, userID any) *gomock.Call {
+func (mr *MockTaskUseCaseMockRecorder) GetTasksByUserID(ctx, userID, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasksByUserID", reflect.TypeOf((*MockTaskUseCase)(nil).GetTasksByUserID), ctx, userID, filter)
+}
+
+// GetTrash mocks base method.
+func (m *MockTaskUseCase) GetTrash(ctx context.Context, userID uuid.UUID) ([]*models.TasksWishTags, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrash", ctx, userID)
+	ret0, _ := ret[0].([]*models.TasksWishTags)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrash indicates an expected call of GetTrash.
+func (mr *MockTaskUseCaseMockRecorder) GetTrash(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrash", reflect.TypeOf((*MockTaskUseCase)(nil).GetTrash), ctx, userID)
+}
+
+// Move mocks base method.
+func (m *MockTaskUseCase) Move(ctx context.Context, move models.TaskMove) (*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Move", ctx, move)
+	ret0, _ := ret[0].(*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Move indicates an expected call of Move.
+func (mr *MockTaskUseCaseMockRecorder) Move(ctx, move any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasksByUserID", reflect.TypeOf((*MockTaskUseCase)(nil).GetTasksByUserID), ctx, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Move", reflect.TypeOf((*MockTaskUseCase)(nil).Move), ctx, move)
+}
+
+// PurgeTrash mocks base method.
+func (m *MockTaskUseCase) PurgeTrash(ctx context.Context, before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeTrash", ctx, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeTrash indicates an expected call of PurgeTrash.
+func (mr *MockTaskUseCaseMockRecorder) PurgeTrash(ctx, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeTrash", reflect.TypeOf((*MockTaskUseCase)(nil).PurgeTrash), ctx, before)
+}
+
+// RemoveAssignee mocks base method.
+func (m *MockTaskUseCase) RemoveAssignee(ctx context.Context, taskID uuid.UUID, userID uuid.UUID, actorID uuid.UUID) (*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveAssignee", ctx, taskID, userID, actorID)
+	ret0, _ := ret[0].(*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveAssignee indicates an expected call of RemoveAssignee.
+func (mr *MockTaskUseCaseMockRecorder) RemoveAssignee(ctx, taskID, userID, actorID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveAssignee", reflect.TypeOf((*MockTaskUseCase)(nil).RemoveAssignee), ctx, taskID, userID, actorID)
 }
 
 // RemoveTags mocks base method.
-func (m *MockTaskUseCase) RemoveTags(ctx context.Context, taskID uuid.UUID, tags []*entities.Tag) error {
+func (m *MockTaskUseCase) RemoveTags(ctx context.Context, taskID uuid.UUID, actorID uuid.UUID, tags []*entities.Tag) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RemoveTags", ctx, taskID, tags)
+	ret := m.ctrl.Call(m, "RemoveTags", ctx, taskID, actorID, tags)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // RemoveTags indicates an expected call of RemoveTags.
-func (mr *MockTaskUseCaseMockRecorder) RemoveTags(ctx, taskID, tags any) *gomock.Call {
+func (mr *MockTaskUseCaseMockRecorder) RemoveTags(ctx, taskID, actorID, tags any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTags", reflect.TypeOf((*MockTaskUseCase)(nil).RemoveTags), ctx, taskID, actorID, tags)
+}
+
+// ReplaceTags mocks base method.
+func (m *MockTaskUseCase) ReplaceTags(ctx context.Context, taskID uuid.UUID, actorID uuid.UUID, tags []string) (*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceTags", ctx, taskID, actorID, tags)
+	ret0, _ := ret[0].(*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplaceTags indicates an expected call of ReplaceTags.
+func (mr *MockTaskUseCaseMockRecorder) ReplaceTags(ctx, taskID, actorID, tags any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceTags", reflect.TypeOf((*MockTaskUseCase)(nil).ReplaceTags), ctx, taskID, actorID, tags)
+}
+
+// Restore mocks base method.
+func (m *MockTaskUseCase) Restore(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, id, userID)
+	ret0, _ := ret[0].(*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockTaskUseCaseMockRecorder) Restore(ctx, id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockTaskUseCase)(nil).Restore), ctx, id, userID)
+}
+
+// SpawnNext mocks base method.
+func (m *MockTaskUseCase) SpawnNext(ctx context.Context, taskID uuid.UUID, now time.Time) (*entities.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SpawnNext", ctx, taskID, now)
+	ret0, _ := ret[0].(*entities.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SpawnNext indicates an expected call of SpawnNext.
+func (mr *MockTaskUseCaseMockRecorder) SpawnNext(ctx, taskID, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SpawnNext", reflect.TypeOf((*MockTaskUseCase)(nil).SpawnNext), ctx, taskID, now)
+}
+
+// SpawnRecurrences mocks base method.
+func (m *MockTaskUseCase) SpawnRecurrences(ctx context.Context, now time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SpawnRecurrences", ctx, now)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SpawnRecurrences indicates an expected call of SpawnRecurrences.
+func (mr *MockTaskUseCaseMockRecorder) SpawnRecurrences(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SpawnRecurrences", reflect.TypeOf((*MockTaskUseCase)(nil).SpawnRecurrences), ctx, now)
+}
+
+// Unarchive mocks base method.
+func (m *MockTaskUseCase) Unarchive(ctx context.Context, id uuid.UUID, actorID uuid.UUID) (*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unarchive", ctx, id, actorID)
+	ret0, _ := ret[0].(*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Unarchive indicates an expected call of Unarchive.
+func (mr *MockTaskUseCaseMockRecorder) Unarchive(ctx, id, actorID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTags", reflect.TypeOf((*MockTaskUseCase)(nil).RemoveTags), ctx, taskID, tags)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unarchive", reflect.TypeOf((*MockTaskUseCase)(nil).Unarchive), ctx, id, actorID)
 }
 
 // Update mocks base method.
-func (m *MockTaskUseCase) Update(ctx context.Context, task *entities.Task) (*models.Task, error) {
+func (m *MockTaskUseCase) Update(ctx context.Context, task *entities.Task, actorID uuid.UUID, tags []string) (*models.Task, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", ctx, task)
+	ret := m.ctrl.Call(m, "Update", ctx, task, actorID, tags)
 	ret0, _ := ret[0].(*models.Task)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockTaskUseCaseMockRecorder) Update(ctx, task any) *gomock.Call {
+func (mr *MockTaskUseCaseMockRecorder) Update(ctx, task, actorID, tags any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTaskUseCase)(nil).Update), ctx, task)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTaskUseCase)(nil).Update), ctx, task, actorID, tags)
 }
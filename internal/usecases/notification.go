@@ -0,0 +1,172 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"task-api/internal/domain/entities"
+	"task-api/internal/domain/events"
+	"task-api/internal/domain/repositories"
+	"time"
+)
+
+var (
+	ErrNotificationNotFound    = errors.New("notification not found")
+	ErrUnknownNotificationType = errors.New("unknown notification type")
+)
+
+type NotificationUseCase interface {
+	List(ctx context.Context, userID uuid.UUID, unreadOnly bool, limit, offset int) ([]*entities.Notification, int, error)
+	MarkRead(ctx context.Context, id, userID uuid.UUID) error
+	MarkAllRead(ctx context.Context, userID uuid.UUID) (int64, error)
+	GetPreferences(ctx context.Context, userID uuid.UUID) ([]*entities.NotificationPreference, error)
+	SetPreferences(ctx context.Context, userID uuid.UUID, preferences []*entities.NotificationPreference) ([]*entities.NotificationPreference, error)
+	Notify(ctx context.Context, event events.Event) error
+}
+
+type notificationUseCase struct {
+	repo  repositories.NotificationRepository
+	tasks repositories.TaskRepository
+}
+
+func NewNotificationUseCase(repo repositories.NotificationRepository, tasks repositories.TaskRepository) NotificationUseCase {
+	return &notificationUseCase{repo: repo, tasks: tasks}
+}
+
+// List returns one page of the user's notifications, newest first, and the
+// number of unread ones.
+func (n *notificationUseCase) List(ctx context.Context, userID uuid.UUID, unreadOnly bool, limit, offset int) ([]*entities.Notification, int, error) {
+	notifications, err := n.repo.GetByUserID(ctx, userID, unreadOnly, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	unread, err := n.repo.CountUnread(ctx, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+	return notifications, unread, nil
+}
+
+func (n *notificationUseCase) MarkRead(ctx context.Context, id, userID uuid.UUID) error {
+	found, err := n.repo.MarkRead(ctx, id, userID, time.Now())
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrNotificationNotFound
+	}
+	return nil
+}
+
+func (n *notificationUseCase) MarkAllRead(ctx context.Context, userID uuid.UUID) (int64, error) {
+	return n.repo.MarkAllRead(ctx, userID, time.Now())
+}
+
+// GetPreferences returns a preference for every notification type, filling in
+// the enabled default for types the user never changed.
+func (n *notificationUseCase) GetPreferences(ctx context.Context, userID uuid.UUID) ([]*entities.NotificationPreference, error) {
+	stored, err := n.repo.GetPreferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	enabled := make(map[string]bool, len(stored))
+	for _, preference := range stored {
+		enabled[preference.Type] = preference.Enabled
+	}
+	preferences := make([]*entities.NotificationPreference, 0, len(entities.NotificationTypes))
+	for _, t := range entities.NotificationTypes {
+		on, ok := enabled[t]
+		preferences = append(preferences, &entities.NotificationPreference{UserID: userID, Type: t, Enabled: on || !ok})
+	}
+	return preferences, nil
+}
+
+func (n *notificationUseCase) SetPreferences(ctx context.Context, userID uuid.UUID, preferences []*entities.NotificationPreference) ([]*entities.NotificationPreference, error) {
+	for _, preference := range preferences {
+		if !entities.IsValidNotificationType(preference.Type) {
+			return nil, ErrUnknownNotificationType
+		}
+		preference.UserID = userID
+	}
+	if err := n.repo.SetPreferences(ctx, preferences); err != nil {
+		return nil, err
+	}
+	return n.GetPreferences(ctx, userID)
+}
+
+// Notify turns domain events into notifications. The user who caused an event
+// is never notified about it.
+func (n *notificationUseCase) Notify(ctx context.Context, event events.Event) error {
+	switch e := event.(type) {
+	case events.CommentCreated:
+		participants, err := n.tasks.GetParticipants(ctx, e.TaskID)
+		if err != nil {
+			return err
+		}
+		skip := append([]uuid.UUID{e.AuthorID}, e.Mentioned...)
+		return n.send(ctx, without(participants, skip), entities.Notification{
+			Type:      entities.NotificationTaskCommented,
+			TaskID:    &e.TaskID,
+			CommentID: &e.CommentID,
+			ActorID:   &e.AuthorID,
+			CreatedAt: e.OccurredAt,
+		})
+	case events.CommentMentioned:
+		return n.send(ctx, without(e.UserIDs, []uuid.UUID{e.AuthorID}), entities.Notification{
+			Type:      entities.NotificationMentioned,
+			TaskID:    &e.TaskID,
+			CommentID: &e.CommentID,
+			ActorID:   &e.AuthorID,
+			CreatedAt: e.OccurredAt,
+		})
+	case events.TaskStatusChanged:
+		participants, err := n.tasks.GetParticipants(ctx, e.TaskID)
+		if err != nil {
+			return err
+		}
+		return n.send(ctx, without(participants, []uuid.UUID{e.ActorID}), entities.Notification{
+			Type:      entities.NotificationTaskStatusChanged,
+			TaskID:    &e.TaskID,
+			ActorID:   &e.ActorID,
+			Data:      map[string]string{"old_status": e.OldStatus, "new_status": e.NewStatus},
+			CreatedAt: e.OccurredAt,
+		})
+	case events.TaskAssigned:
+		return n.send(ctx, without([]uuid.UUID{e.UserID}, []uuid.UUID{e.AssignedBy}), entities.Notification{
+			Type:      entities.NotificationTaskAssigned,
+			TaskID:    &e.TaskID,
+			ActorID:   &e.AssignedBy,
+			CreatedAt: e.OccurredAt,
+		})
+	}
+	return nil
+}
+
+func (n *notificationUseCase) send(ctx context.Context, recipients []uuid.UUID, template entities.Notification) error {
+	var errs []error
+	for _, userID := range recipients {
+		notification := template
+		notification.UserID = userID
+		if _, err := n.repo.Create(ctx, &notification); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func without(ids, skip []uuid.UUID) []uuid.UUID {
+	var res []uuid.UUID
+	for _, id := range ids {
+		excluded := false
+		for _, s := range skip {
+			if id == s {
+				excluded = true
+				break
+			}
+		}
+		if !excluded {
+			res = append(res, id)
+		}
+	}
+	return res
+}
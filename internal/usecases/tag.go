@@ -2,52 +2,119 @@ package usecases
 
 import (
 	"context"
+	"errors"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"task-api/internal/adapters/models"
 	"task-api/internal/domain/entities"
 	"task-api/internal/domain/repositories"
 )
 
+var (
+	ErrTagNotFound   = errors.New("tag not found")
+	ErrTagExists     = errors.New("tag with this title already exists")
+	ErrMergeIntoSelf = errors.New("tag cannot be merged into itself")
+)
+
+// TagUseCase manages the tags of one owner. Tags of other users are reported
+// as not found.
 type TagUseCase interface {
 	Create(ctx context.Context, tag *entities.Tag) (*entities.Tag, error)
-	GetTag(ctx context.Context, id uuid.UUID) (*entities.Tag, error)
-	GetTags(ctx context.Context) ([]*entities.Tag, error)
-	Update(ctx context.Context, tag *entities.Tag) error
-	Delete(ctx context.Context, id uuid.UUID) error
+	GetTag(ctx context.Context, id, ownerID uuid.UUID) (*entities.Tag, error)
+	GetTags(ctx context.Context, ownerID uuid.UUID, prefix string, limit int) ([]*models.TagUsage, error)
+	GetTasks(ctx context.Context, id, ownerID uuid.UUID) ([]*models.TasksWishTags, error)
+	Update(ctx context.Context, tag *entities.Tag) (*entities.Tag, error)
+	Delete(ctx context.Context, id, ownerID uuid.UUID) error
+	Merge(ctx context.Context, sourceID, targetID, ownerID uuid.UUID) (*models.TagUsage, error)
 }
 type tagUseCase struct {
-	repo repositories.TagRepository
+	repo  repositories.TagRepository
+	tasks repositories.TaskRepository
 }
 
-func NewTagsUseCase(repo repositories.TagRepository) TagUseCase {
-	return &tagUseCase{repo: repo}
+func NewTagsUseCase(repo repositories.TagRepository, tasks repositories.TaskRepository) TagUseCase {
+	return &tagUseCase{repo: repo, tasks: tasks}
 }
 
 func (t *tagUseCase) Create(ctx context.Context, tag *entities.Tag) (*entities.Tag, error) {
 	if err := t.repo.CreateTag(ctx, tag); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrTagExists
+		}
 		return nil, err
 	}
 	return tag, nil
 }
 
-func (t *tagUseCase) GetTag(ctx context.Context, id uuid.UUID) (*entities.Tag, error) {
-	return t.repo.GetTagByID(ctx, id)
+func (t *tagUseCase) GetTag(ctx context.Context, id, ownerID uuid.UUID) (*entities.Tag, error) {
+	tag, err := t.repo.GetTagByID(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrTagNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if tag.OwnerID != ownerID {
+		return nil, ErrTagNotFound
+	}
+	return tag, nil
 }
 
-func (t *tagUseCase) GetTags(ctx context.Context) ([]*entities.Tag, error) {
-	return t.repo.GetAllTags(ctx)
+// GetTags returns the owner's tags starting with prefix, the most used first;
+// an empty prefix matches every tag and a zero limit returns all matches.
+func (t *tagUseCase) GetTags(ctx context.Context, ownerID uuid.UUID, prefix string, limit int) ([]*models.TagUsage, error) {
+	return t.repo.ListTags(ctx, ownerID, prefix, limit)
 }
 
-func (t *tagUseCase) Update(ctx context.Context, tag *entities.Tag) error {
-	if err := t.repo.UpdateTag(ctx, tag); err != nil {
-		return err
+// GetTasks lists the tasks with the tag among those the owner can see.
+func (t *tagUseCase) GetTasks(ctx context.Context, id, ownerID uuid.UUID) ([]*models.TasksWishTags, error) {
+	if _, err := t.GetTag(ctx, id, ownerID); err != nil {
+		return nil, err
 	}
-	tag, err := t.repo.GetTagByID(ctx, tag.ID)
+	tasks, err := t.tasks.GetTasksByTagID(ctx, id, ownerID)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	return tasksWithTags(ctx, t.tasks, tasks)
+}
+
+// Update replaces the title, color and description of a tag owned by tag.OwnerID.
+func (t *tagUseCase) Update(ctx context.Context, tag *entities.Tag) (*entities.Tag, error) {
+	if _, err := t.GetTag(ctx, tag.ID, tag.OwnerID); err != nil {
+		return nil, err
+	}
+	existing, err := t.repo.GetTagByTitle(ctx, tag.OwnerID, tag.Title)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+	if existing != nil && existing.ID != tag.ID {
+		return nil, ErrTagExists
 	}
-	return nil
+	if err := t.repo.UpdateTag(ctx, tag); err != nil {
+		return nil, err
+	}
+	return t.repo.GetTagByID(ctx, tag.ID)
 }
 
-func (t *tagUseCase) Delete(ctx context.Context, id uuid.UUID) error {
+func (t *tagUseCase) Delete(ctx context.Context, id, ownerID uuid.UUID) error {
+	if _, err := t.GetTag(ctx, id, ownerID); err != nil {
+		return err
+	}
 	return t.repo.DeleteTag(ctx, id)
 }
+
+// Merge moves the tasks of the source tag to the target tag and deletes the
+// source. Both tags must belong to ownerID.
+func (t *tagUseCase) Merge(ctx context.Context, sourceID, targetID, ownerID uuid.UUID) (*models.TagUsage, error) {
+	if sourceID == targetID {
+		return nil, ErrMergeIntoSelf
+	}
+	merged, err := t.repo.MergeTags(ctx, sourceID, targetID, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	if !merged {
+		return nil, ErrTagNotFound
+	}
+	return t.repo.GetTagUsage(ctx, targetID)
+}
@@ -2,35 +2,78 @@ package usecases
 
 import (
 	"context"
+	"errors"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"slices"
+	"strings"
 	"task-api/internal/adapters/models"
 	"task-api/internal/domain/entities"
+	"task-api/internal/domain/events"
 	"task-api/internal/domain/repositories"
+	"task-api/internal/infrastructure/metrics"
+	"task-api/pkg/rrule"
+	"time"
+)
+
+var (
+	ErrTaskNotFound = errors.New("task not found")
+	ErrUserNotFound = errors.New("user not found")
+	// ErrInvalidRecurrence is wrapped together with the reason the rule was rejected.
+	ErrInvalidRecurrence  = rrule.ErrInvalid
+	ErrRecurrenceNeedsDue = errors.New("a recurring task needs a due date")
+	ErrInvalidAnchor      = errors.New("anchor task is not in the target column")
+)
+
+const (
+	defaultOccurrences = 10
+	maxOccurrences     = 100
 )
 
 type TaskUseCase interface {
-	Create(ctx context.Context, task *entities.Task) (*models.Task, error)
-	GetTask(ctx context.Context, id uuid.UUID) (*models.Task, error)
+	Create(ctx context.Context, task *entities.Task, tags []string) (*models.Task, error)
+	GetTask(ctx context.Context, id, viewerID uuid.UUID) (*models.Task, error)
 	GetTasks(ctx context.Context) ([]*models.Task, error)
-	GetTasksByUserID(ctx context.Context, userID uuid.UUID) ([]*models.TasksWishTags, error)
-	Update(ctx context.Context, task *entities.Task) (*models.Task, error)
-	Delete(ctx context.Context, id uuid.UUID) error
-	AddTags(ctx context.Context, taskID uuid.UUID, tags []*entities.Tag) error
-	RemoveTags(ctx context.Context, taskID uuid.UUID, tags []*entities.Tag) error
+	GetTasksByUserID(ctx context.Context, userID uuid.UUID, filter models.TaskFilter) ([]*models.TasksWishTags, error)
+	Update(ctx context.Context, task *entities.Task, actorID uuid.UUID, tags []string) (*models.Task, error)
+	Delete(ctx context.Context, id, actorID uuid.UUID) error
+	GetTrash(ctx context.Context, userID uuid.UUID) ([]*models.TasksWishTags, error)
+	Restore(ctx context.Context, id, userID uuid.UUID) (*models.Task, error)
+	PurgeTrash(ctx context.Context, before time.Time) (int64, error)
+	Archive(ctx context.Context, id, actorID uuid.UUID) (*models.Task, error)
+	Unarchive(ctx context.Context, id, actorID uuid.UUID) (*models.Task, error)
+	AutoArchive(ctx context.Context, completedBefore time.Time) (int64, error)
+	SpawnNext(ctx context.Context, taskID uuid.UUID, now time.Time) (*entities.Task, error)
+	SpawnRecurrences(ctx context.Context, now time.Time) (int, error)
+	GetOccurrences(ctx context.Context, taskID, userID uuid.UUID, limit int) ([]time.Time, error)
+	GetBoard(ctx context.Context, userID uuid.UUID, statuses []string) ([]models.BoardColumn, error)
+	Move(ctx context.Context, move models.TaskMove) (*models.Task, error)
+	AddTags(ctx context.Context, taskID, actorID uuid.UUID, tags []*entities.Tag) error
+	RemoveTags(ctx context.Context, taskID, actorID uuid.UUID, tags []*entities.Tag) error
+	ReplaceTags(ctx context.Context, taskID, actorID uuid.UUID, tags []string) (*models.Task, error)
+	AddAssignee(ctx context.Context, taskID, userID, actorID uuid.UUID) (*models.Task, error)
+	RemoveAssignee(ctx context.Context, taskID, userID, actorID uuid.UUID) (*models.Task, error)
 }
 
 type tasksUseCase struct {
-	repo repositories.TaskRepository
+	repo      repositories.TaskRepository
+	publisher events.Publisher
 }
 
-func NewTasksUseCase(repo repositories.TaskRepository) TaskUseCase {
-	return &tasksUseCase{repo: repo}
+func NewTasksUseCase(repo repositories.TaskRepository, publisher events.Publisher) TaskUseCase {
+	return &tasksUseCase{repo: repo, publisher: publisher}
 }
 
-func (t *tasksUseCase) Create(ctx context.Context, task *entities.Task) (*models.Task, error) {
-	if err := t.repo.CreateTask(ctx, task); err != nil {
+// Create stores the task tagged with the creator's tags of the given titles;
+// tags that do not exist yet are created.
+func (t *tasksUseCase) Create(ctx context.Context, task *entities.Task, tagTitles []string) (*models.Task, error) {
+	if err := normalizeRecurrence(task); err != nil {
+		return nil, err
+	}
+	if err := t.repo.CreateTask(ctx, task, normalizeTagTitles(tagTitles)); err != nil {
 		return nil, err
 	}
+	metrics.TasksCreated.Inc()
 	model, err := t.repo.GetTaskByID(ctx, task.ID)
 	if err != nil {
 		return nil, err
@@ -54,13 +97,23 @@ func (t *tasksUseCase) Create(ctx context.Context, task *entities.Task) (*models
 	return model, nil
 }
 
-func (t *tasksUseCase) GetTask(ctx context.Context, id uuid.UUID) (*models.Task, error) {
-	task, err := t.repo.GetTaskByID(ctx, id)
+// GetTask returns a task the viewer can see.
+func (t *tasksUseCase) GetTask(ctx context.Context, id, viewerID uuid.UUID) (*models.Task, error) {
+	if err := t.checkVisible(ctx, id, viewerID); err != nil {
+		return nil, err
+	}
+	return getTask(ctx, t.repo, id)
+}
+
+// getTask loads the task with its comments, tags, attachments, assignees,
+// subtasks and tracked time.
+func getTask(ctx context.Context, repo repositories.TaskRepository, id uuid.UUID) (*models.Task, error) {
+	task, err := repo.GetTaskByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
-	comments, err := t.repo.GetComments(ctx, task.Task.ID)
+	comments, err := repo.GetComments(ctx, task.Task.ID)
 	if err != nil {
 		return nil, err
 	}
@@ -68,35 +121,72 @@ func (t *tasksUseCase) GetTask(ctx context.Context, id uuid.UUID) (*models.Task,
 		task.Comments = append(task.Comments, *comment)
 	}
 
-	tags, err := t.repo.GetTags(ctx, id)
+	tags, err := repo.GetTags(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 	for _, tag := range tags {
 		task.Tags = append(task.Tags, *tag)
 	}
+
+	attachments, err := repo.GetAttachments(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	for _, attachment := range attachments {
+		task.Attachments = append(task.Attachments, *attachment)
+	}
+
+	assignees, err := repo.GetAssignees(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	for _, assignee := range assignees {
+		task.Assignees = append(task.Assignees, *assignee)
+	}
+
+	subtasks, err := repo.GetSubtasks(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	for _, subtask := range subtasks {
+		task.Subtasks = append(task.Subtasks, *subtask)
+	}
+
+	totals, err := repo.GetTimeTotals(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	for _, total := range totals {
+		task.TimeTotals = append(task.TimeTotals, *total)
+	}
 	return task, nil
 }
 
-func (t *tasksUseCase) GetTasksByUserID(ctx context.Context, userID uuid.UUID) ([]*models.TasksWishTags, error) {
-	tasks, err := t.repo.GetAllTasksByUserID(ctx, userID)
+func (t *tasksUseCase) GetTasksByUserID(ctx context.Context, userID uuid.UUID, filter models.TaskFilter) ([]*models.TasksWishTags, error) {
+	tasks, err := t.repo.GetAllTasksByUserID(ctx, userID, filter)
 	if err != nil {
 		return nil, err
 	}
+	return t.withTags(ctx, tasks)
+}
+
+func (t *tasksUseCase) withTags(ctx context.Context, tasks []*entities.Task) ([]*models.TasksWishTags, error) {
+	return tasksWithTags(ctx, t.repo, tasks)
+}
+
+func tasksWithTags(ctx context.Context, repo repositories.TaskRepository, tasks []*entities.Task) ([]*models.TasksWishTags, error) {
 	var taskIds []uuid.UUID
 	for _, task := range tasks {
 		taskIds = append(taskIds, task.ID)
 	}
-	tags, err := t.repo.GetTagsForManyTasks(ctx, taskIds)
+	tags, err := repo.GetTagsForManyTasks(ctx, taskIds)
 	if err != nil {
 		return nil, err
 	}
 	tagsMap := make(map[uuid.UUID][]entities.Tag)
 	for _, tag := range tags {
-		tagsMap[tag.TaskID] = append(tagsMap[tag.TaskID], entities.Tag{
-			ID:    tag.Tag.ID,
-			Title: tag.Tag.Title,
-		})
+		tagsMap[tag.TaskID] = append(tagsMap[tag.TaskID], tag.Tag)
 	}
 	var tasksWishTags []*models.TasksWishTags
 	for _, task := range tasks {
@@ -124,10 +214,7 @@ func (t *tasksUseCase) GetTasks(ctx context.Context) ([]*models.Task, error) {
 	}
 	tagsMap := make(map[uuid.UUID][]entities.Tag)
 	for _, tag := range tags {
-		tagsMap[tag.TaskID] = append(tagsMap[tag.TaskID], entities.Tag{
-			ID:    tag.Tag.ID,
-			Title: tag.Tag.Title,
-		})
+		tagsMap[tag.TaskID] = append(tagsMap[tag.TaskID], tag.Tag)
 	}
 
 	for _, task := range tasks {
@@ -136,47 +223,305 @@ func (t *tasksUseCase) GetTasks(ctx context.Context) ([]*models.Task, error) {
 	return tasks, nil
 }
 
-func (t *tasksUseCase) Update(ctx context.Context, task *entities.Task) (*models.Task, error) {
-	if err := t.repo.UpdateTask(ctx, task); err != nil {
+// Update saves a task the actor can see. Unless tags is nil, the actor's tags
+// on the task are replaced with the given titles; an empty list removes them all.
+func (t *tasksUseCase) Update(ctx context.Context, task *entities.Task, actorID uuid.UUID, tags []string) (*models.Task, error) {
+	if err := t.checkVisible(ctx, task.ID, actorID); err != nil {
 		return nil, err
 	}
-	model, err := t.repo.GetTaskByID(ctx, task.ID)
+	if err := normalizeRecurrence(task); err != nil {
+		return nil, err
+	}
+	if tags != nil {
+		tags = normalizeTagTitles(tags)
+	}
+	previous, err := t.repo.UpdateTask(ctx, task, actorID, tags)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrTaskNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
+	if previous != task.Status {
+		t.publisher.Publish(ctx, events.TaskStatusChanged{
+			TaskID:     task.ID,
+			ActorID:    actorID,
+			OldStatus:  previous,
+			NewStatus:  task.Status,
+			OccurredAt: time.Now(),
+		})
+	}
+	return getTask(ctx, t.repo, task.ID)
+}
 
-	comments, err := t.repo.GetComments(ctx, task.ID)
+// Delete moves a task to the trash. Only its creator may do so, just as only
+// the creator can restore it.
+func (t *tasksUseCase) Delete(ctx context.Context, id, actorID uuid.UUID) error {
+	if err := t.repo.DeleteTask(ctx, id, actorID, time.Now()); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrTaskNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func (t *tasksUseCase) GetTrash(ctx context.Context, userID uuid.UUID) ([]*models.TasksWishTags, error) {
+	tasks, err := t.repo.GetDeletedTasksByUserID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
-	for _, comment := range comments {
-		model.Comments = append(model.Comments, *comment)
+	return t.withTags(ctx, tasks)
+}
+
+func (t *tasksUseCase) Restore(ctx context.Context, id, userID uuid.UUID) (*models.Task, error) {
+	restored, err := t.repo.RestoreTask(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !restored {
+		return nil, ErrTaskNotFound
 	}
+	return getTask(ctx, t.repo, id)
+}
 
-	tags, err := t.repo.GetTags(ctx, task.ID)
+func (t *tasksUseCase) PurgeTrash(ctx context.Context, before time.Time) (int64, error) {
+	return t.repo.PurgeDeletedTasks(ctx, before)
+}
+
+func (t *tasksUseCase) Archive(ctx context.Context, id, actorID uuid.UUID) (*models.Task, error) {
+	now := time.Now()
+	return t.setArchived(ctx, id, actorID, &now)
+}
+
+func (t *tasksUseCase) Unarchive(ctx context.Context, id, actorID uuid.UUID) (*models.Task, error) {
+	return t.setArchived(ctx, id, actorID, nil)
+}
+
+// setArchived changes the archive state of a task the actor can see.
+func (t *tasksUseCase) setArchived(ctx context.Context, id, actorID uuid.UUID, archivedAt *time.Time) (*models.Task, error) {
+	if err := t.checkVisible(ctx, id, actorID); err != nil {
+		return nil, err
+	}
+	found, err := t.repo.SetArchived(ctx, id, archivedAt)
 	if err != nil {
 		return nil, err
 	}
-	for _, tag := range tags {
-		model.Tags = append(model.Tags, *tag)
+	if !found {
+		return nil, ErrTaskNotFound
+	}
+	return getTask(ctx, t.repo, id)
+}
+
+func (t *tasksUseCase) AutoArchive(ctx context.Context, completedBefore time.Time) (int64, error) {
+	return t.repo.ArchiveCompletedTasks(ctx, completedBefore, time.Now())
+}
+
+// normalizeRecurrence checks the recurrence rule of the task and stores it in
+// canonical form. The series starts at the due date, so one is required.
+func normalizeRecurrence(task *entities.Task) error {
+	if strings.TrimSpace(task.Recurrence) == "" {
+		task.Recurrence = ""
+		return nil
+	}
+	if task.DueAt == nil {
+		return ErrRecurrenceNeedsDue
+	}
+	rule, err := rrule.Parse(task.Recurrence)
+	if err != nil {
+		return err
+	}
+	task.Recurrence = rule.String()
+	return nil
+}
+
+// SpawnNext creates the next instance of a recurring task with its tags and
+// assignees. The instance is due at the first occurrence after both the due
+// date and now, so occurrences missed while the task was overdue are skipped.
+// It returns nil when the task is not recurring, has already spawned or its
+// series has ended.
+func (t *tasksUseCase) SpawnNext(ctx context.Context, taskID uuid.UUID, now time.Time) (*entities.Task, error) {
+	model, err := t.repo.GetTaskByID(ctx, taskID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrTaskNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	source := model.Task
+	if source.Recurrence == "" || source.DueAt == nil {
+		return nil, nil
+	}
+	rule, err := rrule.Parse(source.Recurrence)
+	if err != nil {
+		return nil, err
+	}
+	after := now
+	if source.DueAt.After(after) {
+		after = *source.DueAt
+	}
+	var next *entities.Task
+	if dueAt, index, ok := rule.Next(*source.DueAt, after); ok {
+		next = &entities.Task{
+			Title:       source.Title,
+			Description: source.Description,
+			Status:      entities.TaskStatusNew,
+			Priority:    source.Priority,
+			CreatedBy:   source.CreatedBy,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+			DueAt:       &dueAt,
+			Recurrence:  rule.Remaining(index).String(),
+		}
+	}
+	spawned, err := t.repo.SpawnRecurrence(ctx, taskID, next, now)
+	if err != nil || !spawned || next == nil {
+		return nil, err
+	}
+	metrics.TasksCreated.Inc()
+	return next, nil
+}
+
+// SpawnRecurrences spawns the next instance of every recurring task whose due
+// date has passed, whether it is done or not, and returns how many were created.
+func (t *tasksUseCase) SpawnRecurrences(ctx context.Context, now time.Time) (int, error) {
+	pending, err := t.repo.GetPendingRecurrences(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+	var spawned int
+	var errs []error
+	for _, task := range pending {
+		next, err := t.SpawnNext(ctx, task.ID, now)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if next != nil {
+			spawned++
+		}
+	}
+	return spawned, errors.Join(errs...)
+}
+
+// GetOccurrences lists the upcoming due dates of the series of a recurring task
+// the user can see, including the task's own while it is ahead. A task without
+// recurrence has none.
+func (t *tasksUseCase) GetOccurrences(ctx context.Context, taskID, userID uuid.UUID, limit int) ([]time.Time, error) {
+	if err := t.checkVisible(ctx, taskID, userID); err != nil {
+		return nil, err
+	}
+	model, err := t.repo.GetTaskByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	occurrences := []time.Time{}
+	if model.Task.Recurrence == "" || model.Task.DueAt == nil {
+		return occurrences, nil
 	}
-	return model, err
+	rule, err := rrule.Parse(model.Task.Recurrence)
+	if err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = defaultOccurrences
+	}
+	limit = min(limit, maxOccurrences)
+	return append(occurrences, rule.Occurrences(*model.Task.DueAt, time.Now(), limit)...), nil
+}
+
+// GetBoard groups the user's board by status. Without statuses the columns are
+// new, the other statuses in use in alphabetical order, and done; otherwise
+// exactly the given columns are returned, even when empty.
+func (t *tasksUseCase) GetBoard(ctx context.Context, userID uuid.UUID, statuses []string) ([]models.BoardColumn, error) {
+	tasks, err := t.repo.GetBoardTasks(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	withTags, err := t.withTags(ctx, tasks)
+	if err != nil {
+		return nil, err
+	}
+
+	byStatus := make(map[string][]*models.TasksWishTags)
+	for _, task := range withTags {
+		byStatus[task.Task.Status] = append(byStatus[task.Task.Status], task)
+	}
+	if len(statuses) == 0 {
+		statuses = boardStatuses(byStatus)
+	}
+	columns := make([]models.BoardColumn, 0, len(statuses))
+	for _, status := range statuses {
+		columns = append(columns, models.BoardColumn{Status: status, Tasks: byStatus[status]})
+	}
+	return columns, nil
 }
 
-func (t *tasksUseCase) Delete(ctx context.Context, id uuid.UUID) error {
-	return t.repo.DeleteTask(ctx, id)
+func boardStatuses(byStatus map[string][]*models.TasksWishTags) []string {
+	var others []string
+	for status := range byStatus {
+		if status != entities.TaskStatusNew && status != entities.TaskStatusDone {
+			others = append(others, status)
+		}
+	}
+	slices.Sort(others)
+	statuses := append([]string{entities.TaskStatusNew}, others...)
+	return append(statuses, entities.TaskStatusDone)
 }
 
-func (t *tasksUseCase) AddTags(ctx context.Context, taskID uuid.UUID, tags []*entities.Tag) error {
+// Move places a task the actor can see into a column of its creator's board.
+// Moving to another column changes the status of the task.
+func (t *tasksUseCase) Move(ctx context.Context, move models.TaskMove) (*models.Task, error) {
+	if err := t.checkVisible(ctx, move.TaskID, move.ActorID); err != nil {
+		return nil, err
+	}
+	if move.AfterID != nil && *move.AfterID == move.TaskID || move.BeforeID != nil && *move.BeforeID == move.TaskID {
+		return nil, ErrInvalidAnchor
+	}
+	now := time.Now()
+	previous, placed, err := t.repo.MoveTask(ctx, move.TaskID, move.Status, move.AfterID, move.BeforeID, now)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrTaskNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !placed {
+		return nil, ErrInvalidAnchor
+	}
+	if previous != move.Status {
+		t.publisher.Publish(ctx, events.TaskStatusChanged{
+			TaskID:     move.TaskID,
+			ActorID:    move.ActorID,
+			OldStatus:  previous,
+			NewStatus:  move.Status,
+			OccurredAt: now,
+		})
+	}
+	return getTask(ctx, t.repo, move.TaskID)
+}
+
+// AddTags links tags of the actor to a task the actor can see; other users' tags are not found.
+func (t *tasksUseCase) AddTags(ctx context.Context, taskID, actorID uuid.UUID, tags []*entities.Tag) error {
+	if err := t.checkVisible(ctx, taskID, actorID); err != nil {
+		return err
+	}
 	for _, tag := range tags {
-		if err := t.repo.AddTags(ctx, taskID, tag.ID); err != nil {
+		found, err := t.repo.AddTags(ctx, taskID, tag.ID, actorID)
+		if err != nil {
 			return err
 		}
+		if !found {
+			return ErrTagNotFound
+		}
 	}
 	return nil
 }
 
-func (t *tasksUseCase) RemoveTags(ctx context.Context, taskID uuid.UUID, tags []*entities.Tag) error {
+func (t *tasksUseCase) RemoveTags(ctx context.Context, taskID, actorID uuid.UUID, tags []*entities.Tag) error {
+	if err := t.checkVisible(ctx, taskID, actorID); err != nil {
+		return err
+	}
 	for _, tag := range tags {
 		if err := t.repo.RemoveTags(ctx, taskID, tag.ID); err != nil {
 			return err
@@ -184,3 +529,76 @@ func (t *tasksUseCase) RemoveTags(ctx context.Context, taskID uuid.UUID, tags []
 	}
 	return nil
 }
+
+// ReplaceTags makes the actor's tags on a task the actor can see exactly the
+// given titles, creating missing tags. Other users' tags on the task stay.
+func (t *tasksUseCase) ReplaceTags(ctx context.Context, taskID, actorID uuid.UUID, tags []string) (*models.Task, error) {
+	if err := t.checkVisible(ctx, taskID, actorID); err != nil {
+		return nil, err
+	}
+	if err := t.repo.ReplaceTags(ctx, taskID, actorID, normalizeTagTitles(tags)); err != nil {
+		return nil, err
+	}
+	return getTask(ctx, t.repo, taskID)
+}
+
+// normalizeTagTitles trims the titles and drops empty and repeated ones. The
+// result is never nil.
+func normalizeTagTitles(titles []string) []string {
+	normalized := make([]string, 0, len(titles))
+	seen := make(map[string]bool, len(titles))
+	for _, title := range titles {
+		title = strings.TrimSpace(title)
+		if title == "" || seen[title] {
+			continue
+		}
+		seen[title] = true
+		normalized = append(normalized, title)
+	}
+	return normalized
+}
+
+// AddAssignee assigns the user to a task the actor can see. Assigning someone
+// who is already assigned changes nothing and notifies nobody.
+func (t *tasksUseCase) AddAssignee(ctx context.Context, taskID, userID, actorID uuid.UUID) (*models.Task, error) {
+	if err := t.checkVisible(ctx, taskID, actorID); err != nil {
+		return nil, err
+	}
+	added, err := t.repo.AddAssignee(ctx, taskID, userID, actorID, time.Now())
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if added {
+		t.publisher.Publish(ctx, events.TaskAssigned{
+			TaskID:     taskID,
+			UserID:     userID,
+			AssignedBy: actorID,
+			OccurredAt: time.Now(),
+		})
+	}
+	return getTask(ctx, t.repo, taskID)
+}
+
+func (t *tasksUseCase) RemoveAssignee(ctx context.Context, taskID, userID, actorID uuid.UUID) (*models.Task, error) {
+	if err := t.checkVisible(ctx, taskID, actorID); err != nil {
+		return nil, err
+	}
+	if _, err := t.repo.RemoveAssignee(ctx, taskID, userID); err != nil {
+		return nil, err
+	}
+	return getTask(ctx, t.repo, taskID)
+}
+
+func (t *tasksUseCase) checkVisible(ctx context.Context, taskID, userID uuid.UUID) error {
+	visible, err := t.repo.IsVisibleTo(ctx, taskID, userID)
+	if err != nil {
+		return err
+	}
+	if !visible {
+		return ErrTaskNotFound
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+package usecases
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"maps"
+	"slices"
+	"strings"
+	"task-api/internal/adapters/models"
+	"task-api/internal/domain/entities"
+	"task-api/internal/domain/repositories"
+	"task-api/internal/infrastructure/metrics"
+	"task-api/pkg/placeholder"
+	"time"
+)
+
+var (
+	ErrTemplateNotFound = errors.New("template not found")
+	ErrMissingVariables = errors.New("template variables have no value")
+	ErrEmptyTaskTitle   = errors.New("task title is empty after substituting variables")
+)
+
+// TemplateUseCase manages the task templates of one owner. Templates of other
+// users are reported as not found.
+type TemplateUseCase interface {
+	Create(ctx context.Context, template *entities.Template) (*entities.Template, error)
+	GetTemplate(ctx context.Context, id, ownerID uuid.UUID) (*entities.Template, error)
+	GetTemplates(ctx context.Context, ownerID uuid.UUID) ([]*entities.Template, error)
+	Update(ctx context.Context, template *entities.Template) (*entities.Template, error)
+	Delete(ctx context.Context, id, ownerID uuid.UUID) error
+	Instantiate(ctx context.Context, id, userID uuid.UUID, values map[string]string, dueAt *time.Time) (*models.Task, error)
+}
+
+type templateUseCase struct {
+	repo  repositories.TemplateRepository
+	tasks repositories.TaskRepository
+}
+
+func NewTemplateUseCase(repo repositories.TemplateRepository, tasks repositories.TaskRepository) TemplateUseCase {
+	return &templateUseCase{repo: repo, tasks: tasks}
+}
+
+func (t *templateUseCase) Create(ctx context.Context, template *entities.Template) (*entities.Template, error) {
+	normalizeTemplate(template)
+	if err := t.repo.CreateTemplate(ctx, template); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+func (t *templateUseCase) GetTemplate(ctx context.Context, id, ownerID uuid.UUID) (*entities.Template, error) {
+	template, err := t.repo.GetTemplateByID(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrTemplateNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if template.OwnerID != ownerID {
+		return nil, ErrTemplateNotFound
+	}
+	return template, nil
+}
+
+func (t *templateUseCase) GetTemplates(ctx context.Context, ownerID uuid.UUID) ([]*entities.Template, error) {
+	return t.repo.GetTemplatesByOwner(ctx, ownerID)
+}
+
+// Update replaces everything but the owner of a template owned by template.OwnerID.
+func (t *templateUseCase) Update(ctx context.Context, template *entities.Template) (*entities.Template, error) {
+	existing, err := t.GetTemplate(ctx, template.ID, template.OwnerID)
+	if err != nil {
+		return nil, err
+	}
+	normalizeTemplate(template)
+	template.CreatedAt = existing.CreatedAt
+	if err := t.repo.UpdateTemplate(ctx, template); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+func (t *templateUseCase) Delete(ctx context.Context, id, ownerID uuid.UUID) error {
+	if _, err := t.GetTemplate(ctx, id, ownerID); err != nil {
+		return err
+	}
+	return t.repo.DeleteTemplate(ctx, id)
+}
+
+// Instantiate creates a task and its subtasks from the template in one
+// transaction, tagging all of them with the template's tags. Variables in
+// titles and descriptions take the given values; {{date}} defaults to today's
+// UTC date. Every variable needs a value, otherwise nothing is created.
+func (t *templateUseCase) Instantiate(ctx context.Context, id, userID uuid.UUID, values map[string]string, dueAt *time.Time) (*models.Task, error) {
+	template, err := t.GetTemplate(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	vars := map[string]string{"date": now.UTC().Format(time.DateOnly)}
+	maps.Copy(vars, values)
+
+	var missing []string
+	expand := func(text string) string {
+		expanded, names := placeholder.Expand(text, vars)
+		missing = append(missing, names...)
+		return expanded
+	}
+	task := &entities.Task{
+		Title:       expand(template.Title),
+		Description: expand(template.Description),
+		Status:      entities.TaskStatusNew,
+		Priority:    template.Priority,
+		CreatedBy:   userID,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		DueAt:       dueAt,
+	}
+	subtasks := make([]*entities.Task, 0, len(template.Subtasks))
+	for i, subtask := range template.Subtasks {
+		// Subtasks are listed by creation time; a microsecond apart they keep
+		// the order of the template.
+		createdAt := now.Add(time.Duration(i+1) * time.Microsecond)
+		subtasks = append(subtasks, &entities.Task{
+			Title:       expand(subtask.Title),
+			Description: expand(subtask.Description),
+			Status:      entities.TaskStatusNew,
+			Priority:    cmp.Or(subtask.Priority, template.Priority),
+			CreatedBy:   userID,
+			CreatedAt:   createdAt,
+			UpdatedAt:   createdAt,
+		})
+	}
+	if len(missing) > 0 {
+		slices.Sort(missing)
+		return nil, fmt.Errorf("%w: %s", ErrMissingVariables, strings.Join(slices.Compact(missing), ", "))
+	}
+	for _, created := range append([]*entities.Task{task}, subtasks...) {
+		if strings.TrimSpace(created.Title) == "" {
+			return nil, ErrEmptyTaskTitle
+		}
+	}
+
+	if err := t.tasks.CreateTaskWithSubtasks(ctx, task, subtasks, template.Tags); err != nil {
+		return nil, err
+	}
+	metrics.TasksCreated.Add(float64(1 + len(subtasks)))
+	return getTask(ctx, t.tasks, task.ID)
+}
+
+// normalizeTemplate trims the name, cleans up the tag titles the same way task
+// tags are and fills in the default priority.
+func normalizeTemplate(template *entities.Template) {
+	template.Name = strings.TrimSpace(template.Name)
+	template.Tags = normalizeTagTitles(template.Tags)
+	template.Priority = cmp.Or(template.Priority, entities.TaskPriorityMedium)
+	if template.Subtasks == nil {
+		template.Subtasks = []entities.TemplateSubtask{}
+	}
+}
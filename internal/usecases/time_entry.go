@@ -0,0 +1,166 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"task-api/internal/adapters/models"
+	"task-api/internal/domain/entities"
+	"task-api/internal/domain/repositories"
+	"time"
+)
+
+var (
+	ErrTimeEntryNotFound = errors.New("time entry not found")
+	ErrTimerRunning      = errors.New("another timer is already running")
+	ErrNoRunningTimer    = errors.New("no timer is running")
+	ErrEntryInFuture     = errors.New("time entry ends in the future")
+	ErrInvalidTimeRange  = errors.New("report range must start before it ends and span at most 366 days")
+)
+
+const maxReportRange = 366 * 24 * time.Hour
+
+// TimeEntryUseCase tracks the time users spend on the tasks they can see. Each
+// user has at most one running timer.
+type TimeEntryUseCase interface {
+	Start(ctx context.Context, taskID, userID uuid.UUID, note string) (*models.TimeEntry, error)
+	Stop(ctx context.Context, taskID, userID uuid.UUID) (*models.TimeEntry, error)
+	GetRunning(ctx context.Context, userID uuid.UUID) (*models.TimeEntry, error)
+	AddEntry(ctx context.Context, entry *entities.TimeEntry) (*models.TimeEntry, error)
+	GetEntries(ctx context.Context, taskID, userID uuid.UUID) ([]*models.TimeEntry, error)
+	DeleteEntry(ctx context.Context, id, userID uuid.UUID) error
+	Report(ctx context.Context, filter models.TimeReportFilter) (*models.TimeReport, error)
+}
+
+type timeEntryUseCase struct {
+	repo  repositories.TimeEntryRepository
+	tasks repositories.TaskRepository
+}
+
+func NewTimeEntryUseCase(repo repositories.TimeEntryRepository, tasks repositories.TaskRepository) TimeEntryUseCase {
+	return &timeEntryUseCase{repo: repo, tasks: tasks}
+}
+
+// Start returns ErrTimerRunning when the user is already tracking time, on
+// this or any other task.
+func (t *timeEntryUseCase) Start(ctx context.Context, taskID, userID uuid.UUID, note string) (*models.TimeEntry, error) {
+	if err := t.checkVisible(ctx, taskID, userID); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	entry := &entities.TimeEntry{TaskID: taskID, UserID: userID, StartedAt: now, Note: note, CreatedAt: now}
+	started, err := t.repo.StartTimer(ctx, entry)
+	if err != nil {
+		return nil, err
+	}
+	if !started {
+		return nil, ErrTimerRunning
+	}
+	return t.GetRunning(ctx, userID)
+}
+
+// Stop finishes the user's timer on the task.
+func (t *timeEntryUseCase) Stop(ctx context.Context, taskID, userID uuid.UUID) (*models.TimeEntry, error) {
+	entry, err := t.repo.StopTimer(ctx, taskID, userID, time.Now())
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNoRunningTimer
+	}
+	if err != nil {
+		return nil, err
+	}
+	return t.getEntry(ctx, entry.ID)
+}
+
+func (t *timeEntryUseCase) GetRunning(ctx context.Context, userID uuid.UUID) (*models.TimeEntry, error) {
+	entry, err := t.repo.GetRunningEntry(ctx, userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNoRunningTimer
+	}
+	return entry, err
+}
+
+// AddEntry records time entered by hand. Manual entries never run, so they do
+// not count against the running timer.
+func (t *timeEntryUseCase) AddEntry(ctx context.Context, entry *entities.TimeEntry) (*models.TimeEntry, error) {
+	if err := t.checkVisible(ctx, entry.TaskID, entry.UserID); err != nil {
+		return nil, err
+	}
+	if entry.EndedAt == nil || entry.EndedAt.After(time.Now()) {
+		return nil, ErrEntryInFuture
+	}
+	entry.CreatedAt = time.Now()
+	if err := t.repo.CreateEntry(ctx, entry); err != nil {
+		return nil, err
+	}
+	return t.getEntry(ctx, entry.ID)
+}
+
+// GetEntries lists the time all users tracked on a task the user can see.
+func (t *timeEntryUseCase) GetEntries(ctx context.Context, taskID, userID uuid.UUID) ([]*models.TimeEntry, error) {
+	if err := t.checkVisible(ctx, taskID, userID); err != nil {
+		return nil, err
+	}
+	return t.repo.GetEntriesByTask(ctx, taskID)
+}
+
+// DeleteEntry removes one of the user's own entries; deleting a running entry
+// discards the timer.
+func (t *timeEntryUseCase) DeleteEntry(ctx context.Context, id, userID uuid.UUID) error {
+	entry, err := t.repo.GetEntryByID(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrTimeEntryNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if entry.Entry.UserID != userID {
+		return ErrTimeEntryNotFound
+	}
+	return t.repo.DeleteEntry(ctx, id)
+}
+
+// Report sums the time tracked on the tasks the viewer can see. The totals
+// count every entry once, even when the rows are grouped by tag.
+func (t *timeEntryUseCase) Report(ctx context.Context, filter models.TimeReportFilter) (*models.TimeReport, error) {
+	if !filter.From.Before(filter.To) || filter.To.Sub(filter.From) > maxReportRange {
+		return nil, ErrInvalidTimeRange
+	}
+	if len(filter.GroupBy) == 0 {
+		filter.GroupBy = []string{models.TimeGroupUser}
+	}
+	rows, err := t.repo.GetTimeReport(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	total := filter
+	total.GroupBy = nil
+	totals, err := t.repo.GetTimeReport(ctx, total)
+	if err != nil {
+		return nil, err
+	}
+	report := &models.TimeReport{Filter: filter, Rows: rows}
+	if len(totals) > 0 {
+		report.TotalSeconds, report.TotalEntries = totals[0].Seconds, totals[0].Entries
+	}
+	return report, nil
+}
+
+func (t *timeEntryUseCase) getEntry(ctx context.Context, id uuid.UUID) (*models.TimeEntry, error) {
+	model, err := t.repo.GetEntryByID(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrTimeEntryNotFound
+	}
+	return model, err
+}
+
+func (t *timeEntryUseCase) checkVisible(ctx context.Context, taskID, userID uuid.UUID) error {
+	visible, err := t.tasks.IsVisibleTo(ctx, taskID, userID)
+	if err != nil {
+		return err
+	}
+	if !visible {
+		return ErrTaskNotFound
+	}
+	return nil
+}
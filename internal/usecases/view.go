@@ -0,0 +1,197 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"strings"
+	"task-api/internal/adapters/models"
+	"task-api/internal/domain/entities"
+	"task-api/internal/domain/repositories"
+	"task-api/pkg/taskquery"
+	"time"
+)
+
+var (
+	ErrViewNotFound  = errors.New("view not found")
+	ErrViewForbidden = errors.New("only the owner can change a view")
+	ErrShareWithSelf = errors.New("view cannot be shared with its owner")
+)
+
+// ViewUseCase manages saved views. A view is visible to its owner and to the
+// users it is shared with; running it lists the caller's own tasks.
+type ViewUseCase interface {
+	Create(ctx context.Context, view *entities.View) (*models.View, error)
+	GetView(ctx context.Context, id, userID uuid.UUID) (*models.View, error)
+	GetViews(ctx context.Context, userID uuid.UUID) ([]*models.View, error)
+	GetTasks(ctx context.Context, id, userID uuid.UUID) ([]*models.TasksWishTags, error)
+	Update(ctx context.Context, view *entities.View) (*models.View, error)
+	Delete(ctx context.Context, id, ownerID uuid.UUID) error
+	Share(ctx context.Context, id, ownerID, userID uuid.UUID) (*models.View, error)
+	Unshare(ctx context.Context, id, actorID, userID uuid.UUID) error
+}
+
+type viewUseCase struct {
+	repo  repositories.ViewRepository
+	tasks repositories.TaskRepository
+}
+
+func NewViewUseCase(repo repositories.ViewRepository, tasks repositories.TaskRepository) ViewUseCase {
+	return &viewUseCase{repo: repo, tasks: tasks}
+}
+
+func (v *viewUseCase) Create(ctx context.Context, view *entities.View) (*models.View, error) {
+	if err := normalizeViewFilter(&view.Filter); err != nil {
+		return nil, err
+	}
+	if err := v.repo.CreateView(ctx, view); err != nil {
+		return nil, err
+	}
+	return &models.View{View: *view}, nil
+}
+
+// GetView returns a view the user owns or that is shared with them; only the
+// owner sees whom it is shared with.
+func (v *viewUseCase) GetView(ctx context.Context, id, userID uuid.UUID) (*models.View, error) {
+	view, err := v.getVisible(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	model := &models.View{View: *view}
+	if view.OwnerID == userID {
+		if model.SharedWith, err = v.repo.GetShares(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+	return model, nil
+}
+
+func (v *viewUseCase) GetViews(ctx context.Context, userID uuid.UUID) ([]*models.View, error) {
+	views, err := v.repo.GetViewsForUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*models.View, 0, len(views))
+	for _, view := range views {
+		result = append(result, &models.View{View: *view})
+	}
+	return result, nil
+}
+
+// GetTasks runs the view's filter over the caller's tasks, so a shared view
+// never reveals tasks of its owner.
+func (v *viewUseCase) GetTasks(ctx context.Context, id, userID uuid.UUID) ([]*models.TasksWishTags, error) {
+	view, err := v.getVisible(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	model := &models.View{View: *view}
+	filter, err := model.TaskFilter()
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := v.tasks.GetAllTasksByUserID(ctx, userID, filter)
+	if err != nil {
+		return nil, err
+	}
+	return tasksWithTags(ctx, v.tasks, tasks)
+}
+
+// Update replaces the name and filter of a view owned by view.OwnerID.
+func (v *viewUseCase) Update(ctx context.Context, view *entities.View) (*models.View, error) {
+	if err := normalizeViewFilter(&view.Filter); err != nil {
+		return nil, err
+	}
+	if _, err := v.getOwned(ctx, view.ID, view.OwnerID); err != nil {
+		return nil, err
+	}
+	if err := v.repo.UpdateView(ctx, view); err != nil {
+		return nil, err
+	}
+	return v.GetView(ctx, view.ID, view.OwnerID)
+}
+
+func (v *viewUseCase) Delete(ctx context.Context, id, ownerID uuid.UUID) error {
+	if _, err := v.getOwned(ctx, id, ownerID); err != nil {
+		return err
+	}
+	return v.repo.DeleteView(ctx, id)
+}
+
+func (v *viewUseCase) Share(ctx context.Context, id, ownerID, userID uuid.UUID) (*models.View, error) {
+	if _, err := v.getOwned(ctx, id, ownerID); err != nil {
+		return nil, err
+	}
+	if userID == ownerID {
+		return nil, ErrShareWithSelf
+	}
+	err := v.repo.ShareView(ctx, id, userID, time.Now())
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return v.GetView(ctx, id, ownerID)
+}
+
+// Unshare revokes the user's access to the view. The owner can revoke anyone;
+// other users can only remove a view shared with themselves.
+func (v *viewUseCase) Unshare(ctx context.Context, id, actorID, userID uuid.UUID) error {
+	view, err := v.getVisible(ctx, id, actorID)
+	if err != nil {
+		return err
+	}
+	if view.OwnerID != actorID && userID != actorID {
+		return ErrViewForbidden
+	}
+	_, err = v.repo.UnshareView(ctx, id, userID)
+	return err
+}
+
+func (v *viewUseCase) getVisible(ctx context.Context, id, userID uuid.UUID) (*entities.View, error) {
+	view, err := v.repo.GetViewByID(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrViewNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if view.OwnerID == userID {
+		return view, nil
+	}
+	shared, err := v.repo.IsSharedWith(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !shared {
+		return nil, ErrViewNotFound
+	}
+	return view, nil
+}
+
+// getOwned reports views shared with the user as forbidden and everything
+// else the user cannot see as not found.
+func (v *viewUseCase) getOwned(ctx context.Context, id, userID uuid.UUID) (*entities.View, error) {
+	view, err := v.getVisible(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if view.OwnerID != userID {
+		return nil, ErrViewForbidden
+	}
+	return view, nil
+}
+
+// normalizeViewFilter cleans up the tag titles the same way task tags are and
+// returns a *taskquery.SyntaxError for an invalid search query.
+func normalizeViewFilter(filter *entities.ViewFilter) error {
+	filter.Tags = normalizeTagTitles(filter.Tags)
+	if len(filter.Tags) == 0 {
+		filter.Tags = nil
+	}
+	filter.Query = strings.TrimSpace(filter.Query)
+	_, err := taskquery.Parse(filter.Query)
+	return err
+}
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS users.idx_identities_user_id;
+DROP INDEX IF EXISTS users.idx_identities_provider_subject;
+DROP TABLE IF EXISTS users.identities;
\ No newline at end of file
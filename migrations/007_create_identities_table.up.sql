@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS users.identities
+(
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id uuid NOT NULL,
+    provider TEXT NOT NULL,
+    subject TEXT NOT NULL,
+    email TEXT,
+    created_at TIMESTAMP DEFAULT now(),
+    updated_at TIMESTAMP DEFAULT now(),
+    FOREIGN KEY (user_id) REFERENCES users.users(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX idx_identities_provider_subject ON users.identities(provider, subject);
+CREATE INDEX idx_identities_user_id ON users.identities(user_id);
\ No newline at end of file
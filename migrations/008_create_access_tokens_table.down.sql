@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS users.idx_access_tokens_user_id;
+DROP INDEX IF EXISTS users.idx_access_tokens_hash;
+DROP TABLE IF EXISTS users.access_tokens;
\ No newline at end of file
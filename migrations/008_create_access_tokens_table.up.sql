@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS users.access_tokens
+(
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id uuid NOT NULL,
+    name TEXT NOT NULL,
+    token_hash TEXT NOT NULL,
+    prefix TEXT NOT NULL,
+    scopes TEXT[] NOT NULL DEFAULT '{}',
+    expires_at TIMESTAMP NOT NULL,
+    last_used_at TIMESTAMP,
+    revoked_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    FOREIGN KEY (user_id) REFERENCES users.users(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX idx_access_tokens_hash ON users.access_tokens(token_hash);
+CREATE INDEX idx_access_tokens_user_id ON users.access_tokens(user_id);
\ No newline at end of file
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS service.idx_rate_limits_updated_at;
+DROP TABLE IF EXISTS service.rate_limits;
+DROP SCHEMA IF EXISTS service;
\ No newline at end of file
@@ -0,0 +1,10 @@
+CREATE SCHEMA IF NOT EXISTS service;
+
+CREATE TABLE IF NOT EXISTS service.rate_limits
+(
+    key TEXT PRIMARY KEY,
+    tokens DOUBLE PRECISION NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_rate_limits_updated_at ON service.rate_limits(updated_at);
\ No newline at end of file
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS service.idx_idempotency_keys_expires_at;
+DROP TABLE IF EXISTS service.idempotency_keys;
\ No newline at end of file
@@ -0,0 +1,16 @@
+CREATE SCHEMA IF NOT EXISTS service;
+
+CREATE TABLE IF NOT EXISTS service.idempotency_keys
+(
+    scope TEXT NOT NULL,
+    key TEXT NOT NULL,
+    request_hash TEXT NOT NULL,
+    status_code INT,
+    content_type TEXT,
+    response_body BYTEA,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    expires_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (scope, key)
+);
+
+CREATE INDEX idx_idempotency_keys_expires_at ON service.idempotency_keys(expires_at);
\ No newline at end of file
@@ -0,0 +1,8 @@
+DELETE FROM tasks.comments WHERE deleted_at IS NOT NULL;
+DELETE FROM tasks.tasks WHERE deleted_at IS NOT NULL;
+
+DROP INDEX IF EXISTS tasks.idx_comments_deleted_at;
+DROP INDEX IF EXISTS tasks.idx_tasks_deleted_at;
+
+ALTER TABLE tasks.comments DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE tasks.tasks DROP COLUMN IF EXISTS deleted_at;
\ No newline at end of file
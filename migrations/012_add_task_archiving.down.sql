@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS tasks.idx_tasks_completed_at;
+DROP INDEX IF EXISTS tasks.idx_tasks_archived_at;
+
+ALTER TABLE tasks.tasks DROP COLUMN IF EXISTS archived_at;
+ALTER TABLE tasks.tasks DROP COLUMN IF EXISTS completed_at;
\ No newline at end of file
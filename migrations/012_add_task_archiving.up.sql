@@ -0,0 +1,7 @@
+ALTER TABLE tasks.tasks ADD COLUMN IF NOT EXISTS completed_at TIMESTAMP;
+ALTER TABLE tasks.tasks ADD COLUMN IF NOT EXISTS archived_at TIMESTAMP;
+
+UPDATE tasks.tasks SET completed_at = updated_at WHERE status = 'done' AND completed_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_archived_at ON tasks.tasks(archived_at) WHERE archived_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_tasks_completed_at ON tasks.tasks(completed_at) WHERE archived_at IS NULL AND completed_at IS NOT NULL;
\ No newline at end of file
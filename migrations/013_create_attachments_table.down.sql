@@ -0,0 +1 @@
+DROP TABLE IF EXISTS tasks.attachments;
\ No newline at end of file
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS tasks.attachments
+(
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    task_id uuid NOT NULL REFERENCES tasks.tasks(id) ON DELETE CASCADE,
+    comment_id uuid REFERENCES tasks.comments(id) ON DELETE SET NULL,
+    uploaded_by uuid NOT NULL REFERENCES users.users(id) ON DELETE CASCADE,
+    file_name TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+    size BIGINT NOT NULL,
+    storage_key TEXT NOT NULL UNIQUE,
+    created_at TIMESTAMP DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_attachments_task_id ON tasks.attachments(task_id);
\ No newline at end of file
@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS tasks.comment_mentions;
+DROP TABLE IF EXISTS tasks.comment_revisions;
+
+DROP INDEX IF EXISTS tasks.idx_comments_parent_id;
+
+ALTER TABLE tasks.comments
+    DROP COLUMN IF EXISTS edited_at,
+    DROP COLUMN IF EXISTS parent_id;
\ No newline at end of file
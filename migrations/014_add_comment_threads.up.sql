@@ -0,0 +1,25 @@
+ALTER TABLE tasks.comments
+    ADD COLUMN IF NOT EXISTS parent_id uuid REFERENCES tasks.comments(id) ON DELETE CASCADE,
+    ADD COLUMN IF NOT EXISTS edited_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_comments_parent_id ON tasks.comments(parent_id);
+
+CREATE TABLE IF NOT EXISTS tasks.comment_revisions
+(
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    comment_id uuid NOT NULL REFERENCES tasks.comments(id) ON DELETE CASCADE,
+    content TEXT NOT NULL,
+    edited_by uuid REFERENCES users.users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_comment_revisions_comment_id ON tasks.comment_revisions(comment_id);
+
+CREATE TABLE IF NOT EXISTS tasks.comment_mentions
+(
+    comment_id uuid NOT NULL REFERENCES tasks.comments(id) ON DELETE CASCADE,
+    user_id uuid NOT NULL REFERENCES users.users(id) ON DELETE CASCADE,
+    PRIMARY KEY (comment_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_comment_mentions_user_id ON tasks.comment_mentions(user_id);
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE users.users
+    ADD COLUMN IF NOT EXISTS role TEXT NOT NULL DEFAULT 'user' CHECK (role IN ('user', 'admin'));
\ No newline at end of file
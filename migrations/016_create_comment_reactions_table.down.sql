@@ -0,0 +1 @@
+DROP TABLE IF EXISTS tasks.comment_reactions;
\ No newline at end of file
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS tasks.comment_reactions
+(
+    comment_id uuid NOT NULL REFERENCES tasks.comments(id) ON DELETE CASCADE,
+    user_id uuid NOT NULL REFERENCES users.users(id) ON DELETE CASCADE,
+    emoji TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT now(),
+    PRIMARY KEY (comment_id, user_id, emoji)
+);
\ No newline at end of file
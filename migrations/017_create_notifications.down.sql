@@ -0,0 +1,3 @@
+DROP SCHEMA IF EXISTS notifications CASCADE;
+
+DROP TABLE IF EXISTS tasks.task_assignees;
\ No newline at end of file
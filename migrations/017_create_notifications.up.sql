@@ -0,0 +1,37 @@
+CREATE TABLE IF NOT EXISTS tasks.task_assignees
+(
+    task_id uuid NOT NULL REFERENCES tasks.tasks(id) ON DELETE CASCADE,
+    user_id uuid NOT NULL REFERENCES users.users(id) ON DELETE CASCADE,
+    assigned_by uuid REFERENCES users.users(id) ON DELETE SET NULL,
+    assigned_at TIMESTAMP DEFAULT now(),
+    PRIMARY KEY (task_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_assignees_user_id ON tasks.task_assignees(user_id);
+
+CREATE SCHEMA IF NOT EXISTS notifications;
+
+CREATE TABLE IF NOT EXISTS notifications.notifications
+(
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id uuid NOT NULL REFERENCES users.users(id) ON DELETE CASCADE,
+    type TEXT NOT NULL,
+    task_id uuid REFERENCES tasks.tasks(id) ON DELETE CASCADE,
+    comment_id uuid REFERENCES tasks.comments(id) ON DELETE CASCADE,
+    actor_id uuid REFERENCES users.users(id) ON DELETE SET NULL,
+    data JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP DEFAULT now(),
+    read_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_notifications_user_id ON notifications.notifications(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_notifications_unread ON notifications.notifications(user_id) WHERE read_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS notifications.preferences
+(
+    user_id uuid NOT NULL REFERENCES users.users(id) ON DELETE CASCADE,
+    type TEXT NOT NULL,
+    enabled BOOLEAN NOT NULL,
+    updated_at TIMESTAMP DEFAULT now(),
+    PRIMARY KEY (user_id, type)
+);
\ No newline at end of file
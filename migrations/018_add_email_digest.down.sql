@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS notifications.digest_settings;
+
+DROP INDEX IF EXISTS tasks.idx_tasks_due_at;
+
+ALTER TABLE tasks.tasks DROP COLUMN IF EXISTS due_at;
\ No newline at end of file
@@ -0,0 +1,12 @@
+ALTER TABLE tasks.tasks ADD COLUMN IF NOT EXISTS due_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_due_at ON tasks.tasks(due_at) WHERE due_at IS NOT NULL AND deleted_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS notifications.digest_settings
+(
+    user_id uuid PRIMARY KEY REFERENCES users.users(id) ON DELETE CASCADE,
+    enabled BOOLEAN NOT NULL DEFAULT true,
+    timezone TEXT NOT NULL DEFAULT 'UTC',
+    last_sent_at TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT now()
+);
\ No newline at end of file
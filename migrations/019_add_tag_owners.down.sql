@@ -0,0 +1,23 @@
+DROP INDEX IF EXISTS tasks.idx_tags_owner_title;
+
+-- Copies of the same title owned by different users are merged into the oldest one.
+CREATE TEMP TABLE tag_keepers AS
+SELECT id, first_value(id) OVER (PARTITION BY title ORDER BY created_at, id) AS keeper_id
+FROM tasks.tags;
+
+INSERT INTO tasks.tasks_tags (task_id, tag_id)
+SELECT tt.task_id, k.keeper_id
+FROM tasks.tasks_tags tt
+JOIN tag_keepers k ON k.id = tt.tag_id
+WHERE k.id <> k.keeper_id
+ON CONFLICT DO NOTHING;
+
+DELETE FROM tasks.tags t USING tag_keepers k WHERE t.id = k.id AND k.id <> k.keeper_id;
+
+DROP TABLE tag_keepers;
+
+ALTER TABLE tasks.tags DROP COLUMN IF EXISTS description;
+ALTER TABLE tasks.tags DROP COLUMN IF EXISTS color;
+ALTER TABLE tasks.tags DROP COLUMN IF EXISTS owner_id;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tags_name ON tasks.tags(title);
\ No newline at end of file
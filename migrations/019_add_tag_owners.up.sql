@@ -0,0 +1,30 @@
+ALTER TABLE tasks.tags ADD COLUMN IF NOT EXISTS owner_id uuid REFERENCES users.users(id) ON DELETE CASCADE;
+ALTER TABLE tasks.tags ADD COLUMN IF NOT EXISTS color TEXT;
+ALTER TABLE tasks.tags ADD COLUMN IF NOT EXISTS description TEXT NOT NULL DEFAULT '';
+
+DROP INDEX IF EXISTS tasks.idx_tags_name;
+
+-- Every user who tagged their tasks with a global tag gets an own copy of it.
+CREATE TEMP TABLE tag_owners AS
+SELECT DISTINCT tt.tag_id, t.created_by AS owner_id, gen_random_uuid() AS new_id
+FROM tasks.tasks_tags tt
+JOIN tasks.tasks t ON t.id = tt.task_id;
+
+INSERT INTO tasks.tags (id, title, owner_id, created_at, updated_at)
+SELECT o.new_id, g.title, o.owner_id, g.created_at, g.updated_at
+FROM tag_owners o
+JOIN tasks.tags g ON g.id = o.tag_id;
+
+UPDATE tasks.tasks_tags tt
+SET tag_id = o.new_id
+FROM tasks.tasks t, tag_owners o
+WHERE t.id = tt.task_id AND o.tag_id = tt.tag_id AND o.owner_id = t.created_by;
+
+DROP TABLE tag_owners;
+
+-- The global tags are now either copied or unused by anyone, so there is nobody to own them.
+DELETE FROM tasks.tags WHERE owner_id IS NULL;
+
+ALTER TABLE tasks.tags ALTER COLUMN owner_id SET NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tags_owner_title ON tasks.tags(owner_id, title);
\ No newline at end of file
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS tasks.view_shares;
+
+DROP TABLE IF EXISTS tasks.views;
\ No newline at end of file
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS tasks.views
+(
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner_id uuid NOT NULL REFERENCES users.users(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    filter JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP DEFAULT now(),
+    updated_at TIMESTAMP DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_views_owner_id ON tasks.views(owner_id);
+
+CREATE TABLE IF NOT EXISTS tasks.view_shares
+(
+    view_id uuid NOT NULL REFERENCES tasks.views(id) ON DELETE CASCADE,
+    user_id uuid NOT NULL REFERENCES users.users(id) ON DELETE CASCADE,
+    shared_at TIMESTAMP DEFAULT now(),
+    PRIMARY KEY (view_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_view_shares_user_id ON tasks.view_shares(user_id);
\ No newline at end of file
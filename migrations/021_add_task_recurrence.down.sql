@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS tasks.idx_tasks_recurrence_pending;
+
+ALTER TABLE tasks.tasks DROP COLUMN IF EXISTS recurrence_spawned_at;
+ALTER TABLE tasks.tasks DROP COLUMN IF EXISTS recurrence;
\ No newline at end of file
@@ -0,0 +1,5 @@
+ALTER TABLE tasks.tasks ADD COLUMN IF NOT EXISTS recurrence TEXT;
+ALTER TABLE tasks.tasks ADD COLUMN IF NOT EXISTS recurrence_spawned_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_recurrence_pending ON tasks.tasks(due_at)
+    WHERE recurrence IS NOT NULL AND recurrence_spawned_at IS NULL AND deleted_at IS NULL;
\ No newline at end of file
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS tasks.templates;
+
+DROP INDEX IF EXISTS tasks.idx_tasks_parent_id;
+
+ALTER TABLE tasks.tasks DROP COLUMN IF EXISTS parent_id;
+ALTER TABLE tasks.tasks DROP COLUMN IF EXISTS priority;
\ No newline at end of file
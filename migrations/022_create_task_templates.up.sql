@@ -0,0 +1,20 @@
+ALTER TABLE tasks.tasks ADD COLUMN IF NOT EXISTS priority TEXT NOT NULL DEFAULT 'medium';
+ALTER TABLE tasks.tasks ADD COLUMN IF NOT EXISTS parent_id uuid REFERENCES tasks.tasks(id) ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_parent_id ON tasks.tasks(parent_id);
+
+CREATE TABLE IF NOT EXISTS tasks.templates
+(
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner_id uuid NOT NULL REFERENCES users.users(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    title TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    priority TEXT NOT NULL DEFAULT 'medium',
+    tags TEXT[] NOT NULL DEFAULT '{}',
+    subtasks JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMP DEFAULT now(),
+    updated_at TIMESTAMP DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_templates_owner_id ON tasks.templates(owner_id);
\ No newline at end of file
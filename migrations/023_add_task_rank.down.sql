@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS tasks.idx_tasks_board;
+
+ALTER TABLE tasks.tasks DROP COLUMN IF EXISTS rank;
\ No newline at end of file
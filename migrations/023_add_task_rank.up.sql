@@ -0,0 +1,9 @@
+ALTER TABLE tasks.tasks ADD COLUMN IF NOT EXISTS rank TEXT COLLATE "C";
+
+UPDATE tasks.tasks t SET rank = lpad(r.n::text, 10, '0') || 'V'
+FROM (SELECT id, row_number() OVER (PARTITION BY created_by ORDER BY created_at, id) AS n FROM tasks.tasks) r
+WHERE t.id = r.id AND t.rank IS NULL;
+
+ALTER TABLE tasks.tasks ALTER COLUMN rank SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_board ON tasks.tasks(created_by, status, rank) WHERE deleted_at IS NULL AND archived_at IS NULL;
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS tasks.time_entries;
\ No newline at end of file
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS tasks.time_entries
+(
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    task_id uuid NOT NULL REFERENCES tasks.tasks(id) ON DELETE CASCADE,
+    user_id uuid NOT NULL REFERENCES users.users(id) ON DELETE CASCADE,
+    started_at TIMESTAMP NOT NULL,
+    ended_at TIMESTAMP,
+    note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT now(),
+    CHECK (ended_at IS NULL OR ended_at >= started_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_time_entries_task_id ON tasks.time_entries(task_id);
+CREATE INDEX IF NOT EXISTS idx_time_entries_started_at ON tasks.time_entries(started_at);
+-- A user has at most one running timer.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_time_entries_running ON tasks.time_entries(user_id) WHERE ended_at IS NULL;
\ No newline at end of file
@@ -11,10 +11,23 @@ import (
 type AppConfig struct {
 	AppName       string `env:"APP_NAME"`
 	AddressServer string `env:"ADDRESS_SERVER"`
-	Auth          Auth
-	Logger        Logger `envPrefix:"LOGGER_"`
-	Telemetry     Telemetry
-	MainStorage   struct {
+	// ShutdownDrainDelay is how long /readyz reports not ready before the server stops accepting connections.
+	ShutdownDrainDelay time.Duration `env:"SHUTDOWN_DRAIN_DELAY" envDefault:"5s"`
+	Auth               Auth
+	OIDC               OIDC
+	RateLimit          RateLimit
+	Idempotency        Idempotency
+	Logger             Logger `envPrefix:"LOGGER_"`
+	Telemetry          Telemetry
+	Metrics            Metrics
+	Trash              Trash
+	Archive            Archive
+	Storage            Storage
+	Attachments        Attachments
+	Mail               Mail
+	Digest             Digest
+	Recurrence         Recurrence
+	MainStorage        struct {
 		Postgres PostgresConfig `envPrefix:"POSTGRES_"`
 	}
 }
@@ -26,6 +39,8 @@ type PostgresConfig struct {
 	Password string `env:"DB_PASSWORD" envDefault:"postgres"`
 	DBName   string `env:"DB_NAME" envDefault:"postgres"`
 	SSLMode  string `env:"DB_SSLMODE" envDefault:"disable"`
+	// AutoMigrate applies pending migrations on application start.
+	AutoMigrate bool `env:"AUTO_MIGRATE" envDefault:"true"`
 }
 
 type Auth struct {
@@ -33,6 +48,33 @@ type Auth struct {
 	JWTExpiry        time.Duration `env:"JWT_EXPIRY" envDefault:"60m"`
 	JWTAlgorithm     string        `env:"JWT_ALGORITHM" envDefault:"HS256"`
 	JWTRefreshExpiry time.Duration `env:"JWT_REFRESH_EXPIRY" envDefault:"43200m"`
+
+	AccessTokenDefaultExpiry time.Duration `env:"ACCESS_TOKEN_DEFAULT_EXPIRY" envDefault:"2160h"`
+	AccessTokenMaxExpiry     time.Duration `env:"ACCESS_TOKEN_MAX_EXPIRY" envDefault:"8760h"`
+}
+
+type OIDC struct {
+	Enabled      bool          `env:"OIDC_ENABLED" envDefault:"false"`
+	Provider     string        `env:"OIDC_PROVIDER" envDefault:"corporate"`
+	IssuerURL    string        `env:"OIDC_ISSUER_URL"`
+	ClientID     string        `env:"OIDC_CLIENT_ID"`
+	ClientSecret string        `env:"OIDC_CLIENT_SECRET"`
+	RedirectURL  string        `env:"OIDC_REDIRECT_URL"`
+	Scopes       []string      `env:"OIDC_SCOPES" envDefault:"openid,profile,email" envSeparator:","`
+	StateTTL     time.Duration `env:"OIDC_STATE_TTL" envDefault:"10m"`
+}
+
+type RateLimit struct {
+	Enabled   bool    `env:"RATE_LIMIT_ENABLED" envDefault:"true"`
+	Backend   string  `env:"RATE_LIMIT_BACKEND" envDefault:"memory"`
+	AuthRate  float64 `env:"RATE_LIMIT_AUTH_RPS" envDefault:"0.5"`
+	AuthBurst int     `env:"RATE_LIMIT_AUTH_BURST" envDefault:"10"`
+	APIRate   float64 `env:"RATE_LIMIT_API_RPS" envDefault:"20"`
+	APIBurst  int     `env:"RATE_LIMIT_API_BURST" envDefault:"100"`
+}
+
+type Idempotency struct {
+	TTL time.Duration `env:"IDEMPOTENCY_TTL" envDefault:"24h"`
 }
 
 type Logger struct {
@@ -52,6 +94,68 @@ type Telemetry struct {
 	Local bool   `env:"TELEMETRY_LOCAL" envDefault:"true"`
 }
 
+type Metrics struct {
+	Enabled bool `env:"METRICS_ENABLED" envDefault:"true"`
+	// Address starts a separate admin listener for /metrics; empty serves it on the main server.
+	Address string `env:"METRICS_ADDRESS"`
+}
+
+type Trash struct {
+	// RetentionDays is how long deleted tasks and comments stay restorable; 0 disables the purge.
+	RetentionDays int           `env:"TRASH_RETENTION_DAYS" envDefault:"30"`
+	PurgeInterval time.Duration `env:"TRASH_PURGE_INTERVAL" envDefault:"1h"`
+}
+
+type Archive struct {
+	// AutoArchiveDays archives tasks that have been done for that many days; 0 disables it.
+	AutoArchiveDays int           `env:"AUTO_ARCHIVE_DAYS" envDefault:"0"`
+	Interval        time.Duration `env:"AUTO_ARCHIVE_INTERVAL" envDefault:"1h"`
+}
+
+type Recurrence struct {
+	// Interval is how often recurring tasks past their due date are checked.
+	Interval time.Duration `env:"RECURRENCE_INTERVAL" envDefault:"5m"`
+}
+
+type Storage struct {
+	Backend   string `env:"STORAGE_BACKEND" envDefault:"local"`
+	LocalPath string `env:"STORAGE_LOCAL_PATH" envDefault:"./data/attachments"`
+	S3        S3Storage
+}
+
+type S3Storage struct {
+	Endpoint  string `env:"STORAGE_S3_ENDPOINT"`
+	Region    string `env:"STORAGE_S3_REGION" envDefault:"us-east-1"`
+	Bucket    string `env:"STORAGE_S3_BUCKET"`
+	AccessKey string `env:"STORAGE_S3_ACCESS_KEY"`
+	SecretKey string `env:"STORAGE_S3_SECRET_KEY"`
+	PathStyle bool   `env:"STORAGE_S3_PATH_STYLE" envDefault:"true"`
+}
+
+type Attachments struct {
+	MaxSize int64 `env:"ATTACHMENTS_MAX_SIZE" envDefault:"10485760"`
+}
+
+type Mail struct {
+	// Backend is smtp or file; file writes .eml files to FileDir instead of sending them.
+	Backend      string        `env:"MAIL_BACKEND" envDefault:"file"`
+	From         string        `env:"MAIL_FROM" envDefault:"Task API <noreply@localhost>"`
+	FileDir      string        `env:"MAIL_FILE_DIR" envDefault:"./data/mail"`
+	SMTPHost     string        `env:"MAIL_SMTP_HOST"`
+	SMTPPort     string        `env:"MAIL_SMTP_PORT" envDefault:"587"`
+	SMTPUsername string        `env:"MAIL_SMTP_USERNAME"`
+	SMTPPassword string        `env:"MAIL_SMTP_PASSWORD"`
+	SMTPTimeout  time.Duration `env:"MAIL_SMTP_TIMEOUT" envDefault:"30s"`
+}
+
+type Digest struct {
+	Enabled bool `env:"DIGEST_ENABLED" envDefault:"false"`
+	// SendHour is the local hour of the user after which the daily digest is sent.
+	SendHour      int           `env:"DIGEST_SEND_HOUR" envDefault:"8"`
+	DueSoonWindow time.Duration `env:"DIGEST_DUE_SOON_WINDOW" envDefault:"48h"`
+	Interval      time.Duration `env:"DIGEST_INTERVAL" envDefault:"15m"`
+}
+
 func (c *AppConfig) ReadEnvConfig() error {
 	if err := godotenv.Load(); err != nil {
 		log.Println("Error loading .env file")
@@ -83,5 +187,65 @@ func (c *AppConfig) Validate() error {
 	if c.Auth.JWTSecret == "" {
 		return errors.New("no jwt secret provided")
 	}
+	if c.RateLimit.Enabled {
+		if c.RateLimit.Backend != "memory" && c.RateLimit.Backend != "postgres" {
+			return errors.New("rate limit backend must be memory or postgres")
+		}
+		if c.RateLimit.AuthRate <= 0 || c.RateLimit.APIRate <= 0 {
+			return errors.New("rate limit rps must be positive")
+		}
+		if c.RateLimit.AuthBurst <= 0 || c.RateLimit.APIBurst <= 0 {
+			return errors.New("rate limit burst must be positive")
+		}
+	}
+	if c.Trash.RetentionDays > 0 && c.Trash.PurgeInterval <= 0 {
+		return errors.New("trash purge interval must be positive")
+	}
+	if c.Archive.AutoArchiveDays > 0 && c.Archive.Interval <= 0 {
+		return errors.New("auto archive interval must be positive")
+	}
+	if c.Recurrence.Interval <= 0 {
+		return errors.New("recurrence interval must be positive")
+	}
+	switch c.Storage.Backend {
+	case "local":
+	case "s3":
+		if c.Storage.S3.Endpoint == "" || c.Storage.S3.Bucket == "" {
+			return errors.New("no s3 endpoint or bucket provided")
+		}
+	default:
+		return errors.New("storage backend must be local or s3")
+	}
+	if c.Attachments.MaxSize <= 0 {
+		return errors.New("attachments max size must be positive")
+	}
+	switch c.Mail.Backend {
+	case "file":
+	case "smtp":
+		if c.Mail.SMTPHost == "" {
+			return errors.New("no smtp host provided")
+		}
+	default:
+		return errors.New("mail backend must be smtp or file")
+	}
+	if c.Digest.Enabled {
+		if c.Digest.SendHour < 0 || c.Digest.SendHour > 23 {
+			return errors.New("digest send hour must be between 0 and 23")
+		}
+		if c.Digest.Interval <= 0 {
+			return errors.New("digest interval must be positive")
+		}
+	}
+	if c.OIDC.Enabled {
+		if c.OIDC.IssuerURL == "" {
+			return errors.New("no oidc issuer url provided")
+		}
+		if c.OIDC.ClientID == "" {
+			return errors.New("no oidc client id provided")
+		}
+		if c.OIDC.RedirectURL == "" {
+			return errors.New("no oidc redirect url provided")
+		}
+	}
 	return nil
 }
@@ -2,15 +2,17 @@ package connectors
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"github.com/exaring/otelpgx"
-	"github.com/golang-migrate/migrate/v4"
-	"github.com/golang-migrate/migrate/v4/database/postgres"
-	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/jackc/pgx/v5/stdlib"
 	"log"
+	"task-api/migrations"
 	"task-api/pkg/config"
+	"task-api/pkg/migrator"
 	"time"
 )
 
@@ -19,15 +21,7 @@ type PostgresConnect struct {
 }
 
 func NewPostgresConnect(cfg *config.PostgresConfig) (*PostgresConnect, error) {
-	connString := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s pool_max_conns=10 pool_max_conn_lifetime=1h30m",
-		cfg.Host,
-		cfg.Port,
-		cfg.User,
-		cfg.Password,
-		cfg.DBName,
-		cfg.SSLMode,
-	)
-	parseConfig, err := pgxpool.ParseConfig(connString)
+	parseConfig, err := pgxpool.ParseConfig(connString(cfg) + " pool_max_conns=10 pool_max_conn_lifetime=1h30m")
 	if err != nil {
 		log.Println("Ошибка при парсенге конфига:", err)
 		return nil, err
@@ -43,24 +37,10 @@ func NewPostgresConnect(cfg *config.PostgresConfig) (*PostgresConnect, error) {
 		return nil, err
 	}
 
-	driver, err := postgres.WithInstance(stdlib.OpenDB(*parseConfig.ConnConfig), &postgres.Config{
-		StatementTimeout: time.Minute,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("%s:%w", "postgres driver", err)
-	}
-
-	m, err := migrate.NewWithDatabaseInstance("file://migrations", cfg.DBName, driver)
-	if err != nil {
-		log.Println(err)
-	}
-	if err := m.Up(); err != nil {
-		if err == migrate.ErrNoChange {
-			log.Println("Миграций для применения не обнаружено")
-		}
-		log.Printf("Ошибка при применении миграций: %v", err)
+	if err := prepareSchema(cfg); err != nil {
+		pool.Close()
+		return nil, err
 	}
-	log.Println("Миграции успешно применены")
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -73,6 +53,59 @@ func NewPostgresConnect(cfg *config.PostgresConfig) (*PostgresConnect, error) {
 
 }
 
+// OpenDB returns a database/sql handle for tools that do not work with pgxpool, such as migrations.
+func OpenDB(cfg *config.PostgresConfig) (*sql.DB, error) {
+	connConfig, err := pgx.ParseConfig(connString(cfg))
+	if err != nil {
+		return nil, err
+	}
+	return stdlib.OpenDB(*connConfig), nil
+}
+
+func connString(cfg *config.PostgresConfig) string {
+	return fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
+		cfg.Host,
+		cfg.Port,
+		cfg.User,
+		cfg.Password,
+		cfg.DBName,
+		cfg.SSLMode,
+	)
+}
+
+// prepareSchema applies embedded migrations when enabled and refuses to start
+// against a schema that is dirty or older than the binary expects.
+func prepareSchema(cfg *config.PostgresConfig) error {
+	db, err := OpenDB(cfg)
+	if err != nil {
+		return err
+	}
+	m, err := migrator.New(migrations.FS, db, cfg.DBName)
+	if err != nil {
+		db.Close()
+		return fmt.Errorf("%s:%w", "migrations", err)
+	}
+	defer m.Close()
+
+	if cfg.AutoMigrate {
+		if err := m.UpLocked(context.Background()); err != nil {
+			log.Printf("Ошибка при применении миграций: %v", err)
+			return fmt.Errorf("%s:%w", "migrations", err)
+		}
+		log.Println("Миграции успешно применены")
+	}
+
+	current, latest, err := m.CheckVersion()
+	switch {
+	case errors.Is(err, migrator.ErrSchemaAhead):
+		// Expected while rolling back a deployment: the previous binary runs against a newer schema.
+		log.Printf("Версия схемы БД (%d) новее последней миграции приложения (%d)", current, latest)
+	case err != nil:
+		return fmt.Errorf("schema version %d, expected %d: %w", current, latest, err)
+	}
+	return nil
+}
+
 func (pool *PostgresConnect) GetConnect() *pgxpool.Pool {
 	return pool.Pool
 }
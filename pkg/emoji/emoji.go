@@ -0,0 +1,53 @@
+// Package emoji validates reaction values.
+package emoji
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// MaxRunes bounds a single emoji. Family and flag sequences joined with ZWJ
+// are the longest in common use and stay well below it.
+const MaxRunes = 16
+
+const (
+	zeroWidthJoiner   = '\u200d'
+	variationSelector = '\ufe0f'
+	keycap            = '\u20e3'
+)
+
+// Valid reports whether s is a single emoji or emoji sequence: pictographic
+// symbols optionally combined with variation selectors, skin tone modifiers,
+// zero-width joiners and keycaps. Text, whitespace and markup are rejected.
+func Valid(s string) bool {
+	if s == "" || !utf8.ValidString(s) || utf8.RuneCountInString(s) > MaxRunes {
+		return false
+	}
+	runes := []rune(s)
+	pictographic := false
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case unicode.Is(unicode.So, r):
+			pictographic = true
+		case r == zeroWidthJoiner, unicode.Is(unicode.Sk, r), unicode.Is(unicode.Mn, r):
+		case isKeycapBase(r):
+			// A digit, # or * only counts as the base of a keycap: "1️⃣".
+			if i+1 < len(runes) && runes[i+1] == variationSelector {
+				i++
+			}
+			if i+1 >= len(runes) || runes[i+1] != keycap {
+				return false
+			}
+			i++
+			pictographic = true
+		default:
+			return false
+		}
+	}
+	return pictographic
+}
+
+func isKeycapBase(r rune) bool {
+	return r == '#' || r == '*' || (r >= '0' && r <= '9')
+}
@@ -0,0 +1,31 @@
+package emoji_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"task-api/pkg/emoji"
+	"testing"
+)
+
+func TestValid(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{value: "👍", want: true},
+		{value: "❤️", want: true},
+		{value: "👍🏽", want: true},
+		{value: "👩‍💻", want: true},
+		{value: "🇷🇺", want: true},
+		{value: "1️⃣", want: true},
+		{value: "", want: false},
+		{value: "ok", want: false},
+		{value: "👍 ", want: false},
+		{value: "<b>", want: false},
+		{value: "1", want: false},
+		{value: "👍1", want: false},
+		{value: "🎉🎉🎉🎉🎉🎉🎉🎉🎉🎉🎉🎉🎉🎉🎉🎉🎉", want: false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, emoji.Valid(tt.value), "value %q", tt.value)
+	}
+}
@@ -0,0 +1,36 @@
+// Package mention extracts @handles from free text such as comment bodies.
+package mention
+
+import (
+	"regexp"
+	"strings"
+)
+
+// A handle is either a full e-mail address (@bob@example.com) or a single
+// word (@bob). The @ must start the text or follow a character that cannot be
+// part of a handle, so plain e-mail addresses in the text are not mentions.
+var pattern = regexp.MustCompile(`(?:^|[^\p{L}\p{N}_.+@-])@([\p{L}\p{N}_.+-]+(?:@[\p{L}\p{N}-]+(?:\.[\p{L}\p{N}-]+)+)?)`)
+
+// Parse returns the distinct handles mentioned in text, lower-cased and in
+// order of first appearance.
+func Parse(text string) []string {
+	var handles []string
+	seen := make(map[string]struct{})
+	for _, m := range pattern.FindAllStringSubmatch(text, -1) {
+		handle := strings.ToLower(strings.TrimRight(m[1], ".-+"))
+		if handle == "" {
+			continue
+		}
+		if _, ok := seen[handle]; ok {
+			continue
+		}
+		seen[handle] = struct{}{}
+		handles = append(handles, handle)
+	}
+	return handles
+}
+
+// IsEmail reports whether a handle returned by Parse is a full e-mail address.
+func IsEmail(handle string) bool {
+	return strings.Contains(handle, "@")
+}
@@ -0,0 +1,35 @@
+package mention_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"task-api/pkg/mention"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{name: "none", text: "no mentions here", want: nil},
+		{name: "single", text: "@alice please look", want: []string{"alice"}},
+		{name: "email", text: "cc @Bob@Example.com", want: []string{"bob@example.com"}},
+		{name: "trailing punctuation", text: "thanks, @alice. And @bob!", want: []string{"alice", "bob"}},
+		{name: "duplicates", text: "@alice @ALICE @alice", want: []string{"alice"}},
+		{name: "plain email is not a mention", text: "write to alice@example.com", want: nil},
+		{name: "parenthesised", text: "(@carol)", want: []string{"carol"}},
+		{name: "unicode", text: "@иван проверь", want: []string{"иван"}},
+		{name: "bare at", text: "meet @ noon", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, mention.Parse(tt.text))
+		})
+	}
+}
+
+func TestIsEmail(t *testing.T) {
+	assert.True(t, mention.IsEmail("bob@example.com"))
+	assert.False(t, mention.IsEmail("bob"))
+}
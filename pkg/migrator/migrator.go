@@ -0,0 +1,247 @@
+// Package migrator wraps golang-migrate with the operations used by the
+// migration CLI and by the application at startup.
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"time"
+)
+
+// advisoryLockKey serializes auto-migration between application replicas.
+// It must differ from the key golang-migrate takes for each individual run.
+const advisoryLockKey int64 = 7046139248121105917
+
+var (
+	ErrNoVersion    = migrate.ErrNilVersion
+	ErrSchemaDirty  = errors.New("database schema is dirty")
+	ErrSchemaBehind = errors.New("database schema is older than the latest migration")
+	ErrSchemaAhead  = errors.New("database schema is newer than the latest migration")
+)
+
+// Migration is a version found in the migration source.
+type Migration struct {
+	Version uint
+	Name    string
+	Applied bool
+}
+
+type Migrator struct {
+	m    *migrate.Migrate
+	db   *sql.DB
+	fsys fs.FS
+}
+
+// New uses fsys as the migration source. The migrator takes ownership of db and closes it in Close.
+func New(fsys fs.FS, db *sql.DB, dbName string) (*Migrator, error) {
+	src, err := iofs.New(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("migration source: %w", err)
+	}
+	driver, err := postgres.WithInstance(db, &postgres.Config{
+		DatabaseName:     dbName,
+		StatementTimeout: time.Minute,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("postgres driver: %w", err)
+	}
+	m, err := migrate.NewWithInstance("iofs", src, dbName, driver)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{m: m, db: db, fsys: fsys}, nil
+}
+
+func (m *Migrator) Close() error {
+	srcErr, dbErr := m.m.Close()
+	return errors.Join(srcErr, dbErr)
+}
+
+// Up applies all pending migrations, or at most steps of them when steps > 0.
+func (m *Migrator) Up(steps int) error {
+	if steps > 0 {
+		return ignoreNoChange(m.m.Steps(steps))
+	}
+	return ignoreNoChange(m.m.Up())
+}
+
+// Down reverts steps migrations.
+func (m *Migrator) Down(steps int) error {
+	if steps <= 0 {
+		return errors.New("number of steps must be positive")
+	}
+	return ignoreNoChange(m.m.Steps(-steps))
+}
+
+func (m *Migrator) Goto(version uint) error {
+	return ignoreNoChange(m.m.Migrate(version))
+}
+
+func (m *Migrator) Force(version int) error {
+	return m.m.Force(version)
+}
+
+// Version returns ErrNoVersion when no migration has been applied yet.
+func (m *Migrator) Version() (uint, bool, error) {
+	return m.m.Version()
+}
+
+// Migrations lists every migration in the source and marks the applied ones.
+func (m *Migrator) Migrations() ([]Migration, error) {
+	all, err := List(m.fsys)
+	if err != nil {
+		return nil, err
+	}
+	version, _, err := m.Version()
+	if err != nil && !errors.Is(err, ErrNoVersion) {
+		return nil, err
+	}
+	for i := range all {
+		all[i].Applied = err == nil && all[i].Version <= version
+	}
+	return all, nil
+}
+
+// Plan returns the migrations that moving to target would run, in execution order.
+func (m *Migrator) Plan(target uint) ([]Migration, error) {
+	all, err := m.Migrations()
+	if err != nil {
+		return nil, err
+	}
+	var plan []Migration
+	for _, mg := range all {
+		if !mg.Applied && mg.Version <= target {
+			plan = append(plan, mg)
+		}
+	}
+	for i := len(all) - 1; i >= 0; i-- {
+		if all[i].Applied && all[i].Version > target {
+			plan = append(plan, all[i])
+		}
+	}
+	return plan, nil
+}
+
+// Pending returns the migrations that Up would apply.
+func (m *Migrator) Pending() ([]Migration, error) {
+	all, err := m.Migrations()
+	if err != nil {
+		return nil, err
+	}
+	var pending []Migration
+	for _, mg := range all {
+		if !mg.Applied {
+			pending = append(pending, mg)
+		}
+	}
+	return pending, nil
+}
+
+// CheckVersion compares the database schema with the newest migration in the source.
+func (m *Migrator) CheckVersion() (current, latest uint, err error) {
+	all, err := List(m.fsys)
+	if err != nil {
+		return 0, 0, err
+	}
+	if len(all) > 0 {
+		latest = all[len(all)-1].Version
+	}
+	current, dirty, err := m.Version()
+	if err != nil && !errors.Is(err, ErrNoVersion) {
+		return 0, latest, err
+	}
+	switch {
+	case dirty:
+		return current, latest, ErrSchemaDirty
+	case current < latest:
+		return current, latest, ErrSchemaBehind
+	case current > latest:
+		return current, latest, ErrSchemaAhead
+	}
+	return current, latest, nil
+}
+
+// UpLocked applies pending migrations while holding a session-level advisory
+// lock, so that replicas starting together do not race each other.
+func (m *Migrator) UpLocked(ctx context.Context) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, advisoryLockKey); err != nil {
+		return fmt.Errorf("acquire migration lock: %w", err)
+	}
+	defer conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, advisoryLockKey)
+	return m.Up(0)
+}
+
+func ignoreNoChange(err error) error {
+	if errors.Is(err, migrate.ErrNoChange) {
+		return nil
+	}
+	return err
+}
+
+// List reads the migrations available in fsys sorted by version.
+func List(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[uint]bool)
+	var out []Migration
+	for _, e := range entries {
+		mg, err := source.Parse(e.Name())
+		if err != nil || seen[mg.Version] {
+			continue
+		}
+		seen[mg.Version] = true
+		out = append(out, Migration{Version: mg.Version, Name: mg.Identifier})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Version < out[j].Version })
+	return out, nil
+}
+
+var namePattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// Create writes an empty up/down pair with the next three-digit sequence number.
+func Create(dir, name string) ([]string, error) {
+	if !namePattern.MatchString(name) {
+		return nil, errors.New("migration name must contain only lowercase letters, digits and underscores")
+	}
+	existing, err := List(os.DirFS(dir))
+	if err != nil {
+		return nil, err
+	}
+	var next uint = 1
+	if len(existing) > 0 {
+		next = existing[len(existing)-1].Version + 1
+	}
+	base := fmt.Sprintf("%03d_%s", next, name)
+	files := []string{
+		filepath.Join(dir, base+".up.sql"),
+		filepath.Join(dir, base+".down.sql"),
+	}
+	for _, f := range files {
+		fh, err := os.OpenFile(f, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+		if err != nil {
+			return nil, err
+		}
+		if err := fh.Close(); err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
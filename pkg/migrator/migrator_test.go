@@ -0,0 +1,60 @@
+package migrator_test
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"task-api/migrations"
+	"task-api/pkg/migrator"
+	"testing"
+	"testing/fstest"
+)
+
+func TestList(t *testing.T) {
+	fsys := fstest.MapFS{
+		"002_create_tasks.up.sql":   {},
+		"002_create_tasks.down.sql": {},
+		"001_create_users.up.sql":   {},
+		"001_create_users.down.sql": {},
+		"README.md":                 {},
+	}
+	list, err := migrator.List(fsys)
+	require.NoError(t, err)
+	assert.Equal(t, []migrator.Migration{
+		{Version: 1, Name: "create_users"},
+		{Version: 2, Name: "create_tasks"},
+	}, list)
+}
+
+func TestCreate(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "009_create_rate_limits.up.sql"), nil, 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "009_create_rate_limits.down.sql"), nil, 0o644))
+
+	files, err := migrator.Create(dir, "add_due_dates")
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "010_add_due_dates.up.sql"),
+		filepath.Join(dir, "010_add_due_dates.down.sql"),
+	}, files)
+	for _, f := range files {
+		assert.FileExists(t, f)
+	}
+
+	_, err = migrator.Create(dir, "Bad Name")
+	assert.Error(t, err)
+}
+
+func TestList_Embedded(t *testing.T) {
+	list, err := migrator.List(migrations.FS)
+	require.NoError(t, err)
+	require.NotEmpty(t, list)
+	for i, mg := range list {
+		assert.Equal(t, uint(i+1), mg.Version, "migration versions must be sequential")
+		_, err := fs.Stat(migrations.FS, fmt.Sprintf("%03d_%s.down.sql", mg.Version, mg.Name))
+		assert.NoError(t, err, "missing down migration for %03d_%s", mg.Version, mg.Name)
+	}
+}
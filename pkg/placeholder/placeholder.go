@@ -0,0 +1,39 @@
+// Package placeholder substitutes {{name}} variables in template text such as
+// task titles. Names are letters, digits and underscores; spaces inside the
+// braces are ignored. Anything else in braces is left as it is.
+package placeholder
+
+import "regexp"
+
+var pattern = regexp.MustCompile(`\{\{\s*([\p{L}_][\p{L}\p{N}_]*)\s*\}\}`)
+
+// Names returns the distinct variable names used in text in order of first
+// appearance.
+func Names(text string) []string {
+	var names []string
+	seen := make(map[string]struct{})
+	for _, m := range pattern.FindAllStringSubmatch(text, -1) {
+		if _, ok := seen[m[1]]; ok {
+			continue
+		}
+		seen[m[1]] = struct{}{}
+		names = append(names, m[1])
+	}
+	return names
+}
+
+// Expand replaces the variables in text with their values. Variables without a
+// value are kept in the text and returned as missing, in order of appearance.
+func Expand(text string, values map[string]string) (string, []string) {
+	var missing []string
+	expanded := pattern.ReplaceAllStringFunc(text, func(match string) string {
+		name := pattern.FindStringSubmatch(match)[1]
+		value, ok := values[name]
+		if !ok {
+			missing = append(missing, name)
+			return match
+		}
+		return value
+	})
+	return expanded, missing
+}
@@ -0,0 +1,42 @@
+package placeholder_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"task-api/pkg/placeholder"
+	"testing"
+)
+
+func TestNames(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{name: "none", text: "Set up laptop", want: nil},
+		{name: "single", text: "Onboard {{name}}", want: []string{"name"}},
+		{name: "spaces", text: "Onboard {{ name }} on {{start_date}}", want: []string{"name", "start_date"}},
+		{name: "duplicates", text: "{{name}} meets {{ name }}", want: []string{"name"}},
+		{name: "unicode", text: "Встреча с {{имя}}", want: []string{"имя"}},
+		{name: "not a name", text: "{{1st}} {{a-b}} {name} {{}}", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, placeholder.Names(tt.text))
+		})
+	}
+}
+
+func TestExpand(t *testing.T) {
+	text, missing := placeholder.Expand("Onboard {{ name }} ({{team}}) by {{date}}", map[string]string{
+		"name": "Alice",
+		"team": "{{date}}",
+	})
+	assert.Equal(t, "Onboard Alice ({{date}}) by {{date}}", text)
+	assert.Equal(t, []string{"date"}, missing)
+}
+
+func TestExpand_AllValues(t *testing.T) {
+	text, missing := placeholder.Expand("{{a}}{{b}}{{a}}", map[string]string{"a": "1", "b": ""})
+	assert.Equal(t, "11", text)
+	assert.Empty(t, missing)
+}
@@ -0,0 +1,81 @@
+// Package rank generates fractional indexing keys: strings that sort in the
+// order of the items they belong to, so that an item can be moved between two
+// others by giving it a new key without touching any other item.
+//
+// Keys are base-62 fractions written with the digits 0-9, A-Z and a-z, which
+// sort correctly as bytes (COLLATE "C" in PostgreSQL). A key never ends with
+// 0, so there is always room for another key before it.
+package rank
+
+import (
+	"errors"
+	"strings"
+)
+
+const digits = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+var ErrInvalid = errors.New("invalid rank")
+
+// Between returns a key that sorts after a and before b. An empty a means the
+// start and an empty b the end of the order, so Between("", "") is the first
+// key of an empty list.
+func Between(a, b string) (string, error) {
+	if !valid(a) || !valid(b) || b != "" && a >= b {
+		return "", ErrInvalid
+	}
+	return midpoint(a, b), nil
+}
+
+func valid(key string) bool {
+	for i := 0; i < len(key); i++ {
+		if strings.IndexByte(digits, key[i]) < 0 {
+			return false
+		}
+	}
+	return !strings.HasSuffix(key, digits[:1])
+}
+
+// midpoint returns a key between a and b, where a < b and an empty b is the end.
+func midpoint(a, b string) string {
+	if b != "" {
+		// Keep the common prefix, padding a with zeros.
+		n := 0
+		for n < len(b) && digitAt(a, n) == b[n] {
+			n++
+		}
+		if n > 0 {
+			return b[:n] + midpoint(suffix(a, n), b[n:])
+		}
+	}
+	digitA := 0
+	if a != "" {
+		digitA = strings.IndexByte(digits, a[0])
+	}
+	digitB := len(digits)
+	if b != "" {
+		digitB = strings.IndexByte(digits, b[0])
+	}
+	if digitB-digitA > 1 {
+		return digits[(digitA+digitB+1)/2 : (digitA+digitB+1)/2+1]
+	}
+	// The first digits are consecutive: b without its tail already lies
+	// between the two, otherwise continue after the first digit of a.
+	if len(b) > 1 {
+		return b[:1]
+	}
+	return digits[digitA:digitA+1] + midpoint(suffix(a, 1), "")
+}
+
+func digitAt(key string, i int) byte {
+	if i < len(key) {
+		return key[i]
+	}
+	return digits[0]
+}
+
+func suffix(key string, n int) string {
+	if n >= len(key) {
+		return ""
+	}
+	return key[n:]
+}
@@ -0,0 +1,77 @@
+package rank_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"math/rand"
+	"sort"
+	"task-api/pkg/rank"
+	"testing"
+)
+
+func TestBetween(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want string
+	}{
+		{a: "", b: "", want: "V"},
+		{a: "V", b: "", want: "l"},
+		{a: "", b: "V", want: "G"},
+		{a: "V", b: "W", want: "VV"},
+		{a: "V", b: "VV", want: "VG"},
+		{a: "z", b: "", want: "zV"},
+		{a: "", b: "01", want: "00V"},
+		{a: "0000000001V", b: "", want: "V"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+"_"+tt.b, func(t *testing.T) {
+			got, err := rank.Between(tt.a, tt.b)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestBetween_Invalid(t *testing.T) {
+	for _, keys := range [][2]string{
+		{"V", "V"},
+		{"W", "V"},
+		{"V0", ""},
+		{"", "a-b"},
+	} {
+		_, err := rank.Between(keys[0], keys[1])
+		assert.ErrorIs(t, err, rank.ErrInvalid, "%q %q", keys[0], keys[1])
+	}
+}
+
+// TestBetween_RandomInserts inserts keys at random positions and checks that
+// every new key fits exactly where it was put.
+func TestBetween_RandomInserts(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	var keys []string
+	for i := 0; i < 2000; i++ {
+		pos := rnd.Intn(len(keys) + 1)
+		var a, b string
+		if pos > 0 {
+			a = keys[pos-1]
+		}
+		if pos < len(keys) {
+			b = keys[pos]
+		}
+		key, err := rank.Between(a, b)
+		require.NoError(t, err)
+		require.True(t, key > a && (b == "" || key < b), "Between(%q, %q) = %q", a, b, key)
+		keys = append(keys[:pos], append([]string{key}, keys[pos:]...)...)
+	}
+	assert.True(t, sort.StringsAreSorted(keys))
+}
+
+func TestBetween_RepeatedInsertsStayShort(t *testing.T) {
+	a, b := "", ""
+	for i := 0; i < 100; i++ {
+		key, err := rank.Between(a, b)
+		require.NoError(t, err)
+		b = key
+	}
+	assert.LessOrEqual(t, len(b), 20)
+}
@@ -0,0 +1,292 @@
+// Package rrule implements the part of RFC 5545 recurrence rules that tasks
+// use: FREQ=DAILY, WEEKLY or MONTHLY with INTERVAL, BYDAY (plain weekdays,
+// weeks start on Monday) and either UNTIL or COUNT, for example
+//
+//	FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR;COUNT=10
+//
+// The start of the series (DTSTART) is passed to the methods; it is always
+// the first occurrence and counts towards COUNT.
+package rrule
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Frequency string
+
+const (
+	Daily   Frequency = "DAILY"
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+)
+
+// maxPeriods bounds the search for occurrences, so that a rule matching
+// nothing in practice cannot loop forever.
+const maxPeriods = 100000
+
+var weekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+var weekdayNames = map[time.Weekday]string{
+	time.Monday:    "MO",
+	time.Tuesday:   "TU",
+	time.Wednesday: "WE",
+	time.Thursday:  "TH",
+	time.Friday:    "FR",
+	time.Saturday:  "SA",
+	time.Sunday:    "SU",
+}
+
+var ErrInvalid = errors.New("invalid recurrence rule")
+
+// Rule is a parsed recurrence rule. Zero Until and Count mean no limit.
+type Rule struct {
+	Freq     Frequency
+	Interval int
+	ByDay    []time.Weekday
+	Until    time.Time
+	Count    int
+}
+
+// Parse parses a rule such as "FREQ=DAILY;INTERVAL=3". An "RRULE:" prefix is
+// accepted. Errors wrap ErrInvalid.
+func Parse(s string) (*Rule, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "RRULE:")
+	rule := &Rule{Interval: 1}
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(s, ";") {
+		name, value, ok := strings.Cut(part, "=")
+		name = strings.ToUpper(strings.TrimSpace(name))
+		value = strings.ToUpper(strings.TrimSpace(value))
+		if !ok || name == "" || value == "" {
+			return nil, invalid("malformed part %q", part)
+		}
+		if seen[name] {
+			return nil, invalid("%s is given twice", name)
+		}
+		seen[name] = true
+		switch name {
+		case "FREQ":
+			switch f := Frequency(value); f {
+			case Daily, Weekly, Monthly:
+				rule.Freq = f
+			default:
+				return nil, invalid("unsupported FREQ %s", value)
+			}
+		case "INTERVAL":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 || n > 1000 {
+				return nil, invalid("INTERVAL must be a number from 1 to 1000")
+			}
+			rule.Interval = n
+		case "COUNT":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 || n > 10000 {
+				return nil, invalid("COUNT must be a number from 1 to 10000")
+			}
+			rule.Count = n
+		case "UNTIL":
+			until, err := parseUntil(value)
+			if err != nil {
+				return nil, err
+			}
+			rule.Until = until
+		case "BYDAY":
+			for _, name := range strings.Split(value, ",") {
+				day, ok := weekdays[strings.TrimSpace(name)]
+				if !ok {
+					return nil, invalid("unsupported BYDAY value %q", name)
+				}
+				if !slices.Contains(rule.ByDay, day) {
+					rule.ByDay = append(rule.ByDay, day)
+				}
+			}
+		default:
+			return nil, invalid("unsupported part %s", name)
+		}
+	}
+	if rule.Freq == "" {
+		return nil, invalid("FREQ is required")
+	}
+	if rule.Count > 0 && !rule.Until.IsZero() {
+		return nil, invalid("UNTIL and COUNT cannot be combined")
+	}
+	slices.SortFunc(rule.ByDay, func(a, b time.Weekday) int { return mondayIndex(a) - mondayIndex(b) })
+	return rule, nil
+}
+
+// parseUntil accepts a UTC date-time or a date, which includes the whole day.
+func parseUntil(value string) (time.Time, error) {
+	if t, err := time.Parse("20060102T150405Z", value); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse("20060102", value); err == nil {
+		return t.Add(24*time.Hour - time.Second), nil
+	}
+	return time.Time{}, invalid("UNTIL must be YYYYMMDD or YYYYMMDDTHHMMSSZ")
+}
+
+func invalid(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ErrInvalid, fmt.Sprintf(format, args...))
+}
+
+// String formats the rule in a canonical form that Parse accepts.
+func (r *Rule) String() string {
+	parts := []string{"FREQ=" + string(r.Freq)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		names := make([]string, len(r.ByDay))
+		for i, day := range r.ByDay {
+			names[i] = weekdayNames[day]
+		}
+		parts = append(parts, "BYDAY="+strings.Join(names, ","))
+	}
+	if !r.Until.IsZero() {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	return strings.Join(parts, ";")
+}
+
+// Next returns the first occurrence after the given time and its index in the
+// series, where dtstart has index 0. It returns false when the series ends
+// before that.
+func (r *Rule) Next(dtstart, after time.Time) (time.Time, int, bool) {
+	var next time.Time
+	var index int
+	found := false
+	r.each(dtstart, func(t time.Time, i int) bool {
+		if t.After(after) {
+			next, index, found = t, i, true
+			return false
+		}
+		return true
+	})
+	return next, index, found
+}
+
+// Occurrences returns up to limit occurrences that are not before from.
+func (r *Rule) Occurrences(dtstart, from time.Time, limit int) []time.Time {
+	var result []time.Time
+	if limit <= 0 {
+		return result
+	}
+	r.each(dtstart, func(t time.Time, _ int) bool {
+		if !t.Before(from) {
+			result = append(result, t)
+		}
+		return len(result) < limit
+	})
+	return result
+}
+
+// Remaining returns the rule for the series that starts at occurrence index
+// of this one: COUNT is reduced by the occurrences already passed.
+func (r *Rule) Remaining(index int) *Rule {
+	rest := *r
+	rest.ByDay = slices.Clone(r.ByDay)
+	if rest.Count > 0 {
+		rest.Count -= index
+	}
+	return &rest
+}
+
+// each calls fn with the occurrences in order until fn returns false or the
+// series ends.
+func (r *Rule) each(dtstart time.Time, fn func(t time.Time, index int) bool) {
+	if !r.Until.IsZero() && dtstart.After(r.Until) {
+		return
+	}
+	if !fn(dtstart, 0) {
+		return
+	}
+	index := 1
+	for period := 0; period < maxPeriods; period++ {
+		for _, t := range r.period(dtstart, period) {
+			if !t.After(dtstart) {
+				continue
+			}
+			if r.Count > 0 && index >= r.Count || !r.Until.IsZero() && t.After(r.Until) {
+				return
+			}
+			if !fn(t, index) {
+				return
+			}
+			index++
+		}
+	}
+}
+
+// period returns the candidate occurrences of the n-th period in order.
+func (r *Rule) period(dtstart time.Time, n int) []time.Time {
+	y, m, d := dtstart.Date()
+	hh, mm, ss := dtstart.Clock()
+	at := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, hh, mm, ss, dtstart.Nanosecond(), dtstart.Location())
+	}
+	step := n * r.Interval
+
+	var candidates []time.Time
+	switch r.Freq {
+	case Daily:
+		day := at(y, m, d+step)
+		if r.matchesDay(day) {
+			candidates = append(candidates, day)
+		}
+	case Weekly:
+		if len(r.ByDay) == 0 {
+			return []time.Time{at(y, m, d+7*step)}
+		}
+		monday := d - mondayIndex(dtstart.Weekday()) + 7*step
+		for i := 0; i < 7; i++ {
+			if day := at(y, m, monday+i); r.matchesDay(day) {
+				candidates = append(candidates, day)
+			}
+		}
+	case Monthly:
+		first := at(y, m+time.Month(step), 1)
+		days := daysIn(first.Year(), first.Month())
+		if len(r.ByDay) == 0 {
+			// Months without the day of dtstart, like the 31st, are skipped.
+			if d <= days {
+				candidates = append(candidates, at(first.Year(), first.Month(), d))
+			}
+			return candidates
+		}
+		for day := 1; day <= days; day++ {
+			if t := at(first.Year(), first.Month(), day); r.matchesDay(t) {
+				candidates = append(candidates, t)
+			}
+		}
+	}
+	return candidates
+}
+
+func (r *Rule) matchesDay(t time.Time) bool {
+	return len(r.ByDay) == 0 || slices.Contains(r.ByDay, t.Weekday())
+}
+
+// mondayIndex numbers weekdays from Monday = 0.
+func mondayIndex(day time.Weekday) int {
+	return (int(day) + 6) % 7
+}
+
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
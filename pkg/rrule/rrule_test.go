@@ -0,0 +1,110 @@
+package rrule_test
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"task-api/pkg/rrule"
+	"testing"
+	"time"
+)
+
+func date(year int, month time.Month, day, hour int) time.Time {
+	return time.Date(year, month, day, hour, 0, 0, 0, time.UTC)
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "FREQ=DAILY", want: "FREQ=DAILY"},
+		{in: "RRULE:freq=weekly;byday=fr,mo;interval=2", want: "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR"},
+		{in: "FREQ=MONTHLY;COUNT=3", want: "FREQ=MONTHLY;COUNT=3"},
+		{in: "FREQ=DAILY;UNTIL=20261231", want: "FREQ=DAILY;UNTIL=20261231T235959Z"},
+		{in: "FREQ=WEEKLY;UNTIL=20261231T100000Z;INTERVAL=1", want: "FREQ=WEEKLY;UNTIL=20261231T100000Z"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			rule, err := rrule.Parse(tt.in)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, rule.String())
+		})
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, in := range []string{
+		"",
+		"INTERVAL=2",
+		"FREQ=YEARLY",
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=DAILY;COUNT=2;UNTIL=20260101",
+		"FREQ=WEEKLY;BYDAY=1MO",
+		"FREQ=DAILY;BYMONTH=1",
+		"FREQ=DAILY;FREQ=WEEKLY",
+		"FREQ=DAILY;UNTIL=tomorrow",
+	} {
+		t.Run(in, func(t *testing.T) {
+			_, err := rrule.Parse(in)
+			assert.True(t, errors.Is(err, rrule.ErrInvalid), "got %v", err)
+		})
+	}
+}
+
+func TestOccurrences(t *testing.T) {
+	// 2026-01-07 is a Wednesday.
+	start := date(2026, 1, 7, 9)
+	tests := []struct {
+		rule string
+		want []time.Time
+	}{
+		{rule: "FREQ=DAILY;INTERVAL=2", want: []time.Time{start, date(2026, 1, 9, 9), date(2026, 1, 11, 9), date(2026, 1, 13, 9)}},
+		{rule: "FREQ=DAILY;BYDAY=MO,FR", want: []time.Time{start, date(2026, 1, 9, 9), date(2026, 1, 12, 9), date(2026, 1, 16, 9)}},
+		{rule: "FREQ=WEEKLY", want: []time.Time{start, date(2026, 1, 14, 9), date(2026, 1, 21, 9), date(2026, 1, 28, 9)}},
+		{rule: "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR", want: []time.Time{start, date(2026, 1, 9, 9), date(2026, 1, 19, 9), date(2026, 1, 23, 9)}},
+		{rule: "FREQ=MONTHLY;BYDAY=WE", want: []time.Time{start, date(2026, 1, 14, 9), date(2026, 1, 21, 9), date(2026, 1, 28, 9)}},
+		{rule: "FREQ=WEEKLY;COUNT=2", want: []time.Time{start, date(2026, 1, 14, 9)}},
+		{rule: "FREQ=DAILY;UNTIL=20260109", want: []time.Time{start, date(2026, 1, 8, 9), date(2026, 1, 9, 9)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			rule, err := rrule.Parse(tt.rule)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, rule.Occurrences(start, start, 4))
+		})
+	}
+}
+
+func TestOccurrences_MonthlySkipsShortMonths(t *testing.T) {
+	rule, err := rrule.Parse("FREQ=MONTHLY")
+	require.NoError(t, err)
+	start := date(2026, 1, 31, 8)
+	assert.Equal(t, []time.Time{start, date(2026, 3, 31, 8), date(2026, 5, 31, 8)}, rule.Occurrences(start, start, 3))
+}
+
+func TestNext(t *testing.T) {
+	rule, err := rrule.Parse("FREQ=WEEKLY;BYDAY=FR;COUNT=3")
+	require.NoError(t, err)
+	start := date(2026, 1, 7, 9)
+
+	next, index, ok := rule.Next(start, start)
+	require.True(t, ok)
+	assert.Equal(t, date(2026, 1, 9, 9), next)
+	assert.Equal(t, 1, index)
+
+	next, index, ok = rule.Next(start, date(2026, 1, 10, 0))
+	require.True(t, ok)
+	assert.Equal(t, date(2026, 1, 16, 9), next)
+	assert.Equal(t, 2, index)
+
+	_, _, ok = rule.Next(start, date(2026, 1, 16, 9))
+	assert.False(t, ok)
+}
+
+func TestRemaining(t *testing.T) {
+	rule, err := rrule.Parse("FREQ=DAILY;COUNT=5")
+	require.NoError(t, err)
+	assert.Equal(t, "FREQ=DAILY;COUNT=3", rule.Remaining(2).String())
+	assert.Equal(t, "FREQ=DAILY;COUNT=5", rule.String())
+}
@@ -0,0 +1,147 @@
+// Package taskquery parses the task search language, for example
+//
+//	status:in_progress tag:backend due<2026-11-01 -tag:wontfix
+//
+// Terms separated by spaces must all match; OR between terms matches either
+// side and binds weaker than the implicit AND. A leading - negates a term or a
+// parenthesised group. A word without a field searches the title and the
+// description; values with spaces are written in double quotes.
+package taskquery
+
+import (
+	"strings"
+	"time"
+)
+
+// Field is what a term compares.
+type Field string
+
+const (
+	FieldText    Field = "text"
+	FieldStatus  Field = "status"
+	FieldTag     Field = "tag"
+	FieldTitle   Field = "title"
+	FieldDue     Field = "due"
+	FieldCreated Field = "created"
+)
+
+// IsDate reports whether the field holds a date and accepts comparisons.
+func (f Field) IsDate() bool {
+	return f == FieldDue || f == FieldCreated
+}
+
+// Op is the comparison between a field and its value.
+type Op string
+
+const (
+	OpEq Op = ":"
+	OpLt Op = "<"
+	OpLe Op = "<="
+	OpGt Op = ">"
+	OpGe Op = ">="
+)
+
+// DateLayout is the format of date values; dates are days in UTC.
+const DateLayout = "2006-01-02"
+
+// None is the date value matching tasks without that date, as in due:none.
+const None = "none"
+
+// Expr is a parsed query: *Term, And, Or or Not.
+type Expr interface {
+	String() string
+}
+
+// Term is a single condition. Date fields carry the day in Date, or Empty for
+// the none value; other fields carry Value.
+type Term struct {
+	Field Field
+	Op    Op
+	Value string
+	Date  time.Time
+	Empty bool
+	// Pos is the 1-based character position of the term in the query.
+	Pos int
+}
+
+// And matches when every expression matches.
+type And []Expr
+
+// Or matches when at least one expression matches.
+type Or []Expr
+
+// Not matches when the expression does not.
+type Not struct {
+	Expr Expr
+}
+
+// String formats the term so that parsing it gives the same term back.
+func (t *Term) String() string {
+	if t.Field == FieldText {
+		return quote(t.Value, true)
+	}
+	switch {
+	case t.Empty:
+		return string(t.Field) + string(t.Op) + None
+	case t.Field.IsDate():
+		return string(t.Field) + string(t.Op) + t.Date.Format(DateLayout)
+	default:
+		return string(t.Field) + string(t.Op) + quote(t.Value, false)
+	}
+}
+
+func (a And) String() string {
+	return join(a, " ")
+}
+
+func (o Or) String() string {
+	return join(o, " OR ")
+}
+
+func (n Not) String() string {
+	return "-" + group(n.Expr)
+}
+
+func join(exprs []Expr, sep string) string {
+	parts := make([]string, len(exprs))
+	for i, e := range exprs {
+		parts[i] = group(e)
+	}
+	return strings.Join(parts, sep)
+}
+
+// group wraps compound expressions in parentheses so that nesting survives a
+// round trip through String and Parse.
+func group(e Expr) string {
+	switch e.(type) {
+	case And, Or:
+		return "(" + e.String() + ")"
+	default:
+		return e.String()
+	}
+}
+
+// quote writes a value in double quotes when it would not read back as the
+// same bare word. Free text is also quoted when it could be taken for a field
+// term, a negation or the OR keyword.
+func quote(value string, text bool) string {
+	bare := value != "" && !strings.ContainsFunc(value, func(r rune) bool {
+		return isDelimiter(r) || r == '"' || r == '\\'
+	})
+	if text && bare {
+		bare = value != keywordOr && !strings.HasPrefix(value, "-") && !strings.ContainsAny(value, ":<>")
+	}
+	if bare {
+		return value
+	}
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range value {
+		if r == '"' || r == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	b.WriteByte('"')
+	return b.String()
+}
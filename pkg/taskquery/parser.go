@@ -0,0 +1,327 @@
+package taskquery
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// MaxDepth limits how deeply groups and negations may nest.
+const MaxDepth = 32
+
+const keywordOr = "OR"
+
+var fields = map[string]Field{
+	string(FieldStatus):  FieldStatus,
+	string(FieldTag):     FieldTag,
+	string(FieldTitle):   FieldTitle,
+	string(FieldDue):     FieldDue,
+	string(FieldCreated): FieldCreated,
+}
+
+// SyntaxError describes why a query could not be parsed. Pos is the 1-based
+// character position of the problem; the end of the query is one past its
+// last character.
+type SyntaxError struct {
+	Pos int
+	Msg string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("syntax error at position %d: %s", e.Pos, e.Msg)
+}
+
+// Parse parses a query. An empty or blank query returns a nil Expr. Errors are
+// always *SyntaxError.
+func Parse(query string) (Expr, error) {
+	tokens, err := lex([]rune(query))
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	if p.peek().kind == tokenEOF {
+		return nil, nil
+	}
+	expr, err := p.parseOr(0)
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, &SyntaxError{Pos: tok.pos, Msg: "unexpected )"}
+	}
+	return expr, nil
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenTerm
+	tokenOr
+	tokenMinus
+	tokenLParen
+	tokenRParen
+)
+
+type token struct {
+	kind tokenKind
+	pos  int
+	term *Term
+}
+
+func isDelimiter(r rune) bool {
+	return unicode.IsSpace(r) || r == '(' || r == ')'
+}
+
+func isFieldRune(r rune) bool {
+	return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r == '_'
+}
+
+// lex splits the query into tokens. Positions are counted in characters and
+// reported 1-based.
+func lex(input []rune) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(input); {
+		r := input[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenLParen, pos: i + 1})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenRParen, pos: i + 1})
+			i++
+		case r == '-':
+			if i+1 == len(input) || unicode.IsSpace(input[i+1]) {
+				return nil, &SyntaxError{Pos: i + 1, Msg: "expected a term after -"}
+			}
+			tokens = append(tokens, token{kind: tokenMinus, pos: i + 1})
+			i++
+		default:
+			tok, next, err := lexTerm(input, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, tok)
+			i = next
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, pos: len(input) + 1}), nil
+}
+
+// lexTerm reads a field term, a quoted phrase or a bare word starting at i and
+// returns the index after it.
+func lexTerm(input []rune, i int) (token, int, error) {
+	start := i
+	if input[i] == '"' {
+		value, next, err := readQuoted(input, i)
+		if err != nil {
+			return token{}, 0, err
+		}
+		return token{kind: tokenTerm, pos: start + 1, term: &Term{Field: FieldText, Value: value, Pos: start + 1}}, next, nil
+	}
+
+	j := i
+	for j < len(input) && isFieldRune(input[j]) {
+		j++
+	}
+	if j == i || j == len(input) || !strings.ContainsRune(":<>", input[j]) {
+		end := i
+		for end < len(input) && !isDelimiter(input[end]) {
+			end++
+		}
+		word := string(input[i:end])
+		if word == keywordOr {
+			return token{kind: tokenOr, pos: start + 1}, end, nil
+		}
+		return token{kind: tokenTerm, pos: start + 1, term: &Term{Field: FieldText, Value: word, Pos: start + 1}}, end, nil
+	}
+
+	name := strings.ToLower(string(input[i:j]))
+	field, ok := fields[name]
+	if !ok {
+		return token{}, 0, &SyntaxError{Pos: start + 1, Msg: fmt.Sprintf("unknown field %q", name)}
+	}
+	op := Op(input[j])
+	j++
+	if op != OpEq && j < len(input) && input[j] == '=' {
+		op += "="
+		j++
+	}
+	if op != OpEq && !field.IsDate() {
+		return token{}, 0, &SyntaxError{Pos: start + 1, Msg: fmt.Sprintf("field %s does not support %s", field, op)}
+	}
+
+	valuePos := j + 1
+	var value string
+	if j < len(input) && input[j] == '"' {
+		var err error
+		if value, j, err = readQuoted(input, j); err != nil {
+			return token{}, 0, err
+		}
+	} else {
+		end := j
+		for end < len(input) && !isDelimiter(input[end]) {
+			end++
+		}
+		value, j = string(input[j:end]), end
+	}
+	if value == "" {
+		return token{}, 0, &SyntaxError{Pos: valuePos, Msg: fmt.Sprintf("missing value for %s", field)}
+	}
+
+	term := &Term{Field: field, Op: op, Value: value, Pos: start + 1}
+	if field.IsDate() {
+		if err := parseDate(term, valuePos); err != nil {
+			return token{}, 0, err
+		}
+	}
+	return token{kind: tokenTerm, pos: start + 1, term: term}, j, nil
+}
+
+// parseDate fills in the date of a date term; only none or a YYYY-MM-DD day
+// are accepted, and none only with ":".
+func parseDate(term *Term, pos int) error {
+	if strings.EqualFold(term.Value, None) {
+		if term.Op != OpEq {
+			return &SyntaxError{Pos: pos, Msg: fmt.Sprintf("%s cannot be compared with %s", None, term.Op)}
+		}
+		term.Value, term.Empty = "", true
+		return nil
+	}
+	day, err := time.Parse(DateLayout, term.Value)
+	if err != nil {
+		return &SyntaxError{Pos: pos, Msg: fmt.Sprintf("invalid date %q, expected YYYY-MM-DD or %s", term.Value, None)}
+	}
+	term.Value, term.Date = "", day
+	return nil
+}
+
+// readQuoted reads a double-quoted string starting at i, where a backslash
+// escapes the next character, and returns the index after the closing quote.
+func readQuoted(input []rune, i int) (string, int, error) {
+	start := i
+	var b strings.Builder
+	for i++; i < len(input); i++ {
+		switch input[i] {
+		case '"':
+			return b.String(), i + 1, nil
+		case '\\':
+			i++
+			if i == len(input) {
+				return "", 0, &SyntaxError{Pos: start + 1, Msg: "unterminated quoted string"}
+			}
+		}
+		b.WriteRune(input[i])
+	}
+	return "", 0, &SyntaxError{Pos: start + 1, Msg: "unterminated quoted string"}
+}
+
+type parser struct {
+	tokens []token
+	next   int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.next]
+}
+
+func (p *parser) advance() token {
+	tok := p.tokens[p.next]
+	if tok.kind != tokenEOF {
+		p.next++
+	}
+	return tok
+}
+
+func (p *parser) parseOr(depth int) (Expr, error) {
+	first, err := p.parseAnd(depth)
+	if err != nil {
+		return nil, err
+	}
+	or := Or{first}
+	for p.peek().kind == tokenOr {
+		p.advance()
+		next, err := p.parseAnd(depth)
+		if err != nil {
+			return nil, err
+		}
+		or = append(or, next)
+	}
+	if len(or) == 1 {
+		return first, nil
+	}
+	return or, nil
+}
+
+func (p *parser) parseAnd(depth int) (Expr, error) {
+	var and And
+	for {
+		switch p.peek().kind {
+		case tokenEOF, tokenRParen, tokenOr:
+			if len(and) == 0 {
+				return nil, p.expectedTerm()
+			}
+			if len(and) == 1 {
+				return and[0], nil
+			}
+			return and, nil
+		}
+		expr, err := p.parseUnary(depth)
+		if err != nil {
+			return nil, err
+		}
+		and = append(and, expr)
+	}
+}
+
+func (p *parser) parseUnary(depth int) (Expr, error) {
+	tok := p.advance()
+	switch tok.kind {
+	case tokenTerm:
+		return tok.term, nil
+	case tokenMinus:
+		if depth >= MaxDepth {
+			return nil, &SyntaxError{Pos: tok.pos, Msg: "query is nested too deeply"}
+		}
+		switch p.peek().kind {
+		case tokenTerm, tokenLParen, tokenMinus:
+		default:
+			return nil, &SyntaxError{Pos: tok.pos, Msg: "expected a term after -"}
+		}
+		expr, err := p.parseUnary(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		return Not{Expr: expr}, nil
+	case tokenLParen:
+		if depth >= MaxDepth {
+			return nil, &SyntaxError{Pos: tok.pos, Msg: "query is nested too deeply"}
+		}
+		expr, err := p.parseOr(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		if p.peek().kind != tokenRParen {
+			return nil, &SyntaxError{Pos: tok.pos, Msg: "missing )"}
+		}
+		p.advance()
+		return expr, nil
+	default:
+		return nil, &SyntaxError{Pos: tok.pos, Msg: "unexpected token"}
+	}
+}
+
+func (p *parser) expectedTerm() error {
+	tok := p.peek()
+	switch tok.kind {
+	case tokenEOF:
+		return &SyntaxError{Pos: tok.pos, Msg: "unexpected end of query, expected a term"}
+	case tokenRParen:
+		return &SyntaxError{Pos: tok.pos, Msg: "unexpected ), expected a term"}
+	default:
+		return &SyntaxError{Pos: tok.pos, Msg: "unexpected OR, expected a term"}
+	}
+}
@@ -0,0 +1,146 @@
+package taskquery_test
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"task-api/pkg/taskquery"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{name: "example", query: "status:in_progress tag:backend due<2026-11-01 -tag:wontfix", want: "status:in_progress tag:backend due<2026-11-01 -tag:wontfix"},
+		{name: "free text", query: "login bug", want: "login bug"},
+		{name: "quoted phrase", query: `"login page" title:"sign in"`, want: `"login page" title:"sign in"`},
+		{name: "escaped quote", query: `"say \"hi\""`, want: `"say \"hi\""`},
+		{name: "field case", query: "STATUS:done", want: "status:done"},
+		{name: "date operators", query: "due>=2026-01-01 created<=2026-02-01 due>2026-03-01", want: "due>=2026-01-01 created<=2026-02-01 due>2026-03-01"},
+		{name: "none", query: "due:NONE", want: "due:none"},
+		{name: "or binds weaker than and", query: "tag:a tag:b OR tag:c", want: "(tag:a tag:b) OR tag:c"},
+		{name: "groups", query: "status:new (tag:a OR tag:b)", want: "status:new (tag:a OR tag:b)"},
+		{name: "negated group", query: "-(tag:a OR tag:b)", want: "-(tag:a OR tag:b)"},
+		{name: "redundant parentheses", query: "((tag:a))", want: "tag:a"},
+		{name: "lowercase or is a word", query: "this or that", want: "this or that"},
+		{name: "number with colon is text", query: "12:30", want: `"12:30"`},
+		{name: "unicode", query: "tag:срочно отчёт", want: "tag:срочно отчёт"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := taskquery.Parse(tt.query)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, expr.String())
+		})
+	}
+}
+
+func TestParse_Empty(t *testing.T) {
+	for _, query := range []string{"", "   ", "\t\n"} {
+		expr, err := taskquery.Parse(query)
+		require.NoError(t, err)
+		assert.Nil(t, expr)
+	}
+}
+
+func TestParse_Terms(t *testing.T) {
+	expr, err := taskquery.Parse("due<2026-11-01 -due:none")
+	require.NoError(t, err)
+	and, ok := expr.(taskquery.And)
+	require.True(t, ok)
+	require.Len(t, and, 2)
+
+	assert.Equal(t, &taskquery.Term{
+		Field: taskquery.FieldDue,
+		Op:    taskquery.OpLt,
+		Date:  time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC),
+		Pos:   1,
+	}, and[0])
+	assert.Equal(t, taskquery.Not{Expr: &taskquery.Term{Field: taskquery.FieldDue, Op: taskquery.OpEq, Empty: true, Pos: 17}}, and[1])
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		query string
+		pos   int
+		msg   string
+	}{
+		{query: "priority:high", pos: 1, msg: `unknown field "priority"`},
+		{query: "tag:a status:", pos: 14, msg: "missing value for status"},
+		{query: "status<done", pos: 1, msg: "field status does not support <"},
+		{query: "due<tomorrow", pos: 5, msg: `invalid date "tomorrow", expected YYYY-MM-DD or none`},
+		{query: "due<none", pos: 5, msg: "none cannot be compared with <"},
+		{query: `tag:a "open`, pos: 7, msg: "unterminated quoted string"},
+		{query: "(tag:a", pos: 1, msg: "missing )"},
+		{query: "tag:a)", pos: 6, msg: "unexpected )"},
+		{query: "tag:a -", pos: 7, msg: "expected a term after -"},
+		{query: "- tag:a", pos: 1, msg: "expected a term after -"},
+		{query: "tag:a OR", pos: 9, msg: "unexpected end of query, expected a term"},
+		{query: "OR tag:a", pos: 1, msg: "unexpected OR, expected a term"},
+		{query: "()", pos: 2, msg: "unexpected ), expected a term"},
+		{query: "отчёт tag:", pos: 11, msg: "missing value for tag"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			_, err := taskquery.Parse(tt.query)
+			var syntaxErr *taskquery.SyntaxError
+			require.True(t, errors.As(err, &syntaxErr), "got %v", err)
+			assert.Equal(t, tt.pos, syntaxErr.Pos)
+			assert.Equal(t, tt.msg, syntaxErr.Msg)
+		})
+	}
+}
+
+func TestParse_DepthLimit(t *testing.T) {
+	deep := ""
+	for i := 0; i < taskquery.MaxDepth+1; i++ {
+		deep += "("
+	}
+	_, err := taskquery.Parse(deep + "tag:a")
+	var syntaxErr *taskquery.SyntaxError
+	require.True(t, errors.As(err, &syntaxErr))
+	assert.Equal(t, "query is nested too deeply", syntaxErr.Msg)
+}
+
+func FuzzParse(f *testing.F) {
+	for _, seed := range []string{
+		"status:in_progress tag:backend due<2026-11-01 -tag:wontfix",
+		`title:"a \"b\" c" OR -(due:none created>=2026-01-01)`,
+		"((a OR b) c) -d",
+		`"" -"-x" "OR" tag:OR`,
+		"due<=2026-02-30",
+		"--(x",
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, query string) {
+		expr, err := taskquery.Parse(query)
+		if err != nil {
+			var syntaxErr *taskquery.SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("Parse(%q) returned %T, want *SyntaxError", query, err)
+			}
+			if n := utf8.RuneCountInString(query); syntaxErr.Pos < 1 || syntaxErr.Pos > n+1 {
+				t.Fatalf("Parse(%q): position %d outside 1..%d", query, syntaxErr.Pos, n+1)
+			}
+			return
+		}
+		if expr == nil {
+			return
+		}
+		// The formatted query must parse back to the same expression.
+		formatted := expr.String()
+		again, err := taskquery.Parse(formatted)
+		if err != nil {
+			t.Fatalf("Parse(%q) = %q, which does not parse: %v", query, formatted, err)
+		}
+		if again == nil || again.String() != formatted {
+			t.Fatalf("Parse(%q) = %q, reparsed as %v", query, formatted, again)
+		}
+	})
+}